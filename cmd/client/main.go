@@ -3,12 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	pb "github.com/pandae7/go-blogger/proto/blog"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -17,31 +20,40 @@ func main() {
 		"localhost:8080",
 		grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
-		log.Fatal("Failed to connect to server: %v", err)
+		log.Fatalf("Failed to connect to server: %v", err)
 	}
 	defer conn.Close()
 
 	client := pb.NewBlogServiceClient(conn)
+	authorClient := pb.NewAuthorServiceClient(conn)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	fmt.Println("Creating the author...")
+	author := findOrCreateAuthor(ctx, authorClient, &pb.CreateAuthorRequest{
+		Name:        "Aman Pandae",
+		Email:       "aman@example.com",
+		ProfileName: "aman",
+	})
+	fmt.Printf("Posting as %s (%s)\n", author.Name, author.AuthorId)
+
 	fmt.Println("Creating a new blog post...")
 	createBlogReq := &pb.CreateBlogPostRequest{
 		Title:           "My First Blog Post",
 		Content:         "This is the content of my first blog post.",
-		Author:          "Aman Pandae",
+		AuthorId:        author.AuthorId,
 		PublicationDate: timestamppb.Now(),
 		Tags:            []string{"trending", "topic", "cloud"},
 	}
 
 	createBlogResp, err := client.CreateBlogPost(ctx, createBlogReq)
 	if err != nil {
-		log.Fatal("Failed to create blog post: %v", err)
+		log.Fatalf("Failed to create blog post: %v", err)
 	}
 
 	if !createBlogResp.Success {
-		log.Fatal("Failed to create blog post: %s", createBlogResp.Message)
+		log.Fatalf("Failed to create blog post: %s", createBlogResp.Message)
 	}
 
 	fmt.Printf("Blog post created successfully with ID: %s\n", createBlogResp.Post.PostId)
@@ -54,47 +66,119 @@ func main() {
 
 	getBlogResp, err := client.GetBlogPost(ctx, getBlogReq)
 	if err != nil {
-		log.Fatal("Failed to fetch blog post: %v", err)
+		log.Fatalf("Failed to fetch blog post: %v", err)
 	}
 
 	if !getBlogResp.Success {
-		log.Fatal("Failed to fetch blog post: %s", getBlogResp.Message)
+		log.Fatalf("Failed to fetch blog post: %s", getBlogResp.Message)
 	}
 	fmt.Printf("Blog post fetched successfully with ID: %s\n", getBlogResp.Post.PostId)
 	printBlogPostDetails(getBlogResp.Post)
 
+	fmt.Println("Listing blog posts...")
+	listBlogReq := &pb.ListBlogPostsRequest{
+		PageSize: 10,
+	}
+	listBlogResp, err := client.ListBlogPosts(ctx, listBlogReq)
+	if err != nil {
+		log.Fatalf("Failed to list blog posts: %v", err)
+	}
+
+	if !listBlogResp.Success {
+		log.Fatalf("Failed to list blog posts: %s", listBlogResp.Message)
+	}
+	fmt.Printf("Listed %d blog post(s)\n", len(listBlogResp.Posts))
+	for _, post := range listBlogResp.Posts {
+		fmt.Printf("  - %s: %s\n", post.PostId, post.Title)
+	}
+
 	fmt.Println("Updating the blog post...")
 	updateBlogReq := &pb.UpdateBlogPostRequest{
 		PostId:  createBlogResp.Post.PostId,
 		Title:   "Updated Blog Post Title",
 		Content: "This is the updated content of my blog post.",
 		Tags:    []string{"news", "trends", "views", "updates"},
+		// Only apply the update if nobody changed the post since we created it
+		ExpectedVersion: createBlogResp.Post.Version,
 	}
 	updateBlogResp, err := client.UpdateBlogPost(ctx, updateBlogReq)
 	if err != nil {
-		log.Fatal("Failed to update blog post: %v", err)
+		log.Fatalf("Failed to update blog post: %v", err)
 	}
 
 	if !updateBlogResp.Success {
-		log.Fatal("Failed to update blog post: %s", updateBlogResp.Message)
+		log.Fatalf("Failed to update blog post: %s", updateBlogResp.Message)
 	}
 
 	fmt.Printf("Blog post updated successfully with ID: %s\n", updateBlogResp.Post.PostId)
 	printBlogPostDetails(updateBlogResp.Post)
 
+	fmt.Println("Listing the revisions of the blog post...")
+	revisionsResp, err := client.ListPostRevisions(ctx, &pb.ListPostRevisionsRequest{PostId: createBlogResp.Post.PostId})
+	if err != nil {
+		log.Fatalf("Failed to list post revisions: %v", err)
+	}
+	for _, revision := range revisionsResp.Revisions {
+		fmt.Printf("  - revision %d: %s\n", revision.Revision, revision.Title)
+	}
+
 	fmt.Println("Deleting the blog post...")
 	deleteBlogReq := &pb.DeleteBlogPostRequest{
-		PostId: createBlogResp.Post.PostId,
+		PostId:          createBlogResp.Post.PostId,
+		ExpectedVersion: updateBlogResp.Post.Version,
 	}
 	deleteBlogResp, err := client.DeleteBlogPost(ctx, deleteBlogReq)
 	if err != nil {
-		log.Fatal("Failed to delete blog post: %v", err)
+		log.Fatalf("Failed to delete blog post: %v", err)
 	}
 	if !deleteBlogResp.Success {
-		log.Fatal("Failed to delete blog post: %s", deleteBlogResp.Message)
+		log.Fatalf("Failed to delete blog post: %s", deleteBlogResp.Message)
 	}
-	fmt.Printf("Blog post deleted successfully with ID: %s\n", deleteBlogReq.PostId)
+	fmt.Printf("Blog post moved to the trash with ID: %s\n", deleteBlogReq.PostId)
 
+	fmt.Println("Restoring the blog post from the trash...")
+	restoreBlogResp, err := client.RestoreBlogPost(ctx, &pb.RestoreBlogPostRequest{PostId: deleteBlogReq.PostId})
+	if err != nil {
+		log.Fatalf("Failed to restore blog post: %v", err)
+	}
+	fmt.Printf("Blog post restored successfully with ID: %s\n", restoreBlogResp.Post.PostId)
+
+	fmt.Println("Deleting the blog post permanently...")
+	_, err = client.DeleteBlogPost(ctx, &pb.DeleteBlogPostRequest{PostId: deleteBlogReq.PostId, Permanent: true})
+	if err != nil {
+		log.Fatalf("Failed to delete blog post: %v", err)
+	}
+	fmt.Printf("Blog post deleted permanently with ID: %s\n", deleteBlogReq.PostId)
+
+}
+
+// findOrCreateAuthor creates the author, or finds it when an earlier run
+// already created it.
+func findOrCreateAuthor(ctx context.Context, client pb.AuthorServiceClient, req *pb.CreateAuthorRequest) *pb.Author {
+	createAuthorResp, err := client.CreateAuthor(ctx, req)
+	if err == nil {
+		return createAuthorResp.Author
+	}
+	if status.Code(err) != codes.AlreadyExists {
+		log.Fatalf("Failed to create author: %v", err)
+	}
+
+	listAuthorsReq := &pb.ListAuthorsRequest{}
+	for {
+		listAuthorsResp, err := client.ListAuthors(ctx, listAuthorsReq)
+		if err != nil {
+			log.Fatalf("Failed to list authors: %v", err)
+		}
+		for _, author := range listAuthorsResp.Authors {
+			if strings.EqualFold(author.ProfileName, req.ProfileName) {
+				return author
+			}
+		}
+		if listAuthorsResp.NextPageToken == "" {
+			log.Fatalf("Author %q exists but could not be found", req.ProfileName)
+		}
+		listAuthorsReq.PageToken = listAuthorsResp.NextPageToken
+	}
 }
 
 func printBlogPostDetails(post *pb.BlogPost) {
@@ -106,5 +190,7 @@ func printBlogPostDetails(post *pb.BlogPost) {
 	fmt.Printf("Publication Date: %s\n", post.PublicationDate.AsTime().Format(time.RFC3339))
 	fmt.Printf("Tags: %v\n", post.Tags)
 	fmt.Printf("Updated At: %s\n", post.UpdatedAt.AsTime().Format(time.RFC3339))
+	fmt.Printf("Version: %d\n", post.Version)
+	fmt.Printf("Status: %s\n", post.Status)
 	fmt.Println("*******************************")
 }
@@ -1,63 +1,162 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sync"
 	"syscall"
 
+	"github.com/pandae7/go-blogger/internal/jobs"
 	"github.com/pandae7/go-blogger/internal/server"
 	storage "github.com/pandae7/go-blogger/internal/storage"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
 	pb "github.com/pandae7/go-blogger/proto/blog"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
 const (
-	defaultPort = "8080"
-	defaultHost = "localhost"
+	defaultPort    = "8080"
+	defaultHost    = "localhost"
+	defaultDataDir = "data"
+	sqliteFileName = "blog.db"
 )
 
 func main() {
 	port := defaultPort
 	host := defaultHost
 
+	backend := flag.String("storage", "memory", "storage backend: memory, file or sqlite")
+	dataDir := flag.String("data-dir", defaultDataDir, "directory for the file and sqlite storage backends")
+	syncPolicy := flag.String("sync", "always", "write-ahead log sync policy for the file backend: always, interval or never")
+	trashRetention := flag.Duration("trash-retention", jobs.DefaultTrashRetention, "how long deleted posts stay in the trash before they are purged, 0 to keep them until purged by hand")
+	requestRetention := flag.Duration("request-retention", server.DefaultRequestRetention, "how long the request_id of a create is remembered, so the create can be retried without creating a second post")
+	publishInterval := flag.Duration("publish-interval", jobs.DefaultPublishInterval, "how often scheduled posts are checked for being due")
+	lowercaseTags := flag.Bool("lowercase-tags", true, "fold tags to lower case")
+	maxTagLength := flag.Int("max-tag-length", tagpolicy.DefaultMaxLength, "maximum length of a tag in characters, 0 for no limit")
+	maxTags := flag.Int("max-tags", tagpolicy.DefaultMaxCount, "maximum number of tags on a post, 0 for no limit")
+	flag.Parse()
+
+	blogStorage, err := newStorage(*backend, *dataDir, *syncPolicy)
+	if err != nil {
+		log.Fatalf("Failed to open %s storage: %v", *backend, err)
+	}
+
 	// Create network listener
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
 
-	log.Infof("Starting server on %s:%s", host, port)
-
-	storage := storage.NewBlogStorage()
+	log.Infof("Starting server on %s:%s with %s storage", host, port, *backend)
 
-	// Create a new gRPC server instance
-	newServer := grpc.NewServer()
+	// Create a new gRPC server instance, translating handler errors into
+	// gRPC status codes
+	newServer := grpc.NewServer(
+		grpc.UnaryInterceptor(server.UnaryErrorInterceptor),
+		grpc.StreamInterceptor(server.StreamErrorInterceptor),
+	)
 
 	// creating a default blog service server for now
-	blogserver := server.NewBlogServiceServer(storage)
+	blogserver := server.NewBlogServiceServer(blogStorage)
+	tagPolicy := tagpolicy.Default()
+	tagPolicy.Lowercase = *lowercaseTags
+	tagPolicy.MaxLength = *maxTagLength
+	tagPolicy.MaxCount = *maxTags
+	blogserver.SetTagPolicy(tagPolicy)
+	blogserver.SetRequestRetention(*requestRetention)
 
 	// register blog service server
 	pb.RegisterBlogServiceServer(newServer, blogserver)
+	// authors live in the same storage as the posts that reference them
+	pb.RegisterAuthorServiceServer(newServer, server.NewAuthorServiceServer(blogStorage))
+	tagserver := server.NewTagServiceServer(blogStorage)
+	tagserver.SetTagPolicy(tagPolicy)
+	pb.RegisterTagServiceServer(newServer, tagserver)
 	// Print server information
 	printServerInfo(host, port)
 
-	if err := newServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	// Publish scheduled posts, forget expired idempotency keys and purge
+	// expired posts from the trash in the background
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobsWG sync.WaitGroup
+	scheduler := jobs.NewScheduler(blogStorage, *publishInterval)
+	jobsWG.Add(1)
+	go func() {
+		defer jobsWG.Done()
+		scheduler.Run(jobsCtx)
+	}()
+	expirer := jobs.NewExpirer(blogStorage, jobs.DefaultExpireInterval)
+	jobsWG.Add(1)
+	go func() {
+		defer jobsWG.Done()
+		expirer.Run(jobsCtx)
+	}()
+	if *trashRetention > 0 {
+		reaper := jobs.NewReaper(blogStorage, *trashRetention, jobs.DefaultReapInterval)
+		jobsWG.Add(1)
+		go func() {
+			defer jobsWG.Done()
+			reaper.Run(jobsCtx)
+		}()
+		log.Infof("Purging posts from the trash after %s", *trashRetention)
 	}
 
+	go func() {
+		if err := newServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve: %v", err)
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutting down gRPC server...")
+	// Watch streams never finish on their own and would hold up the stop
+	blogserver.StopWatches()
 	newServer.GracefulStop()
+	// Stop the background jobs before the storage they use is closed
+	stopJobs()
+	jobsWG.Wait()
+	if closer, ok := blogStorage.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Errorf("Failed to close storage: %v", err)
+		}
+	}
 	log.Println("Server stopped")
 }
 
+// newStorage creates the storage backend selected on the command line.
+func newStorage(backend, dataDir, syncPolicy string) (storage.Storage, error) {
+	switch backend {
+	case "memory":
+		return storage.NewBlogStorage(), nil
+	case "file":
+		policy, err := storage.ParseSyncPolicy(syncPolicy)
+		if err != nil {
+			return nil, err
+		}
+		return storage.NewFileBlogStorage(storage.FileStorageOptions{
+			Dir:        dataDir,
+			SyncPolicy: policy,
+		})
+	case "sqlite":
+		if err := os.MkdirAll(dataDir, 0o755); err != nil {
+			return nil, err
+		}
+		return storage.NewSQLBlogStorage(filepath.Join(dataDir, sqliteFileName))
+	}
+	return nil, fmt.Errorf("unknown storage backend %q", backend)
+}
+
 func printServerInfo(host string, port string) {
 	fmt.Println("===========================================")
 	fmt.Println("          gRPC Blog Service")
@@ -65,8 +164,29 @@ func printServerInfo(host string, port string) {
 	fmt.Printf("Server Address: %s:%s\n", host, port)
 	fmt.Println("Available Methods:")
 	fmt.Println("  - CreateBlogPost")
+	fmt.Println("  - ImportBlogPosts")
 	fmt.Println("  - GetBlogPost")
+	fmt.Println("  - BatchGetBlogPosts")
 	fmt.Println("  - UpdateBlogPost")
 	fmt.Println("  - DeleteBlogPost")
+	fmt.Println("  - BatchDeleteBlogPosts")
+	fmt.Println("  - RestoreBlogPost")
+	fmt.Println("  - PurgeBlogPost")
+	fmt.Println("  - PublishBlogPost")
+	fmt.Println("  - UnpublishBlogPost")
+	fmt.Println("  - ArchiveBlogPost")
+	fmt.Println("  - ListBlogPosts")
+	fmt.Println("  - ListBlogPostsByAuthor")
+	fmt.Println("  - ListBlogPostsByTag")
+	fmt.Println("  - SearchBlogPosts")
+	fmt.Println("  - GetRelatedPosts")
+	fmt.Println("  - ExportBlogPosts")
+	fmt.Println("  - WatchBlogPosts")
+	fmt.Println("  - ListPostRevisions")
+	fmt.Println("  - GetPostRevision")
+	fmt.Println("  - RestorePostRevision")
+	fmt.Println("  - DiffPostRevisions")
+	fmt.Println("  - AuthorService: CreateAuthor, GetAuthor, UpdateAuthor, DeleteAuthor, ListAuthors")
+	fmt.Println("  - TagService: ListTags, GetTag, RenameTag, MergeTags, SuggestTags")
 	fmt.Println("===========================================")
 }
@@ -0,0 +1,291 @@
+// Package diff compares texts line by line or word by word.
+//
+// Lines and Words return the edit script as a list of spans, each of which is
+// kept, inserted or deleted. Unified renders a line diff in the familiar
+// unified format:
+//
+//	--- a
+//	+++ b
+//	@@ -1,2 +1,2 @@
+//	 unchanged
+//	-old line
+//	+new line
+//
+// Edit scripts are computed with Myers' O(ND) algorithm. Texts that differ in
+// more than MaxEdits tokens are not compared in detail: the differing middle
+// is reported as one deletion followed by one insertion.
+package diff
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxEdits bounds the work spent on a single comparison.
+const MaxEdits = 1000
+
+// Op says what happened to the text of a span.
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+func (op Op) String() string {
+	switch op {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	}
+	return "equal"
+}
+
+// Span is a run of text that was kept, inserted or deleted.
+type Span struct {
+	Op   Op
+	Text string
+}
+
+// Changed reports whether spans contain any insertion or deletion.
+func Changed(spans []Span) bool {
+	for _, s := range spans {
+		if s.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Lines diffs a and b line by line. Every span holds one or more whole lines,
+// including their line terminators.
+func Lines(a, b string) []Span {
+	return merge(diff(splitLines(a), splitLines(b)))
+}
+
+// Words diffs a and b word by word. Words are runs of letters and digits;
+// whitespace runs and punctuation characters are tokens of their own, so
+// reflowing or repunctuating a sentence only touches the affected tokens.
+func Words(a, b string) []Span {
+	return merge(diff(splitWords(a), splitWords(b)))
+}
+
+// splitLines splits s after every newline. A last line without a newline is
+// kept as is.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func splitWords(s string) []string {
+	var tokens []string
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		n := size
+		if class := runeClass(r); class != punct {
+			for n < len(s) {
+				r, size := utf8.DecodeRuneInString(s[n:])
+				if runeClass(r) != class {
+					break
+				}
+				n += size
+			}
+		}
+		tokens = append(tokens, s[:n])
+		s = s[n:]
+	}
+	return tokens
+}
+
+const (
+	word = iota
+	space
+	punct
+)
+
+func runeClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return word
+	case unicode.IsSpace(r):
+		return space
+	}
+	return punct
+}
+
+// token is one element of an edit script.
+type token struct {
+	op   Op
+	text string
+}
+
+// diff returns the edit script turning a into b, one entry per token.
+func diff(a, b []string) []token {
+	// Common prefixes and suffixes need no search
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	script := make([]token, 0, len(a)+len(b))
+	for _, t := range a[:prefix] {
+		script = append(script, token{Equal, t})
+	}
+	script = append(script, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, t := range a[len(a)-suffix:] {
+		script = append(script, token{Equal, t})
+	}
+	return script
+}
+
+// myers finds a shortest edit script with Myers' algorithm. trace[d] holds
+// the furthest reaching x of every diagonal k in -d..d after d edits, which
+// is all the backtracking needs.
+func myers(a, b []string) []token {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 || !withinEdits(n, m) {
+		return replace(a, b)
+	}
+
+	var (
+		trace [][]int
+		prev  []int
+	)
+	for d := 0; d <= n+m; d++ {
+		if d > MaxEdits {
+			return replace(a, b)
+		}
+		v := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			// Step down (insert) from diagonal k+1 or right (delete) from k-1
+			if k == -d || (k != d && at(prev, d-1, k-1) < at(prev, d-1, k+1)) {
+				x = at(prev, d-1, k+1)
+			} else {
+				x = at(prev, d-1, k-1) + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				trace = append(trace, v)
+				return backtrack(a, b, trace)
+			}
+		}
+		trace = append(trace, v)
+		prev = v
+	}
+	return replace(a, b)
+}
+
+// withinEdits reports whether a script of at most MaxEdits edits can exist:
+// at least the difference in length has to be inserted or deleted.
+func withinEdits(n, m int) bool {
+	if n > m {
+		return n-m <= MaxEdits
+	}
+	return m-n <= MaxEdits
+}
+
+// at returns the furthest x on diagonal k after d edits, given the row of
+// the trace for d.
+func at(v []int, d, k int) int {
+	if d < 0 {
+		return 0
+	}
+	return v[k+d]
+}
+
+func backtrack(a, b []string, trace [][]int) []token {
+	var script []token
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(prev, d-1, k-1) < at(prev, d-1, k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prev, d-1, prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			script = append(script, token{Equal, a[x]})
+		}
+		if x == prevX {
+			y--
+			script = append(script, token{Insert, b[y]})
+		} else {
+			x--
+			script = append(script, token{Delete, a[x]})
+		}
+	}
+	for x > 0 {
+		x--
+		script = append(script, token{Equal, a[x]})
+	}
+	for i, j := 0, len(script)-1; i < j; i, j = i+1, j-1 {
+		script[i], script[j] = script[j], script[i]
+	}
+	return script
+}
+
+// replace is the fallback script that deletes all of a and inserts all of b.
+func replace(a, b []string) []token {
+	script := make([]token, 0, len(a)+len(b))
+	for _, t := range a {
+		script = append(script, token{Delete, t})
+	}
+	for _, t := range b {
+		script = append(script, token{Insert, t})
+	}
+	return script
+}
+
+// merge joins consecutive tokens with the same op into spans. Within a run of
+// changes, deletions are placed before insertions.
+func merge(script []token) []Span {
+	var spans []Span
+	var equal, del, ins strings.Builder
+	flush := func() {
+		for _, run := range []struct {
+			op Op
+			b  *strings.Builder
+		}{{Equal, &equal}, {Delete, &del}, {Insert, &ins}} {
+			if run.b.Len() > 0 {
+				spans = append(spans, Span{run.op, run.b.String()})
+				run.b.Reset()
+			}
+		}
+	}
+	for _, t := range script {
+		switch t.op {
+		case Delete:
+			del.WriteString(t.text)
+		case Insert:
+			ins.WriteString(t.text)
+		default:
+			if del.Len() > 0 || ins.Len() > 0 {
+				flush()
+			}
+			equal.WriteString(t.text)
+		}
+	}
+	flush()
+	return spans
+}
@@ -0,0 +1,160 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// sides rebuilds both texts from an edit script.
+func sides(spans []Span) (string, string) {
+	var a, b strings.Builder
+	for _, s := range spans {
+		if s.Op != Insert {
+			a.WriteString(s.Text)
+		}
+		if s.Op != Delete {
+			b.WriteString(s.Text)
+		}
+	}
+	return a.String(), b.String()
+}
+
+func format(spans []Span) string {
+	var parts []string
+	for _, s := range spans {
+		parts = append(parts, fmt.Sprintf("%s%q", map[Op]string{Equal: "=", Insert: "+", Delete: "-"}[s.Op], s.Text))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", ""},
+		{"a\n", "a\n", `="a\n"`},
+		{"", "a\n", `+"a\n"`},
+		{"a\nb\nc\n", "a\nx\nc\n", `="a\n" -"b\n" +"x\n" ="c\n"`},
+		{"a\nb\n", "b\nc\n", `-"a\n" ="b\n" +"c\n"`},
+		{"a\nb", "a\nb\n", `="a\n" -"b" +"b\n"`},
+	}
+	for _, tt := range tests {
+		if got := format(Lines(tt.a, tt.b)); got != tt.want {
+			t.Errorf("Lines(%q, %q) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"the quick fox", "the slow fox", `="the " -"quick" +"slow" =" fox"`},
+		{"Hello, world.", "Hello world!", `="Hello" -"," =" world" -"." +"!"`},
+		{"grüße aus köln", "grüße aus bonn", `="grüße aus " -"köln" +"bonn"`},
+	}
+	for _, tt := range tests {
+		if got := format(Words(tt.a, tt.b)); got != tt.want {
+			t.Errorf("Words(%q, %q) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestShortestScript(t *testing.T) {
+	// The classic example from Myers' paper needs 5 edits
+	a, b := strings.Split("ABCABBA", ""), strings.Split("CBABAC", "")
+	var edits int
+	for _, tok := range diff(a, b) {
+		if tok.op != Equal {
+			edits++
+		}
+	}
+	if edits != 5 {
+		t.Errorf("expected 5 edits, got %d", edits)
+	}
+}
+
+func TestRandomScriptsRebuildBothSides(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"a", "b", "c", " ", "\n", "."}
+	random := func() string {
+		var sb strings.Builder
+		for i := rng.Intn(40); i > 0; i-- {
+			sb.WriteString(words[rng.Intn(len(words))])
+		}
+		return sb.String()
+	}
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		for _, spans := range [][]Span{Lines(a, b), Words(a, b)} {
+			if gotA, gotB := sides(spans); gotA != a || gotB != b {
+				t.Fatalf("script %s does not rebuild %q and %q", format(spans), a, b)
+			}
+			if Changed(spans) != (a != b) {
+				t.Fatalf("Changed = %v for %q and %q", Changed(spans), a, b)
+			}
+		}
+	}
+}
+
+func TestTooManyEditsFallsBackToReplace(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < MaxEdits+1; i++ {
+		fmt.Fprintf(&a, "a%d\n", i)
+		fmt.Fprintf(&b, "b%d\n", i)
+	}
+	a.WriteString("same\n")
+	b.WriteString("same\n")
+
+	spans := Lines(a.String(), b.String())
+	if len(spans) != 3 || spans[0].Op != Delete || spans[1].Op != Insert || spans[2].Text != "same\n" {
+		t.Errorf("expected delete, insert, equal, got %d spans", len(spans))
+	}
+	if gotA, gotB := sides(spans); gotA != a.String() || gotB != b.String() {
+		t.Errorf("fallback script does not rebuild both sides")
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13"
+	want := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+\ No newline at end of file
+`
+	if got := Unified(a, b, "a", "b", DefaultContext); got != want {
+		t.Errorf("unexpected unified diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Changes closer than two contexts share a hunk
+	if got := Unified(a, b, "a", "b", 5); strings.Count(got, "@@ -") != 1 {
+		t.Errorf("expected a single hunk, got:\n%s", got)
+	}
+	if got := Unified(a, a, "a", "b", DefaultContext); got != "" {
+		t.Errorf("expected no diff for identical texts, got:\n%s", got)
+	}
+}
+
+func TestUnifiedEmptySide(t *testing.T) {
+	want := "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n"
+	if got := Unified("", "x\ny\n", "a", "b", DefaultContext); got != want {
+		t.Errorf("unexpected unified diff:\n%s\nwant:\n%s", got, want)
+	}
+}
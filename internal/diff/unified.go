@@ -0,0 +1,97 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change in
+// unified diffs, as in diff -u.
+const DefaultContext = 3
+
+// Unified returns the line diff of a and b in unified format, labelling the
+// sides from and to and showing context unchanged lines around each change.
+// Identical texts produce an empty diff.
+func Unified(a, b, from, to string, context int) string {
+	if context < 0 {
+		context = 0
+	}
+	script := diff(splitLines(a), splitLines(b))
+
+	// aPos[i] and bPos[i] count the lines of a and b before script[i]
+	aPos := make([]int, len(script)+1)
+	bPos := make([]int, len(script)+1)
+	for i, t := range script {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if t.op != Insert {
+			aPos[i+1]++
+		}
+		if t.op != Delete {
+			bPos[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(script); {
+		if script[i].op == Equal {
+			i++
+			continue
+		}
+		// A hunk runs from context lines before the first change to context
+		// lines after the last one, absorbing changes that are closer than
+		// two contexts apart.
+		start := max(0, i-context)
+		end := i
+		for end < len(script) {
+			if script[end].op != Equal {
+				end++
+				continue
+			}
+			j := end
+			for j < len(script) && script[j].op == Equal {
+				j++
+			}
+			if j == len(script) || j-end > 2*context {
+				end = min(end+context, len(script))
+				break
+			}
+			end = j
+		}
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", from, to)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(aPos[start], aPos[end]-aPos[start]),
+			hunkRange(bPos[start], bPos[end]-bPos[start]))
+		for _, t := range script[start:end] {
+			switch t.op {
+			case Equal:
+				sb.WriteByte(' ')
+			case Insert:
+				sb.WriteByte('+')
+			case Delete:
+				sb.WriteByte('-')
+			}
+			sb.WriteString(t.text)
+			if !strings.HasSuffix(t.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// hunkRange formats the line range of one side of a hunk. before is the
+// number of lines preceding the hunk; an empty range is numbered after the
+// line it follows.
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
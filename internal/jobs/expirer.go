@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	storage "github.com/pandae7/go-blogger/internal/storage"
+)
+
+// DefaultExpireInterval is how often expired idempotency keys are forgotten.
+const DefaultExpireInterval = time.Hour
+
+// Expirer forgets the idempotency keys of creates once they expire, so that
+// they do not pile up in the storage.
+type Expirer struct {
+	storage  storage.BlogStorage
+	interval time.Duration
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
+}
+
+// NewExpirer creates an expirer that checks for expired keys every interval.
+func NewExpirer(storage storage.BlogStorage, interval time.Duration) *Expirer {
+	return &Expirer{
+		storage:  storage,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// RunOnce forgets the expired keys and returns how many were forgotten.
+func (e *Expirer) RunOnce(ctx context.Context) (int, error) {
+	return e.storage.ExpireIdempotencyKeys(ctx, e.now())
+}
+
+// Run forgets expired keys right away and then every interval until ctx is
+// canceled.
+func (e *Expirer) Run(ctx context.Context) {
+	runEvery(ctx, e.interval, "expire idempotency keys", "Expired %d idempotency keys", e.RunOnce)
+}
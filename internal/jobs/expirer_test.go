@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	storage "github.com/pandae7/go-blogger/internal/storage"
+)
+
+func TestExpirer_ForgetsExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewBlogStorage()
+	now := time.Now()
+	for _, key := range []*models.IdempotencyKey{
+		{RequestId: "old", ExpiresAt: now.Add(time.Minute)},
+		{RequestId: "new", ExpiresAt: now.Add(time.Hour)},
+	} {
+		if _, _, err := s.CreatePostOnce(ctx, &models.BlogPost{PostId: key.RequestId}, key); err != nil {
+			t.Fatalf("CreatePostOnce failed: %v", err)
+		}
+	}
+
+	// Move the clock past the expiry of "old" but not of "new"
+	e := NewExpirer(s, time.Hour)
+	e.now = func() time.Time { return now.Add(2 * time.Minute) }
+	n, err := e.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 expired key, got %d", n)
+	}
+	if n, err := e.RunOnce(ctx); err != nil || n != 0 {
+		t.Errorf("expected nothing left to expire, got %d, %v", n, err)
+	}
+
+	// The remaining key still returns the post it created
+	post, created, err := s.CreatePostOnce(ctx, &models.BlogPost{PostId: "retry"}, &models.IdempotencyKey{RequestId: "new", ExpiresAt: now.Add(time.Hour)})
+	if err != nil || created || post.PostId != "new" {
+		t.Errorf("expected the key of new to be kept, got %+v, %v, %v", post, created, err)
+	}
+}
@@ -0,0 +1,50 @@
+// Package jobs runs periodic background work against the blog storage.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	storage "github.com/pandae7/go-blogger/internal/storage"
+)
+
+const (
+	// DefaultTrashRetention is how long deleted posts stay in the trash.
+	DefaultTrashRetention = 30 * 24 * time.Hour
+
+	// DefaultReapInterval is how often the trash is checked for expired posts.
+	DefaultReapInterval = time.Hour
+)
+
+// Reaper permanently deletes posts that have been in the trash for longer
+// than the retention period.
+type Reaper struct {
+	storage   storage.BlogStorage
+	retention time.Duration
+	interval  time.Duration
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
+}
+
+// NewReaper creates a reaper that purges posts trashed more than retention
+// ago, checking every interval.
+func NewReaper(storage storage.BlogStorage, retention, interval time.Duration) *Reaper {
+	return &Reaper{
+		storage:   storage,
+		retention: retention,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// RunOnce purges the expired posts and returns how many were purged.
+func (r *Reaper) RunOnce(ctx context.Context) (int, error) {
+	return r.storage.PurgeTrashedBefore(ctx, r.now().Add(-r.retention))
+}
+
+// Run purges expired posts right away and then every interval until ctx is
+// canceled.
+func (r *Reaper) Run(ctx context.Context) {
+	runEvery(ctx, r.interval, "purge trashed posts", "Purged %d posts from the trash", r.RunOnce)
+}
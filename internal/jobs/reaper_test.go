@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	storage "github.com/pandae7/go-blogger/internal/storage"
+)
+
+func TestReaper_PurgesExpiredPosts(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewBlogStorage()
+	for _, id := range []string{"old", "new", "live"} {
+		if err := s.CreatePost(ctx, &models.BlogPost{PostId: id}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "old"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	// Keep the trash timestamps apart even on coarse clocks
+	time.Sleep(time.Millisecond)
+	trashedAt := time.Now()
+	time.Sleep(time.Millisecond)
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "new"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	// Pretend the retention period has passed for "old" only
+	r := NewReaper(s, time.Hour, time.Hour)
+	r.now = func() time.Time { return trashedAt.Add(time.Hour) }
+	n, err := r.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 purged post, got %d", n)
+	}
+	if _, err := s.RestorePost(ctx, "old"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected old to be purged, got: %v", err)
+	}
+	if _, err := s.RestorePost(ctx, "new"); err != nil {
+		t.Errorf("expected new to still be in the trash, got: %v", err)
+	}
+	if _, err := s.GetPost(ctx, "live"); err != nil {
+		t.Errorf("expected live post to be untouched, got: %v", err)
+	}
+}
+
+func TestReaper_RunStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewReaper(storage.NewBlogStorage(), time.Hour, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was canceled")
+	}
+}
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// runEvery calls run right away and then every interval until ctx is
+// canceled. Failures are logged as "Failed to <task>", and runs that did
+// something are logged with done, a format for the count run returned.
+func runEvery(ctx context.Context, interval time.Duration, task, done string, run func(context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := run(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Errorf("Failed to %s: %v", task, err)
+		case n > 0:
+			log.Infof(done, n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	storage "github.com/pandae7/go-blogger/internal/storage"
+)
+
+// DefaultPublishInterval is how often scheduled posts are checked for being
+// due, and so roughly how late after its publication date a post goes live.
+const DefaultPublishInterval = time.Minute
+
+// Scheduler publishes scheduled posts once their publication date has
+// passed.
+type Scheduler struct {
+	storage  storage.BlogStorage
+	interval time.Duration
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
+}
+
+// NewScheduler creates a scheduler that publishes due posts every interval.
+func NewScheduler(storage storage.BlogStorage, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		storage:  storage,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// RunOnce publishes the posts that are due and returns how many were
+// published.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	return s.storage.PublishScheduled(ctx, s.now())
+}
+
+// Run publishes due posts right away and then every interval until ctx is
+// canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	runEvery(ctx, s.interval, "publish scheduled posts", "Published %d scheduled posts", s.RunOnce)
+}
@@ -0,0 +1,79 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	storage "github.com/pandae7/go-blogger/internal/storage"
+)
+
+func TestScheduler_PublishesDuePosts(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewBlogStorage()
+	start := time.Now()
+	for id, publishAt := range map[string]time.Time{
+		"first":  start.Add(time.Hour),
+		"second": start.Add(2 * time.Hour),
+	} {
+		if err := s.CreatePost(ctx, &models.BlogPost{PostId: id, PublicationDate: publishAt}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+
+	// Advance a fake clock past each publication date in turn
+	clock := start
+	sched := NewScheduler(s, time.Minute)
+	sched.now = func() time.Time { return clock }
+	status := func(id string) models.PostStatus {
+		post, err := s.GetPost(ctx, id)
+		if err != nil {
+			t.Fatalf("GetPost failed: %v", err)
+		}
+		return post.Status
+	}
+
+	for _, step := range []struct {
+		at            time.Duration
+		published     int
+		first, second models.PostStatus
+	}{
+		{0, 0, models.StatusScheduled, models.StatusScheduled},
+		{time.Hour - time.Second, 0, models.StatusScheduled, models.StatusScheduled},
+		{time.Hour, 1, models.StatusPublished, models.StatusScheduled},
+		{90 * time.Minute, 0, models.StatusPublished, models.StatusScheduled},
+		{3 * time.Hour, 1, models.StatusPublished, models.StatusPublished},
+	} {
+		clock = start.Add(step.at)
+		n, err := sched.RunOnce(ctx)
+		if err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+		if n != step.published {
+			t.Errorf("at +%s: expected %d published posts, got %d", step.at, step.published, n)
+		}
+		if got := status("first"); got != step.first {
+			t.Errorf("at +%s: expected first to be %s, got %s", step.at, step.first, got)
+		}
+		if got := status("second"); got != step.second {
+			t.Errorf("at +%s: expected second to be %s, got %s", step.at, step.second, got)
+		}
+	}
+}
+
+func TestScheduler_RunStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewScheduler(storage.NewBlogStorage(), time.Millisecond).Run(ctx)
+		close(done)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was canceled")
+	}
+}
@@ -3,13 +3,95 @@ package models
 import "time"
 
 type BlogPost struct {
-	PostId          string    `json:"post_id"`
-	Title           string    `json:"title"`
-	Content         string    `json:"content"`
-	Author          string    `json:"author"`
-	PublicationDate time.Time `json:"publication_date"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	Tags            []string  `json:"tags"`
+	PostId  string `json:"post_id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// Author is the name of the author, kept in sync with the author record
+	// when the post has an AuthorId.
+	Author          string     `json:"author"`
+	AuthorId        string     `json:"author_id,omitempty"`
+	PublicationDate time.Time  `json:"publication_date"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	Tags            []string   `json:"tags"`
+	Version         int64      `json:"version"`
+	Status          PostStatus `json:"status"`
+	// DeletedAt is set while the post is in the trash.
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// PostStatus is the stage of a post's publishing lifecycle.
+type PostStatus string
+
+const (
+	// StatusDraft posts are work in progress and not visible to readers.
+	StatusDraft PostStatus = "draft"
+	// StatusScheduled posts are published automatically once their
+	// publication date has passed.
+	StatusScheduled PostStatus = "scheduled"
+	// StatusPublished posts are live.
+	StatusPublished PostStatus = "published"
+	// StatusArchived posts were published once and have been retired.
+	StatusArchived PostStatus = "archived"
+)
+
+// Trashed reports whether the post has been moved to the trash.
+func (p *BlogPost) Trashed() bool {
+	return !p.DeletedAt.IsZero()
+}
+
+// Clone returns a deep copy of the post that shares no memory with it.
+func (p *BlogPost) Clone() *BlogPost {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	if p.Tags != nil {
+		clone.Tags = append([]string(nil), p.Tags...)
+	}
+	return &clone
+}
+
+// PostRevision is an immutable record of a post's editable fields as they were
+// after a change to the post. Every change that bumps the version records one,
+// including status changes that leave the fields as they were, so Revision
+// equals the post version it recorded and no version is missing.
+type PostRevision struct {
+	PostId    string    `json:"post_id"`
+	Revision  int64     `json:"revision"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+	Editor    string    `json:"editor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewPostRevision records the current state of a post.
+func NewPostRevision(post *BlogPost, editor string) *PostRevision {
+	return &PostRevision{
+		PostId:    post.PostId,
+		Revision:  post.Version,
+		Title:     post.Title,
+		Content:   post.Content,
+		Tags:      append([]string(nil), post.Tags...),
+		Editor:    editor,
+		CreatedAt: post.UpdatedAt,
+	}
+}
+
+// SystemEditor is the editor of the revisions that the service records on
+// its own, such as when a scheduled post goes live or a tag is renamed.
+const SystemEditor = "system"
+
+// Clone returns a deep copy of the revision that shares no memory with it.
+func (r *PostRevision) Clone() *PostRevision {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	if r.Tags != nil {
+		clone.Tags = append([]string(nil), r.Tags...)
+	}
+	return &clone
 }
 
 type Author struct {
@@ -21,20 +103,87 @@ type Author struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// Clone returns a copy of the author.
+func (a *Author) Clone() *Author {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	return &clone
+}
+
+// Tag is an entry of the tag registry. Posts carry tags by name; a tag is
+// registered the first time a post uses it. UsageCount is computed when the
+// tag is read and counts the posts carrying it, excluding the trash.
+type Tag struct {
+	TagId       string    `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description,omitempty"`
+	UsageCount  int       `json:"usage_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Clone returns a copy of the tag.
+func (t *Tag) Clone() *Tag {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
+
 type CreateBlogPostRequest struct {
 	Title           string    `json:"title"`
 	Content         string    `json:"content"`
-	Author          string    `json:"author"`
+	AuthorId        string    `json:"author_id"`
 	PublicationDate time.Time `json:"publication_date,omitempty"`
 	Tags            []string  `json:"tags"`
+	// Status defaults to scheduled for a future publication date and to
+	// published otherwise.
+	Status PostStatus `json:"status,omitempty"`
+}
+
+// IdempotencyKey remembers a create made with a client-chosen request ID, so
+// that a retry of the same request gets the post it created instead of a new
+// one. Fingerprint identifies the request payload and PostId is the post that
+// was created, set by the storage.
+type IdempotencyKey struct {
+	RequestId   string    `json:"request_id"`
+	Fingerprint string    `json:"fingerprint"`
+	PostId      string    `json:"post_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// SetPostStatusRequest moves a post to another stage of its lifecycle.
+// Status is the target: StatusPublished publishes the post, or schedules it
+// when PublishAt is in the future; StatusDraft unpublishes it and
+// StatusArchived archives it.
+type SetPostStatusRequest struct {
+	PostId string     `json:"id"`
+	Status PostStatus `json:"status"`
+	// PublishAt sets the publication date when publishing. When zero, drafts
+	// and scheduled posts are published now and archived posts keep their
+	// original publication date.
+	PublishAt       time.Time `json:"publish_at,omitempty"`
+	ExpectedVersion int64     `json:"expected_version,omitempty"`
+	// Editor is recorded as the author of the resulting revision.
+	Editor string `json:"editor,omitempty"`
 }
 
 type UpdateBlogPostRequest struct {
-	PostId    string    `json:"id"`
-	Title     string    `json:"title,omitempty"`
-	Content   string    `json:"content,omitempty"`
-	Tags      []string  `json:"tags,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	PostId          string    `json:"id"`
+	Title           string    `json:"title,omitempty"`
+	Content         string    `json:"content,omitempty"`
+	Tags            []string  `json:"tags,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at,omitempty"`
+	ExpectedVersion int64     `json:"expected_version,omitempty"`
+	// UpdateMask lists the fields to set. When empty, only non-empty fields
+	// are applied.
+	UpdateMask []string `json:"update_mask,omitempty"`
+	// Editor is recorded as the author of the resulting revision.
+	Editor string `json:"editor,omitempty"`
 }
 
 type CreateBlogPostResponse struct {
@@ -60,10 +209,374 @@ type GetBlogPostResponse struct {
 }
 
 type DeleteBlogPostRequest struct {
-	PostId string `json:"id"`
+	PostId          string `json:"id"`
+	ExpectedVersion int64  `json:"expected_version,omitempty"`
+	Permanent       bool   `json:"permanent,omitempty"`
+}
+
+// BatchDeleteRequest deletes several posts at once, moving them to the trash
+// unless Permanent is set, as DeleteBlogPostRequest does for one post without
+// a version check.
+type BatchDeleteRequest struct {
+	PostIds   []string `json:"ids"`
+	Permanent bool     `json:"permanent,omitempty"`
 }
 
 type DeleteBlogPostResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message,omitempty"`
 }
+
+type ListBlogPostsRequest struct {
+	PageSize  int    `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+	Filter    string `json:"filter,omitempty"`
+	OrderBy   string `json:"order_by,omitempty"`
+	// ShowDeleted includes posts in the trash.
+	ShowDeleted bool `json:"show_deleted,omitempty"`
+}
+
+type ListBlogPostsResponse struct {
+	Posts         []*BlogPost `json:"posts"`
+	NextPageToken string      `json:"next_page_token,omitempty"`
+	Success       bool        `json:"success"`
+	Message       string      `json:"message,omitempty"`
+}
+
+type ListPostRevisionsRequest struct {
+	PostId string `json:"id"`
+}
+
+type ListPostRevisionsResponse struct {
+	Revisions []*PostRevision `json:"revisions"`
+	Success   bool            `json:"success"`
+	Message   string          `json:"message,omitempty"`
+}
+
+type GetPostRevisionRequest struct {
+	PostId   string `json:"id"`
+	Revision int64  `json:"revision"`
+}
+
+type GetPostRevisionResponse struct {
+	Revision *PostRevision `json:"revision"`
+	Success  bool          `json:"success"`
+	Message  string        `json:"message,omitempty"`
+}
+
+type RestorePostRevisionRequest struct {
+	PostId          string `json:"id"`
+	Revision        int64  `json:"revision"`
+	ExpectedVersion int64  `json:"expected_version,omitempty"`
+	Editor          string `json:"editor,omitempty"`
+}
+
+type RestorePostRevisionResponse struct {
+	Post    *BlogPost `json:"post"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+}
+
+type RestoreBlogPostRequest struct {
+	PostId string `json:"id"`
+}
+
+type RestoreBlogPostResponse struct {
+	Post    *BlogPost `json:"post"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+}
+
+type PurgeBlogPostRequest struct {
+	PostId string `json:"id"`
+}
+
+type PurgeBlogPostResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+type PublishBlogPostRequest struct {
+	PostId          string    `json:"id"`
+	PublishAt       time.Time `json:"publish_at,omitempty"`
+	ExpectedVersion int64     `json:"expected_version,omitempty"`
+	// Editor is recorded as the author of the resulting revision.
+	Editor string `json:"editor,omitempty"`
+}
+
+type PublishBlogPostResponse struct {
+	Post    *BlogPost `json:"post"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+}
+
+type UnpublishBlogPostRequest struct {
+	PostId          string `json:"id"`
+	ExpectedVersion int64  `json:"expected_version,omitempty"`
+}
+
+type UnpublishBlogPostResponse struct {
+	Post    *BlogPost `json:"post"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+}
+
+type ArchiveBlogPostRequest struct {
+	PostId          string `json:"id"`
+	ExpectedVersion int64  `json:"expected_version,omitempty"`
+}
+
+type ArchiveBlogPostResponse struct {
+	Post    *BlogPost `json:"post"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+}
+
+type CreateAuthorRequest struct {
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	ProfileName string `json:"profile_name"`
+}
+
+type CreateAuthorResponse struct {
+	Author  *Author `json:"author"`
+	Success bool    `json:"success"`
+	Message string  `json:"message,omitempty"`
+}
+
+type GetAuthorRequest struct {
+	AuthorId string `json:"id"`
+}
+
+type GetAuthorResponse struct {
+	Author  *Author `json:"author"`
+	Success bool    `json:"success"`
+	Message string  `json:"message,omitempty"`
+}
+
+// UpdateAuthorRequest applies the non-empty fields to an author. A new name
+// is copied to the author's posts.
+type UpdateAuthorRequest struct {
+	AuthorId    string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	ProfileName string `json:"profile_name,omitempty"`
+}
+
+type UpdateAuthorResponse struct {
+	Author  *Author `json:"author"`
+	Success bool    `json:"success"`
+	Message string  `json:"message,omitempty"`
+}
+
+type DeleteAuthorRequest struct {
+	AuthorId string `json:"id"`
+}
+
+type DeleteAuthorResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+type ListAuthorsRequest struct {
+	PageSize  int    `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+type ListAuthorsResponse struct {
+	Authors       []*Author `json:"authors"`
+	NextPageToken string    `json:"next_page_token,omitempty"`
+	Success       bool      `json:"success"`
+	Message       string    `json:"message,omitempty"`
+}
+
+type ListPostsByAuthorRequest struct {
+	AuthorId  string `json:"author_id"`
+	PageSize  int    `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+type ListPostsByAuthorResponse struct {
+	Posts         []*BlogPost `json:"posts"`
+	NextPageToken string      `json:"next_page_token,omitempty"`
+	Success       bool        `json:"success"`
+	Message       string      `json:"message,omitempty"`
+}
+
+type ListPostsByTagRequest struct {
+	Tag       string `json:"tag"`
+	PageSize  int    `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+type ListPostsByTagResponse struct {
+	Posts         []*BlogPost `json:"posts"`
+	NextPageToken string      `json:"next_page_token,omitempty"`
+	Success       bool        `json:"success"`
+	Message       string      `json:"message,omitempty"`
+}
+
+type SearchPostsRequest struct {
+	Query     string `json:"query"`
+	PageSize  int    `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+// SearchResult is a post matching a search query, with its relevance score.
+type SearchResult struct {
+	Post  *BlogPost `json:"post"`
+	Score float64   `json:"score"`
+}
+
+type SearchPostsResponse struct {
+	Results       []*SearchResult `json:"results"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+	Success       bool            `json:"success"`
+	Message       string          `json:"message,omitempty"`
+}
+
+// GetRelatedPostsRequest asks for up to Limit published posts related to a
+// post.
+type GetRelatedPostsRequest struct {
+	PostId string `json:"post_id"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// RelatedPost is a post related to another, with a score from 0 to 1.
+type RelatedPost struct {
+	Post  *BlogPost `json:"post"`
+	Score float64   `json:"score"`
+}
+
+type GetRelatedPostsResponse struct {
+	Posts   []*RelatedPost `json:"posts"`
+	Success bool           `json:"success"`
+	Message string         `json:"message,omitempty"`
+}
+
+// EventType tells how a post changed, as seen through GetPost.
+type EventType string
+
+const (
+	// EventCreated is sent when a post is created or restored from the
+	// trash.
+	EventCreated EventType = "created"
+
+	// EventUpdated is sent when a post outside the trash changes.
+	EventUpdated EventType = "updated"
+
+	// EventDeleted is sent when a post is deleted or moved to the trash.
+	EventDeleted EventType = "deleted"
+)
+
+// PostEvent is a change to a post. Sequence numbers increase by one with
+// every event of a storage.
+type PostEvent struct {
+	Sequence uint64    `json:"sequence"`
+	Type     EventType `json:"type"`
+	PostId   string    `json:"post_id"`
+	// Post is the post after the change or, for a deleted post, as it was
+	// last seen.
+	Post *BlogPost `json:"post"`
+	// Previous is the post before the change, nil for a created post.
+	Previous *BlogPost `json:"previous,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// WatchPostsRequest asks for the events after SinceSequence, or for the
+// events from now on when it is zero. AuthorId and Tag, when set, narrow the
+// events down to the posts that have that author or tag before or after the
+// change.
+type WatchPostsRequest struct {
+	AuthorId      string `json:"author_id,omitempty"`
+	Tag           string `json:"tag,omitempty"`
+	SinceSequence uint64 `json:"since_sequence,omitempty"`
+}
+
+// ExportPostsRequest selects the posts to export with a filter expression,
+// as in ListBlogPostsRequest. Unlike listing, posts of every status are
+// exported; the filter can narrow them down by status.
+type ExportPostsRequest struct {
+	Filter string `json:"filter,omitempty"`
+	// ShowDeleted includes posts in the trash.
+	ShowDeleted bool `json:"show_deleted,omitempty"`
+}
+
+type ListTagsRequest struct {
+	PageSize  int    `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+type ListTagsResponse struct {
+	Tags          []*Tag `json:"tags"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+	Success       bool   `json:"success"`
+	Message       string `json:"message,omitempty"`
+}
+
+type GetTagRequest struct {
+	TagId string `json:"id"`
+}
+
+type GetTagResponse struct {
+	Tag     *Tag   `json:"tag"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// RenameTagRequest gives a tag a new name, rewriting every post that carries
+// it, and replaces its description when Description is set.
+type RenameTagRequest struct {
+	TagId       string  `json:"id"`
+	Name        string  `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type RenameTagResponse struct {
+	Tag     *Tag   `json:"tag"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// MergeTagsRequest replaces the source tag with the target tag on every post
+// and removes the source tag from the registry.
+type MergeTagsRequest struct {
+	SourceTagId string `json:"source_id"`
+	TargetTagId string `json:"target_id"`
+}
+
+type MergeTagsResponse struct {
+	Tag     *Tag   `json:"tag"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// SuggestTagsRequest asks for registered tags starting with Prefix. Without a
+// draft they are ranked by usage; when Title, Content or Tags describe a
+// draft post they are ranked by how well they fit it.
+type SuggestTagsRequest struct {
+	Prefix  string   `json:"prefix,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Content string   `json:"content,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Limit   int      `json:"limit,omitempty"`
+}
+
+// Draft reports whether the request describes a draft post.
+func (r *SuggestTagsRequest) Draft() bool {
+	return r.Title != "" || r.Content != "" || len(r.Tags) > 0
+}
+
+// TagSuggestion is a tag suggested for a prefix or a draft post. Score is the
+// usage count of the tag without a draft, and how well it fits the draft
+// otherwise.
+type TagSuggestion struct {
+	Tag   *Tag    `json:"tag"`
+	Score float64 `json:"score"`
+}
+
+type SuggestTagsResponse struct {
+	Suggestions []*TagSuggestion `json:"suggestions"`
+	Success     bool             `json:"success"`
+	Message     string           `json:"message,omitempty"`
+}
@@ -1,17 +1,71 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error constants
 var (
-	ErrPostNotFound    = errors.New("post not found")
-	ErrAuthorNotFound  = errors.New("author not found")
-	ErrTagNotFound     = errors.New("tag not found")
-	ErrInvalidPostID   = errors.New("invalid post ID")
-	ErrInvalidAuthorID = errors.New("invalid author ID")
-	ErrInvalidTagID    = errors.New("invalid tag ID")
-	ErrEmptyTitle      = errors.New("post title cannot be empty")
-	ErrEmptyContent    = errors.New("post content cannot be empty")
-	ErrEmptyAuthor     = errors.New("post author cannot be empty")
-	ErrDuplicatePost   = errors.New("post with this ID already exists")
+	ErrPostNotFound      = errors.New("post not found")
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrAuthorNotFound    = errors.New("author not found")
+	ErrTagNotFound       = errors.New("tag not found")
+	ErrInvalidPostID     = errors.New("invalid post ID")
+	ErrInvalidAuthorID   = errors.New("invalid author ID")
+	ErrInvalidTagID      = errors.New("invalid tag ID")
+	ErrEmptyTitle        = errors.New("post title cannot be empty")
+	ErrEmptyContent      = errors.New("post content cannot be empty")
+	ErrEmptyAuthor       = errors.New("post author cannot be empty")
+	ErrDuplicatePost     = errors.New("post with this ID already exists")
+	ErrPostNotTrashed    = errors.New("post is not in the trash")
+	ErrInvalidPageSize   = errors.New("page size cannot be negative")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrEmptyUpdate       = errors.New("at least one field (title, content, tags) must be provided for update")
+	ErrInvalidVersion    = errors.New("expected version cannot be negative")
+	ErrVersionMismatch   = errors.New("post has been modified since the expected version")
+	ErrInvalidUpdateMask = errors.New("unknown field in update mask")
+	ErrImmutableField    = errors.New("field cannot be updated")
+	ErrInvalidRevision   = errors.New("invalid revision number")
+	ErrInvalidDiffFormat = errors.New("unknown diff format")
+	ErrInvalidContext    = errors.New("context lines cannot be negative")
+	ErrInvalidStatus     = errors.New("invalid post status")
+	ErrInvalidTransition = errors.New("post cannot move to this status from its current status")
+	ErrPublishInFuture   = errors.New("publication date is in the future")
+	ErrPublishInPast     = errors.New("scheduled publication date must be in the future")
+	ErrEmptyAuthorName   = errors.New("author name cannot be empty")
+	ErrEmptyProfileName  = errors.New("author profile name cannot be empty")
+	ErrInvalidEmail      = errors.New("invalid email address")
+	ErrEmptyAuthorUpdate = errors.New("at least one field (name, email, profile_name) must be provided for update")
+	ErrDuplicateAuthor   = errors.New("author with this profile name or email already exists")
+	ErrAuthorHasPosts    = errors.New("author still has posts")
+	ErrEmptyTagName      = errors.New("tag name cannot be empty")
+	ErrEmptyTag          = errors.New("tag cannot be empty")
+	ErrEmptyQuery        = errors.New("search query must contain at least one word")
+	ErrEmptyTagUpdate    = errors.New("at least one field (name, description) must be provided for update")
+	ErrDuplicateTag      = errors.New("tag with this name already exists")
+	ErrMergeSameTag      = errors.New("cannot merge a tag into itself")
+	ErrInvalidLimit      = errors.New("limit cannot be negative")
+	ErrSequenceExpired   = errors.New("events after this sequence are no longer available")
+	ErrShuttingDown      = errors.New("server is shutting down")
+	ErrImportTooLarge    = errors.New("too many posts for an all-or-nothing import")
+	ErrEmptyBatch        = errors.New("at least one post ID must be provided")
+	ErrBatchTooLarge     = errors.New("too many post IDs in one batch")
+	ErrInvalidRequestID  = errors.New("request ID is too long")
+	ErrRequestIDReused   = errors.New("request ID was already used for a different request")
 )
+
+// ItemError reports the item of a batch that failed, by its position in the
+// batch.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,205 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+// Expr is a node of a parsed filter expression.
+type Expr interface {
+	// Match reports whether the post satisfies the expression.
+	Match(post *models.BlogPost) bool
+
+	String() string
+}
+
+// Match reports whether the post satisfies expr. A nil expression matches
+// every post.
+func Match(expr Expr, post *models.BlogPost) bool {
+	return expr == nil || expr.Match(post)
+}
+
+// References reports whether expr compares the named field anywhere.
+func References(expr Expr, field string) bool {
+	switch e := expr.(type) {
+	case *AndExpr:
+		return References(e.Left, field) || References(e.Right, field)
+	case *OrExpr:
+		return References(e.Left, field) || References(e.Right, field)
+	case *NotExpr:
+		return References(e.Expr, field)
+	case *Comparison:
+		return e.Field == field
+	}
+	return false
+}
+
+// AndExpr matches posts that satisfy both operands.
+type AndExpr struct {
+	Left, Right Expr
+}
+
+func (e *AndExpr) Match(post *models.BlogPost) bool {
+	return e.Left.Match(post) && e.Right.Match(post)
+}
+
+func (e *AndExpr) String() string {
+	return fmt.Sprintf("(%s AND %s)", e.Left, e.Right)
+}
+
+// OrExpr matches posts that satisfy at least one operand.
+type OrExpr struct {
+	Left, Right Expr
+}
+
+func (e *OrExpr) Match(post *models.BlogPost) bool {
+	return e.Left.Match(post) || e.Right.Match(post)
+}
+
+func (e *OrExpr) String() string {
+	return fmt.Sprintf("(%s OR %s)", e.Left, e.Right)
+}
+
+// NotExpr negates its operand.
+type NotExpr struct {
+	Expr Expr
+}
+
+func (e *NotExpr) Match(post *models.BlogPost) bool {
+	return !e.Expr.Match(post)
+}
+
+func (e *NotExpr) String() string {
+	return fmt.Sprintf("NOT %s", e.Expr)
+}
+
+// Comparison compares a single post field against a literal value.
+type Comparison struct {
+	Field string
+	Op    string
+	Value string
+
+	// time holds the parsed value for timestamp fields.
+	time time.Time
+}
+
+func (c *Comparison) Match(post *models.BlogPost) bool {
+	f := fields[c.Field]
+	switch f.kind {
+	case kindTime:
+		return compareTime(f.time(post), c.Op, c.time)
+	case kindList:
+		// Only the has operator is allowed on lists
+		for _, v := range f.list(post) {
+			if strings.EqualFold(v, c.Value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return compareString(f.str(post), c.Op, c.Value)
+	}
+}
+
+func (c *Comparison) String() string {
+	return fmt.Sprintf("%s %s %q", c.Field, c.Op, c.Value)
+}
+
+// Time returns the parsed value of a comparison on a timestamp field.
+func (c *Comparison) Time() time.Time {
+	return c.time
+}
+
+func compareString(v, op, want string) bool {
+	switch op {
+	case "=":
+		return v == want
+	case "!=":
+		return v != want
+	case ":":
+		return strings.Contains(strings.ToLower(v), strings.ToLower(want))
+	case "<":
+		return v < want
+	case "<=":
+		return v <= want
+	case ">":
+		return v > want
+	case ">=":
+		return v >= want
+	}
+	return false
+}
+
+func compareTime(v time.Time, op string, want time.Time) bool {
+	switch op {
+	case "=":
+		return v.Equal(want)
+	case "!=":
+		return !v.Equal(want)
+	case "<":
+		return v.Before(want)
+	case "<=":
+		return !v.After(want)
+	case ">":
+		return v.After(want)
+	case ">=":
+		return !v.Before(want)
+	}
+	return false
+}
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindTime
+	kindList
+)
+
+// field describes a filterable and sortable post attribute.
+type field struct {
+	kind fieldKind
+	// sortable reports whether the field can be used in order_by.
+	sortable bool
+	str      func(*models.BlogPost) string
+	time     func(*models.BlogPost) time.Time
+	list     func(*models.BlogPost) []string
+}
+
+var fields = map[string]field{
+	"post_id":   {kind: kindString, sortable: true, str: func(p *models.BlogPost) string { return p.PostId }},
+	"title":     {kind: kindString, sortable: true, str: func(p *models.BlogPost) string { return p.Title }},
+	"content":   {kind: kindString, str: func(p *models.BlogPost) string { return p.Content }},
+	"author":    {kind: kindString, sortable: true, str: func(p *models.BlogPost) string { return p.Author }},
+	"author_id": {kind: kindString, str: func(p *models.BlogPost) string { return p.AuthorId }},
+	"publication_date": {kind: kindTime, sortable: true, time: func(p *models.BlogPost) time.Time {
+		return p.PublicationDate
+	}},
+	"updated_at": {kind: kindTime, sortable: true, time: func(p *models.BlogPost) time.Time {
+		return p.UpdatedAt
+	}},
+	"tags":   {kind: kindList, list: func(p *models.BlogPost) []string { return p.Tags }},
+	"status": {kind: kindString, str: func(p *models.BlogPost) string { return string(p.Status) }},
+}
+
+// operators lists the comparison operators each field kind accepts.
+var operators = map[fieldKind][]string{
+	kindString: {"=", "!=", ":", "<", "<=", ">", ">="},
+	kindTime:   {"=", "!=", "<", "<=", ">", ">="},
+	kindList:   {":"},
+}
+
+// timeLayouts are the accepted formats for timestamp values.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
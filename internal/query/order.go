@@ -0,0 +1,93 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+// DefaultOrderBy is the listing order used when no order_by is given.
+const DefaultOrderBy = "publication_date desc"
+
+// OrderBy sorts posts by a single field. Ties are always broken by post ID in
+// ascending order so that the ordering is total and pagination is stable.
+type OrderBy struct {
+	Field string
+	Desc  bool
+}
+
+// ParseOrderBy parses an order_by expression of the form "field [asc|desc]".
+// An empty expression yields DefaultOrderBy.
+func ParseOrderBy(orderBy string) (*OrderBy, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		orderBy = DefaultOrderBy
+	}
+	order, err := parseOrderBy(orderBy)
+	if err != nil {
+		err.Field = "order_by"
+		return nil, err
+	}
+	return order, nil
+}
+
+func parseOrderBy(orderBy string) (*OrderBy, *ParseError) {
+	tokens, err := lex(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldTok := tokens[0]
+	if fieldTok.kind != tokenIdent {
+		return nil, errorf(fieldTok.pos, "unexpected %s, expected a field name", fieldTok)
+	}
+	f, ok := fields[fieldTok.text]
+	if !ok {
+		return nil, errorf(fieldTok.pos, "unknown field '%s'", fieldTok.text)
+	}
+	if !f.sortable {
+		return nil, errorf(fieldTok.pos, "cannot order by field '%s'", fieldTok.text)
+	}
+
+	order := &OrderBy{Field: fieldTok.text}
+	rest := tokens[1:]
+	if rest[0].kind == tokenIdent {
+		switch strings.ToLower(rest[0].text) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return nil, errorf(rest[0].pos, "unexpected %s, expected asc or desc", rest[0])
+		}
+		rest = rest[1:]
+	}
+	if rest[0].kind != tokenEOF {
+		return nil, errorf(rest[0].pos, "unexpected %s, expected end of order_by", rest[0])
+	}
+	return order, nil
+}
+
+// Less reports whether a sorts before b.
+func (o *OrderBy) Less(a, b *models.BlogPost) bool {
+	f := fields[o.Field]
+	var cmp int
+	switch f.kind {
+	case kindTime:
+		cmp = f.time(a).Compare(f.time(b))
+	default:
+		cmp = strings.Compare(f.str(a), f.str(b))
+	}
+	if o.Desc {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	return a.PostId < b.PostId
+}
+
+func (o *OrderBy) String() string {
+	if o.Desc {
+		return o.Field + " desc"
+	}
+	return o.Field + " asc"
+}
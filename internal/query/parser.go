@@ -0,0 +1,166 @@
+// Package query parses the filter and order_by expressions accepted by the
+// post listing API.
+//
+// Filters combine field comparisons with AND, OR, NOT and parentheses:
+//
+//	author = "x" AND tags:"go" AND publication_date > "2025-01-01"
+//
+// String fields support = != < <= > >= and ':' (case-insensitive contains),
+// timestamp fields support = != < <= > >= against RFC 3339 or YYYY-MM-DD
+// values, and tags only support ':' (has tag). status is one of "draft",
+// "scheduled", "published" or "archived".
+package query
+
+import (
+	"slices"
+	"strings"
+)
+
+// Parse parses a filter expression. An empty filter returns a nil Expr,
+// which Match treats as matching every post.
+func Parse(filter string) (Expr, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	expr, err := parseFilter(filter)
+	if err != nil {
+		err.Field = "filter"
+		return nil, err
+	}
+	return expr, nil
+}
+
+func parseFilter(filter string) (Expr, *ParseError) {
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "unexpected %s, expected AND or OR", tok)
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && tok.text == word
+}
+
+// parseOr handles: and_expr { "OR" and_expr }
+func (p *parser) parseOr() (Expr, *ParseError) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &OrExpr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd handles: unary { "AND" unary }
+func (p *parser) parseAnd() (Expr, *ParseError) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &AndExpr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary handles: "NOT" unary | "(" or_expr ")" | comparison
+func (p *parser) parseUnary() (Expr, *ParseError) {
+	if p.isKeyword("NOT") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Expr: expr}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, errorf(tok.pos, "unexpected %s, expected ')'", tok)
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison handles: field operator value
+func (p *parser) parseComparison() (Expr, *ParseError) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenIdent || isReserved(fieldTok.text) {
+		return nil, errorf(fieldTok.pos, "unexpected %s, expected a field name", fieldTok)
+	}
+	f, ok := fields[fieldTok.text]
+	if !ok {
+		return nil, errorf(fieldTok.pos, "unknown field '%s'", fieldTok.text)
+	}
+
+	opTok := p.next()
+	if opTok.kind != tokenOperator {
+		return nil, errorf(opTok.pos, "unexpected %s, expected a comparison operator", opTok)
+	}
+	if !slices.Contains(operators[f.kind], opTok.text) {
+		return nil, errorf(opTok.pos, "operator '%s' is not supported for field '%s'", opTok.text, fieldTok.text)
+	}
+
+	valueTok := p.next()
+	if valueTok.kind != tokenString && (valueTok.kind != tokenIdent || isReserved(valueTok.text)) {
+		return nil, errorf(valueTok.pos, "unexpected %s, expected a value", valueTok)
+	}
+
+	cmp := &Comparison{Field: fieldTok.text, Op: opTok.text, Value: valueTok.text}
+	if f.kind == kindTime {
+		t, ok := parseTime(valueTok.text)
+		if !ok {
+			return nil, errorf(valueTok.pos, "invalid timestamp %q for field '%s'", valueTok.text, fieldTok.text)
+		}
+		cmp.time = t
+	}
+	return cmp, nil
+}
+
+func isReserved(word string) bool {
+	return word == "AND" || word == "OR" || word == "NOT"
+}
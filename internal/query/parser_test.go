@@ -0,0 +1,141 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/pandae7/go-blogger/internal/models"
+)
+
+func testPost() *models.BlogPost {
+	return &models.BlogPost{
+		PostId:          "p1",
+		Title:           "Learning Go Generics",
+		Content:         "Type parameters are here.",
+		Author:          "x",
+		PublicationDate: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+		Tags:            []string{"go", "generics"},
+		Status:          models.StatusPublished,
+	}
+}
+
+func TestParse_Match(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{``, true},
+		{`author = "x"`, true},
+		{`author != "x"`, false},
+		{`author = "x" AND tags:"go" AND publication_date > "2025-01-01"`, true},
+		{`author = "x" AND publication_date < 2025-01-01`, false},
+		{`tags:"rust" OR tags:"GO"`, true},
+		{`NOT tags:"go"`, false},
+		{`title:"generics"`, true},
+		{`(author = "y" OR author = "x") AND NOT title:"rust"`, true},
+		{`publication_date >= "2025-03-10T12:00:00Z"`, true},
+		{`updated_at > "2025-01-01"`, false},
+		{`title = "say \"hi\""`, false},
+		{`status = "published"`, true},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.filter)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.filter, err)
+			continue
+		}
+		if got := Match(expr, testPost()); got != tt.want {
+			t.Errorf("Parse(%q) matched %v, want %v (ast: %v)", tt.filter, got, tt.want, expr)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{``, false},
+		{`author = "x"`, false},
+		{`status = "draft"`, true},
+		{`author = "x" AND (tags:"go" OR NOT status = "draft")`, true},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.filter, err)
+		}
+		if got := References(expr, "status"); got != tt.want {
+			t.Errorf("References(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Precedence(t *testing.T) {
+	expr, err := Parse(`author = "a" OR author = "b" AND tags:"go"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := expr.(*OrExpr); !ok {
+		t.Errorf("expected AND to bind tighter than OR, got %v", expr)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		filter string
+		pos    int
+	}{
+		{`author = `, 10},
+		{`writer = "x"`, 1},
+		{`author = "x" AND`, 17},
+		{`author = "x" tags:"go"`, 14},
+		{`tags = "go"`, 6},
+		{`publication_date > "yesterday"`, 20},
+		{`(author = "x"`, 14},
+		{`title = "open`, 9},
+		{`title # "x"`, 7},
+		{`content:"x" OR OR`, 16},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.filter)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Parse(%q) expected ParseError, got: %v", tt.filter, err)
+			continue
+		}
+		if parseErr.Pos != tt.pos {
+			t.Errorf("Parse(%q) error at position %d, want %d (%v)", tt.filter, parseErr.Pos, tt.pos, err)
+		}
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	older := testPost()
+	newer := testPost()
+	newer.PostId = "p2"
+	newer.PublicationDate = older.PublicationDate.Add(time.Hour)
+
+	order, err := ParseOrderBy("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !order.Less(newer, older) {
+		t.Errorf("default order should put newer posts first")
+	}
+
+	order, err = ParseOrderBy("publication_date asc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !order.Less(older, newer) {
+		t.Errorf("ascending order should put older posts first")
+	}
+
+	for _, bad := range []string{"tags", "content desc", "title sideways", "title asc extra", "nope"} {
+		if _, err := ParseOrderBy(bad); err == nil {
+			t.Errorf("ParseOrderBy(%q) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Query is a parsed search query.
+type Query struct {
+	clauses []clause
+}
+
+// clause is a word, or a phrase of words that must follow each other. With
+// prefix set, the last word matches any word that starts with it.
+type clause struct {
+	terms  []string
+	prefix bool
+}
+
+func (c clause) String() string {
+	s := strings.Join(c.terms, " ")
+	if len(c.terms) > 1 {
+		s = `"` + s + `"`
+	}
+	if c.prefix {
+		s += "*"
+	}
+	return s
+}
+
+// ParseQuery parses a search query. Words are separated by spaces, "double
+// quotes" group words into a phrase and a trailing * turns a word, or the
+// last word of a phrase, into a prefix. A word made of several parts, such
+// as grpc-go, is a phrase of those parts. Parsing never fails: a missing
+// closing quote ends the phrase at the end of the query, and punctuation is
+// ignored.
+func ParseQuery(s string) *Query {
+	q := &Query{}
+	seen := make(map[string]bool)
+	add := func(text string, prefix bool) {
+		tokens := tokenize(text)
+		if len(tokens) == 0 {
+			return
+		}
+		c := clause{prefix: prefix}
+		for _, tok := range tokens {
+			c.terms = append(c.terms, tok.term)
+		}
+		if key := c.String(); !seen[key] {
+			seen[key] = true
+			q.clauses = append(q.clauses, c)
+		}
+	}
+
+	for s != "" {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if strings.HasPrefix(s, `"`) {
+			phrase, rest, _ := strings.Cut(s[1:], `"`)
+			add(phrase, strings.HasSuffix(phrase, "*"))
+			s = rest
+			continue
+		}
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '"'
+		})
+		if end < 0 {
+			end = len(s)
+		}
+		word := s[:end]
+		add(word, strings.HasSuffix(word, "*"))
+		s = s[end:]
+	}
+	return q
+}
+
+// Empty reports whether the query has nothing to search for.
+func (q *Query) Empty() bool {
+	return q == nil || len(q.clauses) == 0
+}
+
+// String returns the query in canonical form, so that queries that only
+// differ in case, spacing or punctuation are equal.
+func (q *Query) String() string {
+	parts := make([]string, len(q.clauses))
+	for i, c := range q.clauses {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " ")
+}
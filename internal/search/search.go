@@ -0,0 +1,445 @@
+// Package search is an in-memory full-text index over the title, content and
+// tags of blog posts.
+//
+// Text is split into lowercase words of letters and digits. Every query
+// clause, whether a word, a "quoted phrase" or a prefix such as gram*, must
+// match a document somewhere for it to be a hit. Hits are ranked with BM25,
+// scoring each clause like a single term and weighting matches in each field
+// by its boost, so that a word in the title counts for more than the same
+// word in the content. Similar finds the documents closest to a given one,
+// such as a draft, without a query.
+//
+// An Index is not safe for concurrent use; callers synchronize access.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Field is a searchable part of a document.
+type Field int
+
+const (
+	Title Field = iota
+	Content
+	Tags
+	numFields
+)
+
+func (f Field) String() string {
+	switch f {
+	case Title:
+		return "title"
+	case Content:
+		return "content"
+	}
+	return "tags"
+}
+
+// Document is the searchable text of a post.
+type Document struct {
+	Id      string
+	Title   string
+	Content string
+	Tags    []string
+}
+
+// Boosts weight the score of the matches in each field.
+type Boosts struct {
+	Title   float64
+	Content float64
+	Tags    float64
+}
+
+// DefaultBoosts rank a match in the title above one in the tags, and both
+// above one in the content.
+var DefaultBoosts = Boosts{Title: 3, Content: 1, Tags: 2}
+
+func (b Boosts) weight(f Field) float64 {
+	switch f {
+	case Title:
+		return b.Title
+	case Content:
+		return b.Content
+	}
+	return b.Tags
+}
+
+// BM25 parameters: bm25K1 controls how quickly repeated matches stop adding
+// to the score, bm25B how much long fields are penalized.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// MaxExpansions caps the number of indexed words a prefix stands for. When
+// more words start with the prefix, the most common ones are used.
+const MaxExpansions = 50
+
+// posting holds the positions of a word in each field of a document.
+type posting [numFields][]int
+
+type docInfo struct {
+	lengths [numFields]int
+	terms   []string
+}
+
+// Index maps every word to the documents it occurs in.
+type Index struct {
+	postings map[string]map[string]*posting
+	docs     map[string]*docInfo
+	totalLen [numFields]int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string]*posting),
+		docs:     make(map[string]*docInfo),
+	}
+}
+
+// Len returns the number of indexed documents.
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+// Add indexes the document, replacing any earlier version of it.
+func (idx *Index) Add(doc Document) {
+	idx.Remove(doc.Id)
+
+	info := &docInfo{}
+	fields := [numFields][]token{tokenize(doc.Title), tokenize(doc.Content), tokenizeTags(doc.Tags)}
+	for f, tokens := range fields {
+		info.lengths[f] = len(tokens)
+		idx.totalLen[f] += len(tokens)
+		for _, tok := range tokens {
+			docs, exists := idx.postings[tok.term]
+			if !exists {
+				docs = make(map[string]*posting)
+				idx.postings[tok.term] = docs
+			}
+			p, exists := docs[doc.Id]
+			if !exists {
+				p = &posting{}
+				docs[doc.Id] = p
+				info.terms = append(info.terms, tok.term)
+			}
+			p[f] = append(p[f], tok.pos)
+		}
+	}
+	idx.docs[doc.Id] = info
+}
+
+// Remove drops the document from the index, if it is there.
+func (idx *Index) Remove(id string) {
+	info, exists := idx.docs[id]
+	if !exists {
+		return
+	}
+	for _, term := range info.terms {
+		docs := idx.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	for f, n := range info.lengths {
+		idx.totalLen[f] -= n
+	}
+	delete(idx.docs, id)
+}
+
+// Hit is a document that matches a query.
+type Hit struct {
+	Id    string
+	Score float64
+}
+
+// Search returns every document that matches all the clauses of the query,
+// best first, with the document ID as tiebreaker.
+func (idx *Index) Search(q *Query, boosts Boosts) []Hit {
+	if q.Empty() || len(idx.docs) == 0 {
+		return nil
+	}
+
+	var scores map[string]float64
+	for _, c := range q.clauses {
+		clauseScores := idx.score(c, boosts)
+		if scores == nil {
+			scores = clauseScores
+		} else {
+			for id := range scores {
+				if s, ok := clauseScores[id]; ok {
+					scores[id] += s
+				} else {
+					delete(scores, id)
+				}
+			}
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{Id: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		return HitLess(hits[i], hits[j])
+	})
+	return hits
+}
+
+// HitLess orders hits best first, then by ID.
+func HitLess(a, b Hit) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Id < b.Id
+}
+
+// MaxSimilarTerms caps the number of words of a document that Similar
+// searches for.
+const MaxSimilarTerms = 25
+
+// Similar returns up to limit indexed documents that share words with doc,
+// best first, leaving out doc itself. The words of doc that are rarest in the
+// index and most frequent in doc, weighted by the boost of the field they
+// occur in, are searched for, and a document scores the sum of the BM25
+// scores of those it contains.
+func (idx *Index) Similar(doc Document, boosts Boosts, limit int) []Hit {
+	if len(idx.docs) == 0 || limit <= 0 {
+		return nil
+	}
+
+	weights := make(map[string]float64)
+	fields := [numFields][]token{tokenize(doc.Title), tokenize(doc.Content), tokenizeTags(doc.Tags)}
+	for f, tokens := range fields {
+		for _, tok := range tokens {
+			if _, exists := idx.postings[tok.term]; exists {
+				weights[tok.term] += boosts.weight(Field(f))
+			}
+		}
+	}
+	n := float64(len(idx.docs))
+	terms := make([]string, 0, len(weights))
+	for term := range weights {
+		df := float64(len(idx.postings[term]))
+		weights[term] *= math.Log(1 + (n-df+0.5)/(df+0.5))
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if weights[terms[i]] != weights[terms[j]] {
+			return weights[terms[i]] > weights[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	if len(terms) > MaxSimilarTerms {
+		terms = terms[:MaxSimilarTerms]
+	}
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		for id, score := range idx.score(clause{terms: []string{term}}, boosts) {
+			if id != doc.Id {
+				scores[id] += score
+			}
+		}
+	}
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{Id: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		return HitLess(hits[i], hits[j])
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// score computes the BM25 score of a clause in every document it occurs in.
+// A phrase or a prefix is scored as if it were a single word, with its own
+// document frequency.
+func (idx *Index) score(c clause, boosts Boosts) map[string]float64 {
+	counts := idx.occurrences(c)
+	if len(counts) == 0 {
+		return nil
+	}
+
+	n := float64(len(idx.docs))
+	df := float64(len(counts))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+	scores := make(map[string]float64, len(counts))
+	for id, tf := range counts {
+		info := idx.docs[id]
+		var score float64
+		for f := Field(0); f < numFields; f++ {
+			if tf[f] == 0 {
+				continue
+			}
+			avgLen := float64(idx.totalLen[f]) / n
+			norm := 1 - bm25B + bm25B*float64(info.lengths[f])/avgLen
+			freq := float64(tf[f])
+			score += boosts.weight(f) * idf * freq * (bm25K1 + 1) / (freq + bm25K1*norm)
+		}
+		scores[id] = score
+	}
+	return scores
+}
+
+// occurrences counts the matches of a clause in each field of every document
+// it occurs in.
+func (idx *Index) occurrences(c clause) map[string]*[numFields]int {
+	slots := make([][]string, len(c.terms))
+	for i, term := range c.terms {
+		if c.prefix && i == len(c.terms)-1 {
+			slots[i] = idx.expand(term)
+		} else if _, exists := idx.postings[term]; exists {
+			slots[i] = []string{term}
+		}
+		if len(slots[i]) == 0 {
+			return nil
+		}
+	}
+
+	counts := make(map[string]*[numFields]int)
+	if len(slots) == 1 {
+		for _, term := range slots[0] {
+			for id, p := range idx.postings[term] {
+				tf, exists := counts[id]
+				if !exists {
+					tf = &[numFields]int{}
+					counts[id] = tf
+				}
+				for f := range p {
+					tf[f] += len(p[f])
+				}
+			}
+		}
+		return counts
+	}
+
+	// A phrase occurs where every word follows the previous one
+	for id := range idx.candidates(slots) {
+		var tf [numFields]int
+		for f := Field(0); f < numFields; f++ {
+			positions := make([]map[int]bool, len(slots))
+			for i, slot := range slots {
+				positions[i] = make(map[int]bool)
+				for _, term := range slot {
+					if p, ok := idx.postings[term][id]; ok {
+						for _, pos := range p[f] {
+							positions[i][pos] = true
+						}
+					}
+				}
+			}
+			for start := range positions[0] {
+				matched := true
+				for i := 1; i < len(slots) && matched; i++ {
+					matched = positions[i][start+i]
+				}
+				if matched {
+					tf[f]++
+				}
+			}
+		}
+		if tf != [numFields]int{} {
+			counts[id] = &tf
+		}
+	}
+	return counts
+}
+
+// candidates returns the documents that contain a word of every slot.
+func (idx *Index) candidates(slots [][]string) map[string]bool {
+	var docs map[string]bool
+	for _, slot := range slots {
+		inSlot := make(map[string]bool)
+		for _, term := range slot {
+			for id := range idx.postings[term] {
+				if docs == nil || docs[id] {
+					inSlot[id] = true
+				}
+			}
+		}
+		docs = inSlot
+	}
+	return docs
+}
+
+// expand returns the indexed words that start with prefix, most common
+// first, up to MaxExpansions of them.
+func (idx *Index) expand(prefix string) []string {
+	var terms []string
+	for term := range idx.postings {
+		if strings.HasPrefix(term, prefix) {
+			terms = append(terms, term)
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		di, dj := len(idx.postings[terms[i]]), len(idx.postings[terms[j]])
+		if di != dj {
+			return di > dj
+		}
+		return terms[i] < terms[j]
+	})
+	if len(terms) > MaxExpansions {
+		terms = terms[:MaxExpansions]
+	}
+	return terms
+}
+
+// token is a word of a text, with its position among the words of the field
+// and its byte offsets in the text.
+type token struct {
+	term       string
+	pos        int
+	start, end int
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenize splits text into lowercase words of letters and digits.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		switch {
+		case isWordRune(r) && start < 0:
+			start = i
+		case !isWordRune(r) && start >= 0:
+			tokens = append(tokens, token{term: strings.ToLower(text[start:i]), pos: len(tokens), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{term: strings.ToLower(text[start:]), pos: len(tokens), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// tokenizeTags tokenizes every tag, leaving a gap in the positions between
+// tags so that a phrase cannot span two of them.
+func tokenizeTags(tags []string) []token {
+	var tokens []token
+	pos := 0
+	for _, tag := range tags {
+		for _, tok := range tokenize(tag) {
+			tok.pos = pos
+			tokens = append(tokens, tok)
+			pos++
+		}
+		pos++
+	}
+	return tokens
+}
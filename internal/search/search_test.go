@@ -0,0 +1,196 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newTestIndex() *Index {
+	idx := NewIndex()
+	for _, doc := range []Document{
+		{Id: "grpc", Title: "Getting started with gRPC", Content: "Protocol buffers describe the service.", Tags: []string{"grpc", "go"}},
+		{Id: "go", Title: "Go concurrency", Content: "Goroutines and channels make concurrent programs simple.", Tags: []string{"go"}},
+		{Id: "streams", Title: "Streaming", Content: "gRPC supports server streaming and bidirectional streaming in Go.", Tags: []string{"grpc"}},
+		{Id: "rust", Title: "Rust ownership", Content: "Borrowing rules, not garbage collection.", Tags: []string{"rust"}},
+	} {
+		idx.Add(doc)
+	}
+	return idx
+}
+
+func ids(hits []Hit) string {
+	var out []string
+	for _, hit := range hits {
+		out = append(out, hit.Id)
+	}
+	return fmt.Sprint(out)
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`Go  gRPC`, `go grpc`},
+		{`"server Streaming" go`, `"server streaming" go`},
+		{`gorout* grpc-go`, `gorout* "grpc go"`},
+		{`go GO "unterminated phrase`, `go "unterminated phrase"`},
+		{`  "" ** !! `, ``},
+	}
+	for _, tt := range tests {
+		if got := ParseQuery(tt.query).String(); got != tt.want {
+			t.Errorf("ParseQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+	if !ParseQuery(" !? ").Empty() {
+		t.Errorf("expected a query without words to be empty")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := newTestIndex()
+	tests := []struct {
+		query string
+		want  string
+	}{
+		// Every clause must match
+		{`grpc go`, `[grpc streams]`},
+		{`rust`, `[rust]`},
+		{`missing`, `[]`},
+		{`grpc missing`, `[]`},
+		// Phrases must match in order
+		{`"server streaming"`, `[streams]`},
+		{`"streaming server"`, `[]`},
+		// A phrase cannot span two tags
+		{`"grpc go"`, `[]`},
+		// Prefixes match any word starting with them
+		{`concurr*`, `[go]`},
+		{`"bidirectional stream*"`, `[streams]`},
+	}
+	for _, tt := range tests {
+		if got := ids(idx.Search(ParseQuery(tt.query), DefaultBoosts)); got != tt.want {
+			t.Errorf("Search(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearch_Ranking(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(Document{Id: "content", Title: "Notes", Content: "A few words about kubernetes operators."})
+	idx.Add(Document{Id: "title", Title: "Kubernetes operators", Content: "A few words about writing them."})
+	idx.Add(Document{Id: "other", Title: "Other", Content: "Nothing to see."})
+
+	// Title matches outrank content matches with the default boosts
+	if got := ids(idx.Search(ParseQuery("kubernetes"), DefaultBoosts)); got != "[title content]" {
+		t.Errorf("expected the title match first, got %s", got)
+	}
+	// and the other way around when content is boosted
+	if got := ids(idx.Search(ParseQuery("kubernetes"), Boosts{Title: 1, Content: 10})); got != "[content title]" {
+		t.Errorf("expected the content match first, got %s", got)
+	}
+
+	// Repeated words count, with diminishing returns
+	idx.Add(Document{Id: "repeat", Title: "Notes", Content: "Kubernetes, kubernetes, kubernetes operators."})
+	hits := idx.Search(ParseQuery("kubernetes"), Boosts{Content: 1})
+	if ids(hits) != "[repeat content title]" {
+		t.Fatalf("expected the repeated word first, got %s", ids(hits))
+	}
+	if hits[0].Score >= 3*hits[1].Score {
+		t.Errorf("expected term frequency to saturate, got %v", hits)
+	}
+
+	// Rare words weigh more than common ones
+	hits = idx.Search(ParseQuery("few"), DefaultBoosts)
+	rare := idx.Search(ParseQuery("writing"), DefaultBoosts)
+	if rare[0].Score <= hits[0].Score {
+		t.Errorf("expected a rare word to score higher, got %v and %v", rare, hits)
+	}
+}
+
+func TestIndex_AddReplacesAndRemove(t *testing.T) {
+	idx := newTestIndex()
+	idx.Add(Document{Id: "rust", Title: "Rust async", Content: "Futures and executors."})
+	if got := ids(idx.Search(ParseQuery("borrowing"), DefaultBoosts)); got != "[]" {
+		t.Errorf("expected the old version to be gone, got %s", got)
+	}
+	if got := ids(idx.Search(ParseQuery("futures"), DefaultBoosts)); got != "[rust]" {
+		t.Errorf("expected the new version to be found, got %s", got)
+	}
+
+	for _, id := range []string{"grpc", "go", "streams", "rust", "missing"} {
+		idx.Remove(id)
+	}
+	if idx.Len() != 0 || len(idx.postings) != 0 || idx.totalLen != [numFields]int{} {
+		t.Errorf("expected an empty index, got %d docs, %d words, lengths %v", idx.Len(), len(idx.postings), idx.totalLen)
+	}
+}
+
+func render(spans []Span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		if s.Match {
+			b.WriteString("[" + s.Text + "]")
+		} else {
+			b.WriteString(s.Text)
+		}
+	}
+	return b.String()
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		query, text, want string
+	}{
+		{`grpc`, `Getting started with gRPC!`, `Getting started with [gRPC]!`},
+		{`"server streaming" go`, `Server streaming, not streaming server, in Go`, `[Server streaming], not streaming server, in [Go]`},
+		{`stream*`, `Streams and streaming`, `[Streams] and [streaming]`},
+		{`missing`, `Nothing here`, `Nothing here`},
+	}
+	for _, tt := range tests {
+		if got := render(ParseQuery(tt.query).Highlight(tt.text)); got != tt.want {
+			t.Errorf("Highlight(%q, %q) = %q, want %q", tt.query, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog while the busy beaver builds a dam across the quiet river."
+	tests := []struct {
+		query string
+		width int
+		want  string
+	}{
+		{`beaver`, 30, `…the busy [beaver] builds a dam…`},
+		{`quick`, 20, `The [quick] brown fox…`},
+		{`river`, 30, `…a dam across the quiet [river].`},
+		{`missing`, 20, `The quick brown fox…`},
+		{`dog`, 200, `The quick brown fox jumps over the lazy [dog] while the busy beaver builds a dam across the quiet river.`},
+	}
+	for _, tt := range tests {
+		if got := render(ParseQuery(tt.query).Snippet(text, tt.width)); got != tt.want {
+			t.Errorf("Snippet(%q, %d) = %q, want %q", tt.query, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	idx := newTestIndex()
+
+	// A draft about gRPC streaming is closest to the streaming post
+	draft := Document{Title: "Bidirectional streaming", Content: "Streaming with gRPC in Go."}
+	if got := ids(idx.Similar(draft, DefaultBoosts, 10)); got != "[streams grpc go]" {
+		t.Errorf("Similar(draft) = %s, want [streams grpc go]", got)
+	}
+	if got := ids(idx.Similar(draft, DefaultBoosts, 1)); got != "[streams]" {
+		t.Errorf("expected the limit to apply, got %s", got)
+	}
+
+	// An indexed document is not similar to itself
+	if got := ids(idx.Similar(Document{Id: "rust", Title: "Rust ownership"}, DefaultBoosts, 10)); got != "[]" {
+		t.Errorf("expected no other document to be similar, got %s", got)
+	}
+	if got := ids(idx.Similar(Document{Content: "Nothing shared"}, DefaultBoosts, 10)); got != "[]" {
+		t.Errorf("expected no similar documents, got %s", got)
+	}
+}
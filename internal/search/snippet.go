@@ -0,0 +1,167 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ellipsis marks text left out of a snippet.
+const Ellipsis = "…"
+
+// Span is a run of text that does or does not match the query.
+type Span struct {
+	Text  string
+	Match bool
+}
+
+// Highlight splits the whole text into spans, marking the words and phrases
+// that match the query.
+func (q *Query) Highlight(text string) []Span {
+	return spans(text, 0, len(text), q.matches(text))
+}
+
+// Snippet returns the part of the text around the first match, about width
+// characters long and cut at word boundaries, split into spans as by
+// Highlight. Text left out at either end is replaced with an Ellipsis span.
+// Without a match the snippet is taken from the start of the text.
+func (q *Query) Snippet(text string, width int) []Span {
+	ranges := q.matches(text)
+	focus, focusEnd := 0, 0
+	if len(ranges) > 0 {
+		focus, focusEnd = ranges[0][0], ranges[0][1]
+	}
+
+	// Show a little context before the match, starting on a word, and more
+	// of it when the match is near the end
+	lo := min(backRunes(text, focus, width/3), backRunes(text, len(text), width))
+	start := 0
+	if lo > 0 {
+		start = focus
+		for _, tok := range tokenize(text[lo:focus]) {
+			if tok.start > 0 || !isWordRune(lastRune(text[:lo])) {
+				start = lo + tok.start
+				break
+			}
+		}
+	}
+
+	end := len(text)
+	if hi := start + forwardRunes(text[start:], width); hi < len(text) {
+		end = hi
+		// Do not cut the last word in two
+		if isWordRune(lastRune(text[:hi])) && isWordRune(firstRune(text[hi:])) {
+			if i := strings.LastIndexFunc(text[start:hi], func(r rune) bool { return !isWordRune(r) }); i >= 0 {
+				end = start + i
+			}
+		}
+		end = start + len(strings.TrimRightFunc(text[start:end], unicode.IsSpace))
+		end = max(end, focusEnd)
+	}
+
+	var out []Span
+	if start > 0 {
+		out = append(out, Span{Text: Ellipsis})
+	}
+	out = append(out, spans(text, start, end, ranges)...)
+	if end < len(text) {
+		out = append(out, Span{Text: Ellipsis})
+	}
+	return out
+}
+
+// matches returns the sorted, non-overlapping byte ranges of the text that
+// match a clause of the query.
+func (q *Query) matches(text string) [][2]int {
+	if q.Empty() {
+		return nil
+	}
+	tokens := tokenize(text)
+	var ranges [][2]int
+	for _, c := range q.clauses {
+		for i := 0; i+len(c.terms) <= len(tokens); i++ {
+			if c.matchAt(tokens[i:]) {
+				ranges = append(ranges, [2]int{tokens[i].start, tokens[i+len(c.terms)-1].end})
+			}
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// matchAt reports whether the clause matches the words at the start of
+// tokens.
+func (c clause) matchAt(tokens []token) bool {
+	for i, term := range c.terms {
+		if c.prefix && i == len(c.terms)-1 {
+			if !strings.HasPrefix(tokens[i].term, term) {
+				return false
+			}
+		} else if tokens[i].term != term {
+			return false
+		}
+	}
+	return true
+}
+
+// spans splits text[start:end] into spans, marking the given ranges.
+func spans(text string, start, end int, ranges [][2]int) []Span {
+	var out []Span
+	pos := start
+	for _, r := range ranges {
+		lo, hi := max(r[0], start), min(r[1], end)
+		if lo >= hi {
+			continue
+		}
+		if pos < lo {
+			out = append(out, Span{Text: text[pos:lo]})
+		}
+		out = append(out, Span{Text: text[lo:hi], Match: true})
+		pos = hi
+	}
+	if pos < end {
+		out = append(out, Span{Text: text[pos:end]})
+	}
+	return out
+}
+
+// backRunes returns the byte offset n characters before offset, or 0.
+func backRunes(text string, offset, n int) int {
+	for ; n > 0 && offset > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(text[:offset])
+		offset -= size
+	}
+	return offset
+}
+
+// forwardRunes returns the byte length of the first n characters of text.
+func forwardRunes(text string, n int) int {
+	offset := 0
+	for ; n > 0 && offset < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func lastRune(text string) rune {
+	r, _ := utf8.DecodeLastRuneInString(text)
+	return r
+}
+
+func firstRune(text string) rune {
+	r, _ := utf8.DecodeRuneInString(text)
+	return r
+}
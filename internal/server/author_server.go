@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+	models "github.com/pandae7/go-blogger/internal/models"
+	storage "github.com/pandae7/go-blogger/internal/storage"
+	pb "github.com/pandae7/go-blogger/proto/blog"
+	log "github.com/sirupsen/logrus"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AuthorServiceServer struct {
+	pb.UnimplementedAuthorServiceServer
+	storage storage.AuthorStorage
+}
+
+func NewAuthorServiceServer(storage storage.AuthorStorage) *AuthorServiceServer {
+	return &AuthorServiceServer{
+		storage: storage,
+	}
+}
+
+func (s *AuthorServiceServer) CreateAuthor(ctx context.Context, req *pb.CreateAuthorRequest) (*pb.CreateAuthorResponse, error) {
+	log.Infof("Creating new author with profile name: %s", req.GetProfileName())
+
+	if err := s.validateCreateAuthorRequest(req); err != nil {
+		log.Errorf("Invalid request: %v", err)
+		return &pb.CreateAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	author := &models.Author{
+		AuthorId:    uuid.New().String(),
+		Name:        strings.TrimSpace(req.GetName()),
+		Email:       strings.TrimSpace(req.GetEmail()),
+		ProfileName: strings.TrimSpace(req.GetProfileName()),
+	}
+	if err := s.storage.CreateAuthor(ctx, author); err != nil {
+		log.Errorf("Failed to create author: %v", err)
+		return &pb.CreateAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Author created successfully with ID: %s", author.AuthorId)
+	return &pb.CreateAuthorResponse{
+		Author:  authorToProtobuf(author),
+		Success: true,
+		Message: "Author created successfully",
+	}, nil
+}
+
+func (s *AuthorServiceServer) GetAuthor(ctx context.Context, req *pb.GetAuthorRequest) (*pb.GetAuthorResponse, error) {
+	log.Infof("Fetching author with ID: %s", req.GetAuthorId())
+
+	if req.GetAuthorId() == "" {
+		return &pb.GetAuthorResponse{
+			Success: false,
+			Message: models.ErrInvalidAuthorID.Error(),
+		}, models.ErrInvalidAuthorID
+	}
+
+	author, err := s.storage.GetAuthor(ctx, req.GetAuthorId())
+	if err != nil {
+		return &pb.GetAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	return &pb.GetAuthorResponse{
+		Author:  authorToProtobuf(author),
+		Success: true,
+		Message: "Author fetched successfully",
+	}, nil
+}
+
+func (s *AuthorServiceServer) UpdateAuthor(ctx context.Context, req *pb.UpdateAuthorRequest) (*pb.UpdateAuthorResponse, error) {
+	log.Infof("Updating author with ID: %s", req.GetAuthorId())
+
+	if err := s.validateUpdateAuthorRequest(req); err != nil {
+		log.Errorf("Invalid request: %v", err)
+		return &pb.UpdateAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	author, err := s.storage.UpdateAuthor(ctx, &models.UpdateAuthorRequest{
+		AuthorId:    req.GetAuthorId(),
+		Name:        strings.TrimSpace(req.GetName()),
+		Email:       strings.TrimSpace(req.GetEmail()),
+		ProfileName: strings.TrimSpace(req.GetProfileName()),
+	})
+	if err != nil {
+		log.Errorf("Failed to update author: %v", err)
+		return &pb.UpdateAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Author updated successfully with ID: %s", author.AuthorId)
+	return &pb.UpdateAuthorResponse{
+		Author:  authorToProtobuf(author),
+		Success: true,
+		Message: "Author updated successfully",
+	}, nil
+}
+
+func (s *AuthorServiceServer) DeleteAuthor(ctx context.Context, req *pb.DeleteAuthorRequest) (*pb.DeleteAuthorResponse, error) {
+	log.Infof("Deleting author with ID: %s", req.GetAuthorId())
+
+	if req.GetAuthorId() == "" {
+		return &pb.DeleteAuthorResponse{
+			Success: false,
+			Message: models.ErrInvalidAuthorID.Error(),
+		}, models.ErrInvalidAuthorID
+	}
+
+	if err := s.storage.DeleteAuthor(ctx, req.GetAuthorId()); err != nil {
+		log.Errorf("Failed to delete author: %v", err)
+		return &pb.DeleteAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Author deleted successfully with ID: %s", req.GetAuthorId())
+	return &pb.DeleteAuthorResponse{
+		Success: true,
+		Message: "Author deleted successfully",
+	}, nil
+}
+
+func (s *AuthorServiceServer) ListAuthors(ctx context.Context, req *pb.ListAuthorsRequest) (*pb.ListAuthorsResponse, error) {
+	log.Infof("Listing authors with page size: %d", req.GetPageSize())
+
+	if req.GetPageSize() < 0 {
+		return &pb.ListAuthorsResponse{
+			Success: false,
+			Message: models.ErrInvalidPageSize.Error(),
+		}, models.ErrInvalidPageSize
+	}
+
+	authors, nextPageToken, err := s.storage.ListAuthors(ctx, &models.ListAuthorsRequest{
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return &pb.ListAuthorsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbAuthors := make([]*pb.Author, 0, len(authors))
+	for _, author := range authors {
+		pbAuthors = append(pbAuthors, authorToProtobuf(author))
+	}
+
+	return &pb.ListAuthorsResponse{
+		Authors:       pbAuthors,
+		NextPageToken: nextPageToken,
+		Success:       true,
+		Message:       "Authors listed successfully",
+	}, nil
+}
+
+func (s *AuthorServiceServer) validateCreateAuthorRequest(req *pb.CreateAuthorRequest) error {
+	if strings.TrimSpace(req.GetName()) == "" {
+		return models.ErrEmptyAuthorName
+	}
+	if strings.TrimSpace(req.GetProfileName()) == "" {
+		return models.ErrEmptyProfileName
+	}
+	if req.GetEmail() != "" && !validEmail(req.GetEmail()) {
+		return models.ErrInvalidEmail
+	}
+	return nil
+}
+
+func (s *AuthorServiceServer) validateUpdateAuthorRequest(req *pb.UpdateAuthorRequest) error {
+	if req.GetAuthorId() == "" {
+		return models.ErrInvalidAuthorID
+	}
+	name, email, profileName := strings.TrimSpace(req.GetName()), strings.TrimSpace(req.GetEmail()), strings.TrimSpace(req.GetProfileName())
+	if name == "" && email == "" && profileName == "" {
+		return models.ErrEmptyAuthorUpdate
+	}
+	if email != "" && !validEmail(email) {
+		return models.ErrInvalidEmail
+	}
+	return nil
+}
+
+// validEmail reports whether s is a bare email address, without a display
+// name or angle brackets.
+func validEmail(s string) bool {
+	s = strings.TrimSpace(s)
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func authorToProtobuf(author *models.Author) *pb.Author {
+	return &pb.Author{
+		AuthorId:    author.AuthorId,
+		Name:        author.Name,
+		Email:       author.Email,
+		ProfileName: author.ProfileName,
+		CreatedAt:   timestamppb.New(author.CreatedAt),
+		UpdatedAt:   timestamppb.New(author.UpdatedAt),
+	}
+}
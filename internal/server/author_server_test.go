@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	models "github.com/pandae7/go-blogger/internal/models"
+	pb "github.com/pandae7/go-blogger/proto/blog"
+)
+
+// mockAuthorStorage is a mock implementation of the AuthorStorage interface
+type mockAuthorStorage struct {
+	CreateAuthorFunc func(ctx context.Context, author *models.Author) error
+	GetAuthorFunc    func(ctx context.Context, authorID string) (*models.Author, error)
+	UpdateAuthorFunc func(ctx context.Context, req *models.UpdateAuthorRequest) (*models.Author, error)
+	DeleteAuthorFunc func(ctx context.Context, authorID string) error
+	ListAuthorsFunc  func(ctx context.Context, req *models.ListAuthorsRequest) ([]*models.Author, string, error)
+}
+
+func (m *mockAuthorStorage) CreateAuthor(ctx context.Context, author *models.Author) error {
+	return m.CreateAuthorFunc(ctx, author)
+}
+func (m *mockAuthorStorage) GetAuthor(ctx context.Context, authorID string) (*models.Author, error) {
+	return m.GetAuthorFunc(ctx, authorID)
+}
+func (m *mockAuthorStorage) UpdateAuthor(ctx context.Context, req *models.UpdateAuthorRequest) (*models.Author, error) {
+	return m.UpdateAuthorFunc(ctx, req)
+}
+func (m *mockAuthorStorage) DeleteAuthor(ctx context.Context, authorID string) error {
+	return m.DeleteAuthorFunc(ctx, authorID)
+}
+func (m *mockAuthorStorage) ListAuthors(ctx context.Context, req *models.ListAuthorsRequest) ([]*models.Author, string, error) {
+	return m.ListAuthorsFunc(ctx, req)
+}
+
+func TestCreateAuthor_Success(t *testing.T) {
+	mockStorage := &mockAuthorStorage{
+		CreateAuthorFunc: func(ctx context.Context, author *models.Author) error {
+			if author.AuthorId == "" || author.Name != "Aman Pandae" || author.ProfileName != "aman" {
+				t.Errorf("unexpected author: %+v", author)
+			}
+			return nil
+		},
+	}
+	server := NewAuthorServiceServer(mockStorage)
+	resp, err := server.CreateAuthor(context.Background(), &pb.CreateAuthorRequest{
+		Name:        " Aman Pandae ",
+		Email:       "aman@example.com",
+		ProfileName: "aman",
+	})
+	if err != nil || !resp.Success || resp.GetAuthor().GetAuthorId() == "" {
+		t.Errorf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestCreateAuthor_InvalidRequest(t *testing.T) {
+	server := NewAuthorServiceServer(&mockAuthorStorage{})
+	tests := []struct {
+		req  *pb.CreateAuthorRequest
+		want error
+	}{
+		{&pb.CreateAuthorRequest{ProfileName: "aman"}, models.ErrEmptyAuthorName},
+		{&pb.CreateAuthorRequest{Name: "Aman", ProfileName: "  "}, models.ErrEmptyProfileName},
+		{&pb.CreateAuthorRequest{Name: "Aman", ProfileName: "aman", Email: "not an email"}, models.ErrInvalidEmail},
+		{&pb.CreateAuthorRequest{Name: "Aman", ProfileName: "aman", Email: "Aman <aman@example.com>"}, models.ErrInvalidEmail},
+	}
+	for _, tt := range tests {
+		resp, err := server.CreateAuthor(context.Background(), tt.req)
+		if !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("CreateAuthor(%+v): expected %v, got: %v", tt.req, tt.want, err)
+		}
+	}
+}
+
+func TestCreateAuthor_Duplicate(t *testing.T) {
+	mockStorage := &mockAuthorStorage{
+		CreateAuthorFunc: func(ctx context.Context, author *models.Author) error {
+			return models.ErrDuplicateAuthor
+		},
+	}
+	server := NewAuthorServiceServer(mockStorage)
+	resp, err := server.CreateAuthor(context.Background(), &pb.CreateAuthorRequest{Name: "aman pandae", ProfileName: "AMAN"})
+	if !errors.Is(err, models.ErrDuplicateAuthor) || resp.Success {
+		t.Errorf("expected ErrDuplicateAuthor, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestGetAuthor_NotFound(t *testing.T) {
+	mockStorage := &mockAuthorStorage{
+		GetAuthorFunc: func(ctx context.Context, authorID string) (*models.Author, error) {
+			return nil, models.ErrAuthorNotFound
+		},
+	}
+	server := NewAuthorServiceServer(mockStorage)
+	resp, err := server.GetAuthor(context.Background(), &pb.GetAuthorRequest{AuthorId: "missing"})
+	if !errors.Is(err, models.ErrAuthorNotFound) || resp.Success {
+		t.Errorf("expected ErrAuthorNotFound, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestUpdateAuthor_Success(t *testing.T) {
+	mockStorage := &mockAuthorStorage{
+		UpdateAuthorFunc: func(ctx context.Context, req *models.UpdateAuthorRequest) (*models.Author, error) {
+			if req.AuthorId != "a1" || req.Name != "New Name" || req.Email != "" {
+				t.Errorf("unexpected update request: %+v", req)
+			}
+			return &models.Author{AuthorId: req.AuthorId, Name: req.Name, ProfileName: "aman"}, nil
+		},
+	}
+	server := NewAuthorServiceServer(mockStorage)
+	resp, err := server.UpdateAuthor(context.Background(), &pb.UpdateAuthorRequest{AuthorId: "a1", Name: "New Name"})
+	if err != nil || !resp.Success || resp.GetAuthor().GetName() != "New Name" {
+		t.Errorf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestUpdateAuthor_EmptyUpdate(t *testing.T) {
+	server := NewAuthorServiceServer(&mockAuthorStorage{})
+	resp, err := server.UpdateAuthor(context.Background(), &pb.UpdateAuthorRequest{AuthorId: "a1", Name: " "})
+	if !errors.Is(err, models.ErrEmptyAuthorUpdate) || resp.Success {
+		t.Errorf("expected ErrEmptyAuthorUpdate, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestDeleteAuthor_HasPosts(t *testing.T) {
+	mockStorage := &mockAuthorStorage{
+		DeleteAuthorFunc: func(ctx context.Context, authorID string) error {
+			return models.ErrAuthorHasPosts
+		},
+	}
+	server := NewAuthorServiceServer(mockStorage)
+	resp, err := server.DeleteAuthor(context.Background(), &pb.DeleteAuthorRequest{AuthorId: "a1"})
+	if !errors.Is(err, models.ErrAuthorHasPosts) || resp.Success {
+		t.Errorf("expected ErrAuthorHasPosts, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestListAuthors_Success(t *testing.T) {
+	mockStorage := &mockAuthorStorage{
+		ListAuthorsFunc: func(ctx context.Context, req *models.ListAuthorsRequest) ([]*models.Author, string, error) {
+			if req.PageSize != 2 || req.PageToken != "token" {
+				t.Errorf("unexpected list request: %+v", req)
+			}
+			return []*models.Author{{AuthorId: "a1", Name: "Alice"}, {AuthorId: "a2", Name: "Bob"}}, "next", nil
+		},
+	}
+	server := NewAuthorServiceServer(mockStorage)
+	resp, err := server.ListAuthors(context.Background(), &pb.ListAuthorsRequest{PageSize: 2, PageToken: "token"})
+	if err != nil || !resp.Success || len(resp.Authors) != 2 || resp.NextPageToken != "next" {
+		t.Errorf("expected two authors and a next page token, got error: %v, resp: %+v", err, resp)
+	}
+}
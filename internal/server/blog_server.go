@@ -2,33 +2,88 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	diff "github.com/pandae7/go-blogger/internal/diff"
 	models "github.com/pandae7/go-blogger/internal/models"
+	query "github.com/pandae7/go-blogger/internal/query"
+	search "github.com/pandae7/go-blogger/internal/search"
 	storage "github.com/pandae7/go-blogger/internal/storage"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
 	pb "github.com/pandae7/go-blogger/proto/blog"
 	log "github.com/sirupsen/logrus"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// DefaultRequestRetention is how long the request_id of a create is
+// remembered, and so how long the create can safely be retried.
+const DefaultRequestRetention = 24 * time.Hour
+
+// maxRequestIDLength caps the length of the request_id of a create.
+const maxRequestIDLength = 128
+
 type BlogServiceServer struct {
 	pb.UnimplementedBlogServiceServer
-	storage storage.BlogStorage
+	storage          storage.BlogStorage
+	tagPolicy        tagpolicy.Policy
+	requestRetention time.Duration
+	// watches is canceled by StopWatches to end every WatchBlogPosts stream
+	watches     context.Context
+	stopWatches context.CancelFunc
 }
 
 func NewBlogServiceServer(storage storage.BlogStorage) *BlogServiceServer {
+	watches, stopWatches := context.WithCancel(context.Background())
 	return &BlogServiceServer{
-		storage: storage,
+		storage:          storage,
+		tagPolicy:        tagpolicy.Default(),
+		requestRetention: DefaultRequestRetention,
+		watches:          watches,
+		stopWatches:      stopWatches,
 	}
 }
 
+// SetTagPolicy replaces the policy used to normalize the tags of created and
+// updated posts, tagpolicy.Default() unless set. It must be called before the
+// server starts serving.
+func (s *BlogServiceServer) SetTagPolicy(policy tagpolicy.Policy) {
+	s.tagPolicy = policy
+}
+
+// SetRequestRetention sets how long the request_id of a create is remembered,
+// DefaultRequestRetention unless set. It must be called before the server
+// starts serving.
+func (s *BlogServiceServer) SetRequestRetention(retention time.Duration) {
+	s.requestRetention = retention
+}
+
+// StopWatches ends every WatchBlogPosts stream, now and from then on, with
+// models.ErrShuttingDown. Watches never end on their own, so it must be called
+// before a graceful stop of the gRPC server.
+func (s *BlogServiceServer) StopWatches() {
+	s.stopWatches()
+}
+
 func (s *BlogServiceServer) CreateBlogPost(ctx context.Context, req *pb.CreateBlogPostRequest) (*pb.CreateBlogPostResponse, error) {
 	log.Infof("Creating new post with title: %s", req.GetTitle())
 
-	if err := s.validateCreatePostRequest(req); err != nil {
+	if len(req.GetRequestId()) > maxRequestIDLength {
+		return &pb.CreateBlogPostResponse{
+			Success: false,
+			Message: models.ErrInvalidRequestID.Error(),
+		}, models.ErrInvalidRequestID
+	}
+	post, err := s.newPost(req)
+	if err != nil {
 		log.Errorf("Invalid request: %v", err)
 		return &pb.CreateBlogPostResponse{
 			Success: false,
@@ -36,6 +91,160 @@ func (s *BlogServiceServer) CreateBlogPost(ctx context.Context, req *pb.CreateBl
 		}, err
 	}
 
+	// A retried request gets the post it created the first time, whose ID
+	// differs from the one just generated
+	created := true
+	if req.GetRequestId() == "" {
+		err = s.storage.CreatePost(ctx, post)
+	} else {
+		post, created, err = s.createPostOnce(ctx, post, req)
+	}
+	if err != nil {
+		log.Errorf("Failed to create post: %v", err)
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	if !created {
+		log.Infof("Post with ID %s already created for request %s", post.PostId, req.GetRequestId())
+		return &pb.CreateBlogPostResponse{
+			Post:     s.modelToProtobuf(post),
+			Success:  true,
+			Message:  "Post already created by an earlier request",
+			Replayed: true,
+		}, nil
+	}
+	log.Infof("Post created successfully with ID: %s", post.PostId)
+	return &pb.CreateBlogPostResponse{
+		Post:    s.modelToProtobuf(post),
+		Success: true,
+		Message: "Post created successfully",
+	}, nil
+}
+
+// createPostOnce creates the post of a request carrying a request_id, unless
+// an earlier request with the same request_id already did.
+func (s *BlogServiceServer) createPostOnce(ctx context.Context, post *models.BlogPost, req *pb.CreateBlogPostRequest) (*models.BlogPost, bool, error) {
+	// The fingerprint covers the payload but not the request ID, so that only
+	// an identical request counts as a retry
+	payload := proto.Clone(req).(*pb.CreateBlogPostRequest)
+	payload.RequestId = ""
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+	if err != nil {
+		return nil, false, err
+	}
+	fingerprint := sha256.Sum256(raw)
+
+	return s.storage.CreatePostOnce(ctx, post, &models.IdempotencyKey{
+		RequestId:   req.GetRequestId(),
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+		ExpiresAt:   time.Now().Add(s.requestRetention),
+	})
+}
+
+// maxAtomicImport caps the number of posts of an all-or-nothing import, which
+// are held in memory until the stream ends.
+const maxAtomicImport = 10000
+
+func (s *BlogServiceServer) ImportBlogPosts(stream pb.BlogService_ImportBlogPostsServer) error {
+	ctx := stream.Context()
+	resp := &pb.ImportBlogPostsResponse{}
+	fail := func(index int, err error) {
+		resp.Failed++
+		resp.Errors = append(resp.Errors, &pb.ImportError{
+			Index:   int32(index),
+			Reason:  errorReason(err),
+			Message: err.Error(),
+		})
+	}
+
+	// Best-effort imports create each post as it arrives. All-or-nothing
+	// imports collect the posts and create them at once after the last one,
+	// if all of them are valid, so their positions in the batch and in the
+	// stream are the same.
+	var (
+		mode  pb.ImportMode
+		batch []*models.BlogPost
+	)
+	for index := 0; ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if index == 0 {
+			mode = req.GetMode()
+			log.Infof("Importing posts in mode %s", mode)
+		}
+		resp.Received++
+		resp.PostIds = append(resp.PostIds, "")
+
+		post, err := s.newPost(req.GetPost())
+		if err != nil {
+			fail(index, err)
+			continue
+		}
+		if mode == pb.ImportMode_IMPORT_MODE_ALL_OR_NOTHING {
+			if len(batch) == maxAtomicImport {
+				return models.ErrImportTooLarge
+			}
+			batch = append(batch, post)
+			continue
+		}
+		if err := s.storage.CreatePost(ctx, post); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fail(index, err)
+			continue
+		}
+		resp.PostIds[index] = post.PostId
+		resp.Created++
+	}
+
+	// The storage stops at the first post it cannot create, so only that one
+	// is reported next to the invalid posts
+	if mode == pb.ImportMode_IMPORT_MODE_ALL_OR_NOTHING && resp.Failed == 0 {
+		var itemErr *models.ItemError
+		err := s.storage.CreatePosts(ctx, batch)
+		switch {
+		case errors.As(err, &itemErr):
+			fail(itemErr.Index, itemErr.Err)
+		case err != nil:
+			log.Errorf("Failed to import posts: %v", err)
+			return err
+		default:
+			for i, post := range batch {
+				resp.PostIds[i] = post.PostId
+			}
+			resp.Created = int32(len(batch))
+		}
+	}
+
+	resp.Success = resp.Failed == 0
+	switch {
+	case resp.Success:
+		resp.Message = "Posts imported successfully"
+	case mode == pb.ImportMode_IMPORT_MODE_ALL_OR_NOTHING:
+		resp.Message = fmt.Sprintf("No posts imported: %d of %d posts failed", resp.Failed, resp.Received)
+	default:
+		resp.Message = fmt.Sprintf("%d of %d posts failed to import", resp.Failed, resp.Received)
+	}
+	log.Infof("Imported %d of %d posts", resp.Created, resp.Received)
+	return stream.SendAndClose(resp)
+}
+
+// newPost validates a create request and returns the post to store.
+func (s *BlogServiceServer) newPost(req *pb.CreateBlogPostRequest) (*models.BlogPost, error) {
+	if err := s.validateCreatePostRequest(req); err != nil {
+		return nil, err
+	}
+	tags, err := s.tagPolicy.Normalize(req.GetTags())
+	if err != nil {
+		return nil, err
+	}
+
 	// check PublishedDate
 	publicationDate := req.GetPublicationDate()
 	if publicationDate == nil {
@@ -43,32 +252,28 @@ func (s *BlogServiceServer) CreateBlogPost(ctx context.Context, req *pb.CreateBl
 		publicationDate = timestamppb.Now()
 	}
 
-	post := &models.BlogPost{
+	return &models.BlogPost{
 		PostId:          uuid.New().String(),
 		Title:           req.GetTitle(),
 		Content:         req.GetContent(),
-		Author:          req.GetAuthor(),
+		AuthorId:        req.GetAuthorId(),
 		PublicationDate: publicationDate.AsTime(),
-		Tags:            req.GetTags(),
+		Tags:            tags,
 		UpdatedAt:       time.Now(),
-	}
-
-	if err := s.storage.CreatePost(ctx, post); err != nil {
-		log.Errorf("Failed to create post: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to create post: %v", err)
-	}
-
-	log.Infof("Post created successfully with ID: %s", post.PostId)
-	return &pb.CreateBlogPostResponse{
-		Post:    s.modelToProtobuf(post),
-		Success: true,
-		Message: "Post created successfully",
+		Status:          postStatuses[req.GetStatus()],
 	}, nil
 }
 
 func (s *BlogServiceServer) GetBlogPost(ctx context.Context, req *pb.GetBlogPostRequest) (*pb.GetBlogPostResponse, error) {
 	log.Infof("Retrieving post with ID: %s", req.GetPostId())
 
+	if req.GetPostId() == "" {
+		return &pb.GetBlogPostResponse{
+			Success: false,
+			Message: models.ErrInvalidPostID.Error(),
+		}, models.ErrInvalidPostID
+	}
+
 	post, err := s.storage.GetPost(ctx, req.GetPostId())
 	if err != nil {
 		return &pb.GetBlogPostResponse{
@@ -84,6 +289,49 @@ func (s *BlogServiceServer) GetBlogPost(ctx context.Context, req *pb.GetBlogPost
 	}, nil
 }
 
+// maxBatchSize caps the number of post IDs of a batch request.
+const maxBatchSize = 100
+
+func (s *BlogServiceServer) BatchGetBlogPosts(ctx context.Context, req *pb.BatchGetBlogPostsRequest) (*pb.BatchGetBlogPostsResponse, error) {
+	log.Infof("Retrieving %d posts", len(req.GetPostIds()))
+
+	if err := s.validateBatch(req.GetPostIds()); err != nil {
+		return &pb.BatchGetBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	posts, err := s.storage.GetPosts(ctx, req.GetPostIds())
+	if err != nil {
+		return &pb.BatchGetBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	resp := &pb.BatchGetBlogPostsResponse{}
+	for i, post := range posts {
+		result := &pb.BatchGetBlogPostsResult{PostId: req.GetPostIds()[i]}
+		if post == nil {
+			result.Reason = errorReason(models.ErrPostNotFound)
+			result.Message = models.ErrPostNotFound.Error()
+			resp.Missing++
+		} else {
+			result.Post = s.modelToProtobuf(post)
+			resp.Found++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	resp.Success = resp.Missing == 0
+	if resp.Success {
+		resp.Message = "Posts retrieved successfully"
+	} else {
+		resp.Message = fmt.Sprintf("%d of %d posts not found", resp.Missing, len(posts))
+	}
+	return resp, nil
+}
+
 func (s *BlogServiceServer) UpdateBlogPost(ctx context.Context, req *pb.UpdateBlogPostRequest) (*pb.UpdateBlogPostResponse, error) {
 	log.Infof("Updating post with ID: %s", req.GetPostId())
 
@@ -93,13 +341,23 @@ func (s *BlogServiceServer) UpdateBlogPost(ctx context.Context, req *pb.UpdateBl
 			Message: err.Error(),
 		}, err
 	}
+	tags, err := s.tagPolicy.Normalize(req.GetTags())
+	if err != nil {
+		return &pb.UpdateBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
 
 	updateReq := &models.UpdateBlogPostRequest{
-		PostId:    req.GetPostId(),
-		Title:     req.GetTitle(),
-		Content:   req.GetContent(),
-		Tags:      req.GetTags(),
-		UpdatedAt: time.Now(),
+		PostId:          req.GetPostId(),
+		Title:           req.GetTitle(),
+		Content:         req.GetContent(),
+		Tags:            tags,
+		UpdatedAt:       time.Now(),
+		ExpectedVersion: req.GetExpectedVersion(),
+		UpdateMask:      req.GetUpdateMask().GetPaths(),
+		Editor:          req.GetEditor(),
 	}
 
 	updatedPost, err := s.storage.UpdatePost(ctx, updateReq)
@@ -120,51 +378,899 @@ func (s *BlogServiceServer) UpdateBlogPost(ctx context.Context, req *pb.UpdateBl
 func (s *BlogServiceServer) DeleteBlogPost(ctx context.Context, req *pb.DeleteBlogPostRequest) (*pb.DeleteBlogPostResponse, error) {
 	log.Infof("Deleting post with ID: %s", req.GetPostId())
 
-	if err := s.storage.DeletePost(ctx, req.GetPostId()); err != nil {
+	if err := s.validateDeletePostRequest(req); err != nil {
+		return &pb.DeleteBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	deleteReq := &models.DeleteBlogPostRequest{
+		PostId:          req.GetPostId(),
+		ExpectedVersion: req.GetExpectedVersion(),
+		Permanent:       req.GetPermanent(),
+	}
+
+	// Posts go to the trash unless the caller asked to skip it
+	deletePost := s.storage.TrashPost
+	if deleteReq.Permanent {
+		deletePost = s.storage.DeletePost
+	}
+	if err := deletePost(ctx, deleteReq); err != nil {
 		return &pb.DeleteBlogPostResponse{
 			Success: false,
 			Message: "Failed to delete post: " + err.Error(),
 		}, err
 	}
 
-	log.Infof("Post deleted successfully with ID: %s", req.GetPostId())
+	if deleteReq.Permanent {
+		log.Infof("Post deleted permanently with ID: %s", req.GetPostId())
+		return &pb.DeleteBlogPostResponse{
+			Success: true,
+			Message: "Post deleted permanently",
+		}, nil
+	}
+	log.Infof("Post moved to the trash with ID: %s", req.GetPostId())
 	return &pb.DeleteBlogPostResponse{
 		Success: true,
-		Message: "Post deleted successfully",
+		Message: "Post moved to the trash",
+	}, nil
+}
+
+func (s *BlogServiceServer) BatchDeleteBlogPosts(ctx context.Context, req *pb.BatchDeleteBlogPostsRequest) (*pb.BatchDeleteBlogPostsResponse, error) {
+	log.Infof("Deleting %d posts", len(req.GetPostIds()))
+
+	if err := s.validateBatch(req.GetPostIds()); err != nil {
+		return &pb.BatchDeleteBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	errs, err := s.storage.DeletePosts(ctx, &models.BatchDeleteRequest{
+		PostIds:   req.GetPostIds(),
+		Permanent: req.GetPermanent(),
+	})
+	if err != nil {
+		return &pb.BatchDeleteBlogPostsResponse{
+			Success: false,
+			Message: "Failed to delete posts: " + err.Error(),
+		}, err
+	}
+
+	resp := &pb.BatchDeleteBlogPostsResponse{}
+	for i, err := range errs {
+		result := &pb.BatchDeleteBlogPostsResult{PostId: req.GetPostIds()[i]}
+		if err != nil {
+			result.Reason = errorReason(err)
+			result.Message = err.Error()
+			resp.Missing++
+		} else {
+			result.Deleted = true
+			resp.Deleted++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	resp.Success = resp.Missing == 0
+	switch {
+	case !resp.Success:
+		resp.Message = fmt.Sprintf("%d of %d posts not found", resp.Missing, len(errs))
+	case req.GetPermanent():
+		resp.Message = "Posts deleted permanently"
+	default:
+		resp.Message = "Posts moved to the trash"
+	}
+	log.Infof("Deleted %d of %d posts", resp.Deleted, len(errs))
+	return resp, nil
+}
+
+func (s *BlogServiceServer) RestoreBlogPost(ctx context.Context, req *pb.RestoreBlogPostRequest) (*pb.RestoreBlogPostResponse, error) {
+	log.Infof("Restoring post with ID: %s", req.GetPostId())
+
+	if req.GetPostId() == "" {
+		return &pb.RestoreBlogPostResponse{
+			Success: false,
+			Message: models.ErrInvalidPostID.Error(),
+		}, models.ErrInvalidPostID
+	}
+
+	post, err := s.storage.RestorePost(ctx, req.GetPostId())
+	if err != nil {
+		return &pb.RestoreBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Post restored from the trash with ID: %s", post.PostId)
+	return &pb.RestoreBlogPostResponse{
+		Post:    s.modelToProtobuf(post),
+		Success: true,
+		Message: "Post restored successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) PurgeBlogPost(ctx context.Context, req *pb.PurgeBlogPostRequest) (*pb.PurgeBlogPostResponse, error) {
+	log.Infof("Purging post with ID: %s", req.GetPostId())
+
+	if req.GetPostId() == "" {
+		return &pb.PurgeBlogPostResponse{
+			Success: false,
+			Message: models.ErrInvalidPostID.Error(),
+		}, models.ErrInvalidPostID
+	}
+
+	if err := s.storage.PurgePost(ctx, req.GetPostId()); err != nil {
+		return &pb.PurgeBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Post purged successfully with ID: %s", req.GetPostId())
+	return &pb.PurgeBlogPostResponse{
+		Success: true,
+		Message: "Post purged successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) PublishBlogPost(ctx context.Context, req *pb.PublishBlogPostRequest) (*pb.PublishBlogPostResponse, error) {
+	log.Infof("Publishing post with ID: %s", req.GetPostId())
+
+	statusReq := &models.SetPostStatusRequest{
+		PostId:          req.GetPostId(),
+		Status:          models.StatusPublished,
+		ExpectedVersion: req.GetExpectedVersion(),
+		Editor:          req.GetEditor(),
+	}
+	if req.PublishAt != nil {
+		statusReq.PublishAt = req.GetPublishAt().AsTime()
+	}
+	post, err := s.setPostStatus(ctx, statusReq)
+	if err != nil {
+		return &pb.PublishBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Post %s is now %s", post.PostId, post.Status)
+	return &pb.PublishBlogPostResponse{
+		Post:    s.modelToProtobuf(post),
+		Success: true,
+		Message: "Post published successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) UnpublishBlogPost(ctx context.Context, req *pb.UnpublishBlogPostRequest) (*pb.UnpublishBlogPostResponse, error) {
+	log.Infof("Unpublishing post with ID: %s", req.GetPostId())
+
+	post, err := s.setPostStatus(ctx, &models.SetPostStatusRequest{
+		PostId:          req.GetPostId(),
+		Status:          models.StatusDraft,
+		ExpectedVersion: req.GetExpectedVersion(),
+		Editor:          req.GetEditor(),
+	})
+	if err != nil {
+		return &pb.UnpublishBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Post %s is now %s", post.PostId, post.Status)
+	return &pb.UnpublishBlogPostResponse{
+		Post:    s.modelToProtobuf(post),
+		Success: true,
+		Message: "Post unpublished successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) ArchiveBlogPost(ctx context.Context, req *pb.ArchiveBlogPostRequest) (*pb.ArchiveBlogPostResponse, error) {
+	log.Infof("Archiving post with ID: %s", req.GetPostId())
+
+	post, err := s.setPostStatus(ctx, &models.SetPostStatusRequest{
+		PostId:          req.GetPostId(),
+		Status:          models.StatusArchived,
+		ExpectedVersion: req.GetExpectedVersion(),
+		Editor:          req.GetEditor(),
+	})
+	if err != nil {
+		return &pb.ArchiveBlogPostResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Post %s is now %s", post.PostId, post.Status)
+	return &pb.ArchiveBlogPostResponse{
+		Post:    s.modelToProtobuf(post),
+		Success: true,
+		Message: "Post archived successfully",
+	}, nil
+}
+
+// setPostStatus validates and applies a lifecycle transition for the
+// publish, unpublish and archive handlers.
+func (s *BlogServiceServer) setPostStatus(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error) {
+	if req.PostId == "" {
+		return nil, models.ErrInvalidPostID
+	}
+	if req.ExpectedVersion < 0 {
+		return nil, models.ErrInvalidVersion
+	}
+	return s.storage.SetPostStatus(ctx, req)
+}
+
+func (s *BlogServiceServer) ListBlogPosts(ctx context.Context, req *pb.ListBlogPostsRequest) (*pb.ListBlogPostsResponse, error) {
+	log.Infof("Listing posts with page size: %d", req.GetPageSize())
+
+	if err := s.validateListPostsRequest(req); err != nil {
+		return &pb.ListBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	listReq := &models.ListBlogPostsRequest{
+		PageSize:    int(req.GetPageSize()),
+		PageToken:   req.GetPageToken(),
+		Filter:      req.GetFilter(),
+		OrderBy:     req.GetOrderBy(),
+		ShowDeleted: req.GetShowDeleted(),
+	}
+
+	posts, nextPageToken, err := s.storage.ListPosts(ctx, listReq)
+	if err != nil {
+		return &pb.ListBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbPosts := make([]*pb.BlogPost, 0, len(posts))
+	for _, post := range posts {
+		pbPosts = append(pbPosts, s.modelToProtobuf(post))
+	}
+
+	return &pb.ListBlogPostsResponse{
+		Posts:         pbPosts,
+		NextPageToken: nextPageToken,
+		Success:       true,
+		Message:       "Posts listed successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) ListBlogPostsByAuthor(ctx context.Context, req *pb.ListBlogPostsByAuthorRequest) (*pb.ListBlogPostsByAuthorResponse, error) {
+	log.Infof("Listing posts of author with ID: %s", req.GetAuthorId())
+
+	if err := s.validateListPostsByAuthorRequest(req); err != nil {
+		return &pb.ListBlogPostsByAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	posts, nextPageToken, err := s.storage.ListPostsByAuthor(ctx, &models.ListPostsByAuthorRequest{
+		AuthorId:  req.GetAuthorId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return &pb.ListBlogPostsByAuthorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbPosts := make([]*pb.BlogPost, 0, len(posts))
+	for _, post := range posts {
+		pbPosts = append(pbPosts, s.modelToProtobuf(post))
+	}
+
+	return &pb.ListBlogPostsByAuthorResponse{
+		Posts:         pbPosts,
+		NextPageToken: nextPageToken,
+		Success:       true,
+		Message:       "Posts listed successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) ListBlogPostsByTag(ctx context.Context, req *pb.ListBlogPostsByTagRequest) (*pb.ListBlogPostsByTagResponse, error) {
+	log.Infof("Listing posts with tag: %s", req.GetTag())
+
+	if err := s.validateListPostsByTagRequest(req); err != nil {
+		return &pb.ListBlogPostsByTagResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	// Look the tag up the way it was stored. A tag the policy rejects cannot
+	// be on any post, so it is looked up as given and simply matches nothing.
+	tag := req.GetTag()
+	if normalized, err := s.tagPolicy.Normalize([]string{tag}); err == nil {
+		tag = normalized[0]
+	}
+
+	posts, nextPageToken, err := s.storage.ListPostsByTag(ctx, &models.ListPostsByTagRequest{
+		Tag:       tag,
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return &pb.ListBlogPostsByTagResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbPosts := make([]*pb.BlogPost, 0, len(posts))
+	for _, post := range posts {
+		pbPosts = append(pbPosts, s.modelToProtobuf(post))
+	}
+
+	return &pb.ListBlogPostsByTagResponse{
+		Posts:         pbPosts,
+		NextPageToken: nextPageToken,
+		Success:       true,
+		Message:       "Posts listed successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) SearchBlogPosts(ctx context.Context, req *pb.SearchBlogPostsRequest) (*pb.SearchBlogPostsResponse, error) {
+	log.Infof("Searching posts for: %s", req.GetQuery())
+
+	if err := s.validateSearchRequest(req); err != nil {
+		return &pb.SearchBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	results, nextPageToken, err := s.storage.SearchPosts(ctx, &models.SearchPostsRequest{
+		Query:     req.GetQuery(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return &pb.SearchBlogPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	parsed := search.ParseQuery(req.GetQuery())
+	pbResults := make([]*pb.SearchResult, 0, len(results))
+	for _, result := range results {
+		pbResults = append(pbResults, &pb.SearchResult{
+			Post:    s.modelToProtobuf(result.Post),
+			Score:   result.Score,
+			Title:   spansToProtobuf(parsed.Highlight(result.Post.Title)),
+			Snippet: spansToProtobuf(parsed.Snippet(result.Post.Content, snippetWidth)),
+		})
+	}
+
+	return &pb.SearchBlogPostsResponse{
+		Results:       pbResults,
+		NextPageToken: nextPageToken,
+		Success:       true,
+		Message:       "Search completed successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) GetRelatedPosts(ctx context.Context, req *pb.GetRelatedPostsRequest) (*pb.GetRelatedPostsResponse, error) {
+	log.Infof("Finding posts related to post with ID: %s", req.GetPostId())
+
+	if err := s.validateGetRelatedPostsRequest(req); err != nil {
+		return &pb.GetRelatedPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	related, err := s.storage.GetRelatedPosts(ctx, &models.GetRelatedPostsRequest{
+		PostId: req.GetPostId(),
+		Limit:  int(req.GetLimit()),
+	})
+	if err != nil {
+		return &pb.GetRelatedPostsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbPosts := make([]*pb.RelatedPost, 0, len(related))
+	for _, r := range related {
+		pbPosts = append(pbPosts, &pb.RelatedPost{
+			Post:  s.modelToProtobuf(r.Post),
+			Score: r.Score,
+		})
+	}
+
+	return &pb.GetRelatedPostsResponse{
+		Posts:   pbPosts,
+		Success: true,
+		Message: "Related posts found successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) ExportBlogPosts(req *pb.ExportBlogPostsRequest, stream pb.BlogService_ExportBlogPostsServer) error {
+	log.Infof("Exporting posts with filter: %q", req.GetFilter())
+
+	exported := 0
+	err := s.storage.ExportPosts(stream.Context(), &models.ExportPostsRequest{
+		Filter:      req.GetFilter(),
+		ShowDeleted: req.GetShowDeleted(),
+	}, func(post *models.BlogPost, sequence uint64) error {
+		exported++
+		return stream.Send(&pb.ExportBlogPostsResponse{
+			Post:     s.modelToProtobuf(post),
+			Sequence: sequence,
+		})
+	})
+	if err != nil {
+		log.Errorf("Export failed after %d posts: %v", exported, err)
+		return err
+	}
+	log.Infof("Exported %d posts", exported)
+	return nil
+}
+
+func (s *BlogServiceServer) WatchBlogPosts(req *pb.WatchBlogPostsRequest, stream pb.BlogService_WatchBlogPostsServer) error {
+	log.Infof("Watching posts with author ID %q and tag %q since sequence %d", req.GetAuthorId(), req.GetTag(), req.GetSinceSequence())
+
+	// Match the tag the way it was stored, as ListBlogPostsByTag does
+	tag := req.GetTag()
+	if tag != "" {
+		if normalized, err := s.tagPolicy.Normalize([]string{tag}); err == nil {
+			tag = normalized[0]
+		}
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	defer context.AfterFunc(s.watches, cancel)()
+
+	err := s.storage.WatchPosts(ctx, &models.WatchPostsRequest{
+		AuthorId:      req.GetAuthorId(),
+		Tag:           tag,
+		SinceSequence: req.GetSinceSequence(),
+	}, func(event *models.PostEvent) error {
+		return stream.Send(s.eventToProtobuf(event))
+	})
+	if s.watches.Err() != nil {
+		return models.ErrShuttingDown
+	}
+	if err != nil && stream.Context().Err() == nil {
+		log.Errorf("Watch failed: %v", err)
+	}
+	return err
+}
+
+func (s *BlogServiceServer) ListPostRevisions(ctx context.Context, req *pb.ListPostRevisionsRequest) (*pb.ListPostRevisionsResponse, error) {
+	log.Infof("Listing revisions of post with ID: %s", req.GetPostId())
+
+	if req.GetPostId() == "" {
+		return &pb.ListPostRevisionsResponse{
+			Success: false,
+			Message: models.ErrInvalidPostID.Error(),
+		}, models.ErrInvalidPostID
+	}
+
+	revisions, err := s.storage.ListRevisions(ctx, req.GetPostId())
+	if err != nil {
+		return &pb.ListPostRevisionsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbRevisions := make([]*pb.PostRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		pbRevisions = append(pbRevisions, s.revisionToProtobuf(revision))
+	}
+
+	return &pb.ListPostRevisionsResponse{
+		Revisions: pbRevisions,
+		Success:   true,
+		Message:   "Revisions listed successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) GetPostRevision(ctx context.Context, req *pb.GetPostRevisionRequest) (*pb.GetPostRevisionResponse, error) {
+	log.Infof("Retrieving revision %d of post with ID: %s", req.GetRevision(), req.GetPostId())
+
+	if err := s.validateRevisionRequest(req.GetPostId(), req.GetRevision()); err != nil {
+		return &pb.GetPostRevisionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	revision, err := s.storage.GetRevision(ctx, req.GetPostId(), req.GetRevision())
+	if err != nil {
+		return &pb.GetPostRevisionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	return &pb.GetPostRevisionResponse{
+		Revision: s.revisionToProtobuf(revision),
+		Success:  true,
+		Message:  "Revision retrieved successfully",
 	}, nil
 }
 
+// RestorePostRevision writes the title, content and tags of an earlier
+// revision as a new update, so the restore itself shows up in the history.
+func (s *BlogServiceServer) RestorePostRevision(ctx context.Context, req *pb.RestorePostRevisionRequest) (*pb.RestorePostRevisionResponse, error) {
+	log.Infof("Restoring revision %d of post with ID: %s", req.GetRevision(), req.GetPostId())
+
+	err := s.validateRevisionRequest(req.GetPostId(), req.GetRevision())
+	if err == nil && req.GetExpectedVersion() < 0 {
+		err = models.ErrInvalidVersion
+	}
+	if err != nil {
+		return &pb.RestorePostRevisionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	revision, err := s.storage.GetRevision(ctx, req.GetPostId(), req.GetRevision())
+	if err != nil {
+		return &pb.RestorePostRevisionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	updateReq := &models.UpdateBlogPostRequest{
+		PostId:          req.GetPostId(),
+		Title:           revision.Title,
+		Content:         revision.Content,
+		Tags:            revision.Tags,
+		UpdatedAt:       time.Now(),
+		ExpectedVersion: req.GetExpectedVersion(),
+		UpdateMask:      []string{"title", "content", "tags"},
+		Editor:          req.GetEditor(),
+	}
+
+	restoredPost, err := s.storage.UpdatePost(ctx, updateReq)
+	if err != nil {
+		return &pb.RestorePostRevisionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Post %s restored to revision %d as version %d", restoredPost.PostId, revision.Revision, restoredPost.Version)
+	return &pb.RestorePostRevisionResponse{
+		Post:    s.modelToProtobuf(restoredPost),
+		Success: true,
+		Message: "Revision restored successfully",
+	}, nil
+}
+
+func (s *BlogServiceServer) DiffPostRevisions(ctx context.Context, req *pb.DiffPostRevisionsRequest) (*pb.DiffPostRevisionsResponse, error) {
+	log.Infof("Diffing revisions %d and %d of post with ID: %s", req.GetFromRevision(), req.GetToRevision(), req.GetPostId())
+
+	if err := s.validateDiffRequest(req); err != nil {
+		return &pb.DiffPostRevisionsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	from, err := s.loadRevision(ctx, req.GetPostId(), req.GetFromRevision())
+	if err != nil {
+		return &pb.DiffPostRevisionsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	to, err := s.loadRevision(ctx, req.GetPostId(), req.GetToRevision())
+	if err != nil {
+		return &pb.DiffPostRevisionsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	contextLines := diff.DefaultContext
+	if req.ContextLines != nil {
+		contextLines = int(req.GetContextLines())
+	}
+	textDiff := func(field, a, b string) *pb.TextDiff {
+		if req.GetFormat() == pb.DiffFormat_DIFF_FORMAT_WORDS {
+			return wordDiffToProtobuf(diff.Words(a, b))
+		}
+		return &pb.TextDiff{
+			Changed: a != b,
+			Unified: diff.Unified(a, b,
+				fmt.Sprintf("%s@%d", field, from.Revision),
+				fmt.Sprintf("%s@%d", field, to.Revision), contextLines),
+		}
+	}
+
+	return &pb.DiffPostRevisionsResponse{
+		FromRevision: from.Revision,
+		ToRevision:   to.Revision,
+		Title:        textDiff("title", from.Title, to.Title),
+		Content:      textDiff("content", from.Content, to.Content),
+		Tags:         diffTags(from.Tags, to.Tags),
+		Success:      true,
+		Message:      "Revisions compared successfully",
+	}, nil
+}
+
+// loadRevision retrieves a revision of a post, where revision 0 stands for
+// the current state.
+func (s *BlogServiceServer) loadRevision(ctx context.Context, postId string, revision int64) (*models.PostRevision, error) {
+	if revision != 0 {
+		return s.storage.GetRevision(ctx, postId, revision)
+	}
+	post, err := s.storage.GetPost(ctx, postId)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPostRevision(post, ""), nil
+}
+
 func (s *BlogServiceServer) validateCreatePostRequest(req *pb.CreateBlogPostRequest) error {
 	if req.GetTitle() == "" {
-		return status.Error(codes.InvalidArgument, "Post title cannot be empty")
+		return models.ErrEmptyTitle
 	}
 	if req.GetContent() == "" {
-		return status.Error(codes.InvalidArgument, "Post content cannot be empty")
+		return models.ErrEmptyContent
 	}
-	if req.GetAuthor() == "" {
-		return status.Error(codes.InvalidArgument, "Post author cannot be empty")
+	// Posts reference their author by ID, the free-form author name is
+	// no longer accepted
+	if req.GetAuthorId() == "" {
+		return models.ErrInvalidAuthorID
+	}
+	if _, ok := postStatuses[req.GetStatus()]; !ok {
+		return models.ErrInvalidStatus
 	}
 	return nil
 }
 
 func (s *BlogServiceServer) validateUpdatePostRequest(req *pb.UpdateBlogPostRequest) error {
 	if req.GetPostId() == "" {
-		return status.Error(codes.InvalidArgument, "Post ID cannot be empty")
+		return models.ErrInvalidPostID
+	}
+	if req.GetExpectedVersion() < 0 {
+		return models.ErrInvalidVersion
+	}
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		return storage.ValidateUpdateMask(&models.UpdateBlogPostRequest{
+			Title:      req.GetTitle(),
+			Content:    req.GetContent(),
+			UpdateMask: req.GetUpdateMask().GetPaths(),
+		})
 	}
 	if req.GetTitle() == "" && req.GetContent() == "" && len(req.GetTags()) == 0 {
-		return status.Error(codes.InvalidArgument, "At least one field (title, content, tags) must be provided for update")
+		return models.ErrEmptyUpdate
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateDeletePostRequest(req *pb.DeleteBlogPostRequest) error {
+	if req.GetPostId() == "" {
+		return models.ErrInvalidPostID
+	}
+	if req.GetExpectedVersion() < 0 {
+		return models.ErrInvalidVersion
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateBatch(postIds []string) error {
+	if len(postIds) == 0 {
+		return models.ErrEmptyBatch
+	}
+	if len(postIds) > maxBatchSize {
+		return models.ErrBatchTooLarge
+	}
+	for _, postId := range postIds {
+		if postId == "" {
+			return models.ErrInvalidPostID
+		}
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateRevisionRequest(postId string, revision int64) error {
+	if postId == "" {
+		return models.ErrInvalidPostID
+	}
+	if revision <= 0 {
+		return models.ErrInvalidRevision
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateDiffRequest(req *pb.DiffPostRevisionsRequest) error {
+	if req.GetPostId() == "" {
+		return models.ErrInvalidPostID
+	}
+	if req.GetFromRevision() < 0 || req.GetToRevision() < 0 {
+		return models.ErrInvalidRevision
+	}
+	if _, ok := pb.DiffFormat_name[int32(req.GetFormat())]; !ok {
+		return models.ErrInvalidDiffFormat
+	}
+	if req.GetContextLines() < 0 {
+		return models.ErrInvalidContext
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateListPostsRequest(req *pb.ListBlogPostsRequest) error {
+	if req.GetPageSize() < 0 {
+		return models.ErrInvalidPageSize
+	}
+	if _, err := query.Parse(req.GetFilter()); err != nil {
+		return err
+	}
+	if _, err := query.ParseOrderBy(req.GetOrderBy()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateListPostsByAuthorRequest(req *pb.ListBlogPostsByAuthorRequest) error {
+	if req.GetAuthorId() == "" {
+		return models.ErrInvalidAuthorID
+	}
+	if req.GetPageSize() < 0 {
+		return models.ErrInvalidPageSize
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateListPostsByTagRequest(req *pb.ListBlogPostsByTagRequest) error {
+	if strings.TrimSpace(req.GetTag()) == "" {
+		return models.ErrEmptyTag
+	}
+	if req.GetPageSize() < 0 {
+		return models.ErrInvalidPageSize
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateSearchRequest(req *pb.SearchBlogPostsRequest) error {
+	if search.ParseQuery(req.GetQuery()).Empty() {
+		return models.ErrEmptyQuery
+	}
+	if req.GetPageSize() < 0 {
+		return models.ErrInvalidPageSize
+	}
+	return nil
+}
+
+func (s *BlogServiceServer) validateGetRelatedPostsRequest(req *pb.GetRelatedPostsRequest) error {
+	if req.GetPostId() == "" {
+		return models.ErrInvalidPostID
+	}
+	if req.GetLimit() < 0 {
+		return models.ErrInvalidLimit
 	}
 	return nil
 }
 
 func (s *BlogServiceServer) modelToProtobuf(post *models.BlogPost) *pb.BlogPost {
+	var deletedAt *timestamppb.Timestamp
+	if post.Trashed() {
+		deletedAt = timestamppb.New(post.DeletedAt)
+	}
 	return &pb.BlogPost{
 		PostId:          post.PostId,
 		Title:           post.Title,
 		Content:         post.Content,
 		Author:          post.Author,
+		AuthorId:        post.AuthorId,
 		PublicationDate: timestamppb.New(post.PublicationDate),
 		UpdatedAt:       timestamppb.New(post.UpdatedAt),
 		Tags:            post.Tags,
+		Version:         post.Version,
+		DeletedAt:       deletedAt,
+		Status:          pbPostStatuses[post.Status],
+	}
+}
+
+func (s *BlogServiceServer) eventToProtobuf(event *models.PostEvent) *pb.PostEvent {
+	pbEvent := &pb.PostEvent{
+		Sequence: event.Sequence,
+		Type:     pbEventTypes[event.Type],
+		PostId:   event.PostId,
+		Post:     s.modelToProtobuf(event.Post),
+		Time:     timestamppb.New(event.Time),
+	}
+	if event.Previous != nil {
+		pbEvent.Previous = s.modelToProtobuf(event.Previous)
+	}
+	return pbEvent
+}
+
+var pbEventTypes = map[models.EventType]pb.EventType{
+	models.EventCreated: pb.EventType_EVENT_TYPE_CREATED,
+	models.EventUpdated: pb.EventType_EVENT_TYPE_UPDATED,
+	models.EventDeleted: pb.EventType_EVENT_TYPE_DELETED,
+}
+
+// postStatuses maps the statuses clients can ask for on create to the storage
+// model; unspecified leaves the choice to the storage.
+var postStatuses = map[pb.PostStatus]models.PostStatus{
+	pb.PostStatus_POST_STATUS_UNSPECIFIED: "",
+	pb.PostStatus_POST_STATUS_DRAFT:       models.StatusDraft,
+	pb.PostStatus_POST_STATUS_SCHEDULED:   models.StatusScheduled,
+	pb.PostStatus_POST_STATUS_PUBLISHED:   models.StatusPublished,
+	pb.PostStatus_POST_STATUS_ARCHIVED:    models.StatusArchived,
+}
+
+var pbPostStatuses = map[models.PostStatus]pb.PostStatus{
+	models.StatusDraft:     pb.PostStatus_POST_STATUS_DRAFT,
+	models.StatusScheduled: pb.PostStatus_POST_STATUS_SCHEDULED,
+	models.StatusPublished: pb.PostStatus_POST_STATUS_PUBLISHED,
+	models.StatusArchived:  pb.PostStatus_POST_STATUS_ARCHIVED,
+}
+
+func (s *BlogServiceServer) revisionToProtobuf(revision *models.PostRevision) *pb.PostRevision {
+	return &pb.PostRevision{
+		PostId:    revision.PostId,
+		Revision:  revision.Revision,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Tags:      revision.Tags,
+		Editor:    revision.Editor,
+		CreatedAt: timestamppb.New(revision.CreatedAt),
+	}
+}
+
+// snippetWidth is the approximate length, in characters, of the content
+// excerpts returned with search results.
+const snippetWidth = 160
+
+func spansToProtobuf(spans []search.Span) []*pb.HighlightSpan {
+	pbSpans := make([]*pb.HighlightSpan, 0, len(spans))
+	for _, span := range spans {
+		pbSpans = append(pbSpans, &pb.HighlightSpan{Text: span.Text, Highlighted: span.Match})
+	}
+	return pbSpans
+}
+
+func wordDiffToProtobuf(spans []diff.Span) *pb.TextDiff {
+	ops := map[diff.Op]pb.DiffOp{
+		diff.Equal:  pb.DiffOp_DIFF_OP_EQUAL,
+		diff.Insert: pb.DiffOp_DIFF_OP_INSERT,
+		diff.Delete: pb.DiffOp_DIFF_OP_DELETE,
+	}
+	pbSpans := make([]*pb.DiffSpan, 0, len(spans))
+	for _, span := range spans {
+		pbSpans = append(pbSpans, &pb.DiffSpan{Op: ops[span.Op], Text: span.Text})
+	}
+	return &pb.TextDiff{
+		Changed: diff.Changed(spans),
+		Spans:   pbSpans,
+	}
+}
+
+// diffTags reports the tags added and removed between two revisions, in the
+// order they appear in each.
+func diffTags(from, to []string) *pb.TagsDiff {
+	result := &pb.TagsDiff{Changed: !slices.Equal(from, to)}
+	for _, tag := range to {
+		if !slices.Contains(from, tag) {
+			result.Added = append(result.Added, tag)
+		}
+	}
+	for _, tag := range from {
+		if !slices.Contains(to, tag) {
+			result.Removed = append(result.Removed, tag)
+		}
 	}
+	return result
 }
@@ -3,11 +3,20 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	models "github.com/pandae7/go-blogger/internal/models"
+	query "github.com/pandae7/go-blogger/internal/query"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
 	pb "github.com/pandae7/go-blogger/proto/blog"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Mock storage for testing
@@ -15,20 +24,159 @@ type mockBlogStorage struct {
 	CreatePostFunc func(ctx context.Context, post *models.BlogPost) error
 	GetPostFunc    func(ctx context.Context, postID string) (*models.BlogPost, error)
 	UpdatePostFunc func(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error)
-	DeletePostFunc func(ctx context.Context, postID string) error
+	DeletePostFunc func(ctx context.Context, req *models.DeleteBlogPostRequest) error
+	ListPostsFunc  func(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error)
+
+	CreatePostsFunc func(ctx context.Context, posts []*models.BlogPost) error
+	GetPostsFunc    func(ctx context.Context, postIDs []string) ([]*models.BlogPost, error)
+	DeletePostsFunc func(ctx context.Context, req *models.BatchDeleteRequest) ([]error, error)
+
+	CreatePostOnceFunc        func(ctx context.Context, post *models.BlogPost, key *models.IdempotencyKey) (*models.BlogPost, bool, error)
+	ExpireIdempotencyKeysFunc func(ctx context.Context, now time.Time) (int, error)
+
+	ListPostsByAuthorFunc func(ctx context.Context, req *models.ListPostsByAuthorRequest) ([]*models.BlogPost, string, error)
+	ListPostsByTagFunc    func(ctx context.Context, req *models.ListPostsByTagRequest) ([]*models.BlogPost, string, error)
+	SearchPostsFunc       func(ctx context.Context, req *models.SearchPostsRequest) ([]*models.SearchResult, string, error)
+	GetRelatedPostsFunc   func(ctx context.Context, req *models.GetRelatedPostsRequest) ([]*models.RelatedPost, error)
+
+	ListRevisionsFunc func(ctx context.Context, postID string) ([]*models.PostRevision, error)
+	GetRevisionFunc   func(ctx context.Context, postID string, revision int64) (*models.PostRevision, error)
+
+	TrashPostFunc          func(ctx context.Context, req *models.DeleteBlogPostRequest) error
+	RestorePostFunc        func(ctx context.Context, postID string) (*models.BlogPost, error)
+	PurgePostFunc          func(ctx context.Context, postID string) error
+	PurgeTrashedBeforeFunc func(ctx context.Context, cutoff time.Time) (int, error)
+
+	SetPostStatusFunc    func(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error)
+	PublishScheduledFunc func(ctx context.Context, now time.Time) (int, error)
+
+	ExportPostsFunc func(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error
+	WatchPostsFunc  func(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error
 }
 
 func (m *mockBlogStorage) CreatePost(ctx context.Context, post *models.BlogPost) error {
 	return m.CreatePostFunc(ctx, post)
 }
+func (m *mockBlogStorage) CreatePosts(ctx context.Context, posts []*models.BlogPost) error {
+	return m.CreatePostsFunc(ctx, posts)
+}
+func (m *mockBlogStorage) CreatePostOnce(ctx context.Context, post *models.BlogPost, key *models.IdempotencyKey) (*models.BlogPost, bool, error) {
+	return m.CreatePostOnceFunc(ctx, post, key)
+}
+func (m *mockBlogStorage) ExpireIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	return m.ExpireIdempotencyKeysFunc(ctx, now)
+}
 func (m *mockBlogStorage) GetPost(ctx context.Context, postID string) (*models.BlogPost, error) {
 	return m.GetPostFunc(ctx, postID)
 }
+func (m *mockBlogStorage) GetPosts(ctx context.Context, postIDs []string) ([]*models.BlogPost, error) {
+	return m.GetPostsFunc(ctx, postIDs)
+}
 func (m *mockBlogStorage) UpdatePost(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
 	return m.UpdatePostFunc(ctx, req)
 }
-func (m *mockBlogStorage) DeletePost(ctx context.Context, postID string) error {
-	return m.DeletePostFunc(ctx, postID)
+func (m *mockBlogStorage) DeletePost(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+	return m.DeletePostFunc(ctx, req)
+}
+func (m *mockBlogStorage) DeletePosts(ctx context.Context, req *models.BatchDeleteRequest) ([]error, error) {
+	return m.DeletePostsFunc(ctx, req)
+}
+func (m *mockBlogStorage) ListPosts(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error) {
+	return m.ListPostsFunc(ctx, req)
+}
+func (m *mockBlogStorage) ListPostsByAuthor(ctx context.Context, req *models.ListPostsByAuthorRequest) ([]*models.BlogPost, string, error) {
+	return m.ListPostsByAuthorFunc(ctx, req)
+}
+func (m *mockBlogStorage) ListPostsByTag(ctx context.Context, req *models.ListPostsByTagRequest) ([]*models.BlogPost, string, error) {
+	return m.ListPostsByTagFunc(ctx, req)
+}
+func (m *mockBlogStorage) SearchPosts(ctx context.Context, req *models.SearchPostsRequest) ([]*models.SearchResult, string, error) {
+	return m.SearchPostsFunc(ctx, req)
+}
+func (m *mockBlogStorage) GetRelatedPosts(ctx context.Context, req *models.GetRelatedPostsRequest) ([]*models.RelatedPost, error) {
+	return m.GetRelatedPostsFunc(ctx, req)
+}
+func (m *mockBlogStorage) ListRevisions(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+	return m.ListRevisionsFunc(ctx, postID)
+}
+func (m *mockBlogStorage) GetRevision(ctx context.Context, postID string, revision int64) (*models.PostRevision, error) {
+	return m.GetRevisionFunc(ctx, postID, revision)
+}
+func (m *mockBlogStorage) TrashPost(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+	return m.TrashPostFunc(ctx, req)
+}
+func (m *mockBlogStorage) RestorePost(ctx context.Context, postID string) (*models.BlogPost, error) {
+	return m.RestorePostFunc(ctx, postID)
+}
+func (m *mockBlogStorage) PurgePost(ctx context.Context, postID string) error {
+	return m.PurgePostFunc(ctx, postID)
+}
+func (m *mockBlogStorage) PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	return m.PurgeTrashedBeforeFunc(ctx, cutoff)
+}
+func (m *mockBlogStorage) SetPostStatus(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error) {
+	return m.SetPostStatusFunc(ctx, req)
+}
+func (m *mockBlogStorage) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	return m.PublishScheduledFunc(ctx, now)
+}
+func (m *mockBlogStorage) ExportPosts(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error {
+	return m.ExportPostsFunc(ctx, req, fn)
+}
+func (m *mockBlogStorage) WatchPosts(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+	return m.WatchPostsFunc(ctx, req, fn)
+}
+
+// mockImportStream feeds requests to ImportBlogPosts and records its response.
+type mockImportStream struct {
+	grpc.ServerStream
+	reqs []*pb.ImportBlogPostsRequest
+	resp *pb.ImportBlogPostsResponse
+}
+
+func (m *mockImportStream) Context() context.Context {
+	return context.Background()
+}
+func (m *mockImportStream) Recv() (*pb.ImportBlogPostsRequest, error) {
+	if len(m.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := m.reqs[0]
+	m.reqs = m.reqs[1:]
+	return req, nil
+}
+func (m *mockImportStream) SendAndClose(resp *pb.ImportBlogPostsResponse) error {
+	m.resp = resp
+	return nil
+}
+
+// mockExportStream records the posts sent on an ExportBlogPosts stream.
+type mockExportStream struct {
+	grpc.ServerStream
+	sent []*pb.ExportBlogPostsResponse
+}
+
+func (m *mockExportStream) Context() context.Context {
+	return context.Background()
+}
+func (m *mockExportStream) Send(resp *pb.ExportBlogPostsResponse) error {
+	m.sent = append(m.sent, resp)
+	return nil
+}
+
+// mockWatchStream records the events sent on a WatchBlogPosts stream.
+type mockWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*pb.PostEvent
+}
+
+func (m *mockWatchStream) Context() context.Context {
+	return m.ctx
+}
+func (m *mockWatchStream) Send(event *pb.PostEvent) error {
+	m.events = append(m.events, event)
+	return nil
 }
 
 func TestCreateBlogPost_Success(t *testing.T) {
@@ -39,10 +187,10 @@ func TestCreateBlogPost_Success(t *testing.T) {
 	}
 	server := NewBlogServiceServer(mockStorage)
 	req := &pb.CreateBlogPostRequest{
-		Title:   "Blog Test",
-		Content: "Test Blog Content",
-		Author:  "NotAman",
-		Tags:    []string{"test1", "test2"},
+		Title:    "Blog Test",
+		Content:  "Test Blog Content",
+		AuthorId: "author-1",
+		Tags:     []string{"test1", "test2"},
 	}
 	resp, err := server.CreateBlogPost(context.Background(), req)
 	if err != nil || !resp.Success {
@@ -54,9 +202,9 @@ func TestCreateBlogPost_InvalidRequest(t *testing.T) {
 	mockStorage := &mockBlogStorage{}
 	server := NewBlogServiceServer(mockStorage)
 	req := &pb.CreateBlogPostRequest{
-		Title:   "",
-		Content: "",
-		Author:  "",
+		Title:    "",
+		Content:  "",
+		AuthorId: "",
 	}
 	resp, err := server.CreateBlogPost(context.Background(), req)
 	if err == nil || resp.Success {
@@ -64,6 +212,220 @@ func TestCreateBlogPost_InvalidRequest(t *testing.T) {
 	}
 }
 
+func TestCreateBlogPost_RequestID(t *testing.T) {
+	// The mock remembers keys like the storage does
+	keys := make(map[string]*models.IdempotencyKey)
+	posts := make(map[string]*models.BlogPost)
+	mockStorage := &mockBlogStorage{
+		CreatePostOnceFunc: func(ctx context.Context, post *models.BlogPost, key *models.IdempotencyKey) (*models.BlogPost, bool, error) {
+			if previous, ok := keys[key.RequestId]; ok {
+				if previous.Fingerprint != key.Fingerprint {
+					return nil, false, models.ErrRequestIDReused
+				}
+				return posts[previous.PostId], false, nil
+			}
+			if time.Until(key.ExpiresAt) <= 0 || time.Until(key.ExpiresAt) > DefaultRequestRetention {
+				t.Errorf("expected the key to expire within the retention, got %v", key.ExpiresAt)
+			}
+			stored := *key
+			stored.PostId = post.PostId
+			keys[key.RequestId] = &stored
+			posts[post.PostId] = post
+			return post, true, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.CreateBlogPostRequest{Title: "Title", Content: "Content", AuthorId: "a1", Tags: []string{"go"}, RequestId: "r1"}
+
+	first, err := server.CreateBlogPost(context.Background(), req)
+	if err != nil || !first.Success || first.Replayed {
+		t.Fatalf("expected the post to be created, got error: %v, resp: %+v", err, first)
+	}
+	retry, err := server.CreateBlogPost(context.Background(), req)
+	if err != nil || !retry.Success || !retry.Replayed || retry.Post.GetPostId() != first.Post.GetPostId() {
+		t.Errorf("expected the retry to return post %s, got error: %v, resp: %+v", first.Post.GetPostId(), err, retry)
+	}
+
+	changed := &pb.CreateBlogPostRequest{Title: "Title", Content: "Content", AuthorId: "a1", Tags: []string{"rust"}, RequestId: "r1"}
+	if resp, err := server.CreateBlogPost(context.Background(), changed); !errors.Is(err, models.ErrRequestIDReused) || resp != nil {
+		t.Errorf("expected ErrRequestIDReused for a different payload, got: %v, resp: %+v", err, resp)
+	}
+	other := &pb.CreateBlogPostRequest{Title: "Title", Content: "Content", AuthorId: "a1", Tags: []string{"go"}, RequestId: "r2"}
+	if resp, err := server.CreateBlogPost(context.Background(), other); err != nil || resp.Replayed {
+		t.Errorf("expected another request ID to create a post, got error: %v, resp: %+v", err, resp)
+	}
+
+	tooLong := &pb.CreateBlogPostRequest{Title: "Title", Content: "Content", AuthorId: "a1", RequestId: strings.Repeat("r", maxRequestIDLength+1)}
+	if resp, err := server.CreateBlogPost(context.Background(), tooLong); !errors.Is(err, models.ErrInvalidRequestID) || resp.Success {
+		t.Errorf("expected ErrInvalidRequestID, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestCreateBlogPost_Author(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		CreatePostFunc: func(ctx context.Context, post *models.BlogPost) error {
+			if post.AuthorId != "missing" {
+				t.Errorf("expected author ID to be passed to storage, got %q", post.AuthorId)
+			}
+			return models.ErrAuthorNotFound
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.CreateBlogPostRequest{
+		Title:   "Blog Test",
+		Content: "Test Blog Content",
+	}
+	if _, err := server.CreateBlogPost(context.Background(), req); !errors.Is(err, models.ErrInvalidAuthorID) {
+		t.Errorf("expected ErrInvalidAuthorID without an author ID, got: %v", err)
+	}
+	req.AuthorId = "missing"
+	if _, err := server.CreateBlogPost(context.Background(), req); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("expected ErrAuthorNotFound, got: %v", err)
+	}
+}
+
+func TestCreateBlogPost_NormalizesTags(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		CreatePostFunc: func(ctx context.Context, post *models.BlogPost) error {
+			if fmt.Sprint(post.Tags) != "[go cloud-native]" {
+				t.Errorf("expected normalized tags, got %q", post.Tags)
+			}
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.CreateBlogPost(context.Background(), &pb.CreateBlogPostRequest{
+		Title:    "Blog Test",
+		Content:  "Test Blog Content",
+		AuthorId: "a1",
+		Tags:     []string{"Go", " go ", "Cloud Native"},
+	})
+	if err != nil || !resp.Success {
+		t.Errorf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+
+	// A stricter policy rejects what it does not allow
+	server.SetTagPolicy(tagpolicy.Policy{MaxCount: 1})
+	resp, err = server.CreateBlogPost(context.Background(), &pb.CreateBlogPostRequest{
+		Title:    "Blog Test",
+		Content:  "Test Blog Content",
+		AuthorId: "a1",
+		Tags:     []string{"Go", "go"},
+	})
+	var policyErr *tagpolicy.PolicyError
+	if !errors.As(err, &policyErr) || resp.Success {
+		t.Errorf("expected a PolicyError, got: %v", err)
+	}
+}
+
+func TestCreateBlogPost_Status(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		CreatePostFunc: func(ctx context.Context, post *models.BlogPost) error {
+			if post.Status != models.StatusDraft {
+				t.Errorf("expected a draft, got %q", post.Status)
+			}
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.CreateBlogPostRequest{
+		Title:    "Blog Test",
+		Content:  "Test Blog Content",
+		AuthorId: "author-1",
+		Status:   pb.PostStatus_POST_STATUS_DRAFT,
+	}
+	if _, err := server.CreateBlogPost(context.Background(), req); err != nil {
+		t.Errorf("expected success, got error: %v", err)
+	}
+
+	req.Status = pb.PostStatus(42)
+	if _, err := server.CreateBlogPost(context.Background(), req); !errors.Is(err, models.ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus, got: %v", err)
+	}
+}
+
+// importRequests returns one import request per title, in the given mode.
+func importRequests(mode pb.ImportMode, titles ...string) []*pb.ImportBlogPostsRequest {
+	reqs := make([]*pb.ImportBlogPostsRequest, len(titles))
+	for i, title := range titles {
+		reqs[i] = &pb.ImportBlogPostsRequest{
+			Post: &pb.CreateBlogPostRequest{Title: title, Content: "Content", AuthorId: "author-1"},
+			Mode: mode,
+		}
+	}
+	return reqs
+}
+
+func TestImportBlogPosts_BestEffort(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		CreatePostFunc: func(ctx context.Context, post *models.BlogPost) error {
+			if post.Title == "Taken" {
+				return models.ErrDuplicatePost
+			}
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	stream := &mockImportStream{reqs: importRequests(pb.ImportMode_IMPORT_MODE_BEST_EFFORT, "First", "", "Taken", "Last")}
+	if err := server.ImportBlogPosts(stream); err != nil {
+		t.Fatalf("ImportBlogPosts failed: %v", err)
+	}
+	resp := stream.resp
+	if resp.GetReceived() != 4 || resp.GetCreated() != 2 || resp.GetFailed() != 2 || resp.GetSuccess() {
+		t.Fatalf("unexpected summary: %+v", resp)
+	}
+	ids := resp.GetPostIds()
+	if len(ids) != 4 || ids[0] == "" || ids[1] != "" || ids[2] != "" || ids[3] == "" {
+		t.Errorf("expected IDs for the first and last posts only, got %q", ids)
+	}
+	errs := resp.GetErrors()
+	if len(errs) != 2 || errs[0].GetIndex() != 1 || errs[0].GetReason() != "EMPTY_TITLE" ||
+		errs[1].GetIndex() != 2 || errs[1].GetReason() != "DUPLICATE_POST" {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestImportBlogPosts_AllOrNothing(t *testing.T) {
+	var created []*models.BlogPost
+	mockStorage := &mockBlogStorage{
+		CreatePostsFunc: func(ctx context.Context, posts []*models.BlogPost) error {
+			if posts[len(posts)-1].Title == "Taken" {
+				return &models.ItemError{Index: len(posts) - 1, Err: models.ErrDuplicatePost}
+			}
+			created = posts
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	mode := pb.ImportMode_IMPORT_MODE_ALL_OR_NOTHING
+
+	stream := &mockImportStream{reqs: importRequests(mode, "First", "Second")}
+	if err := server.ImportBlogPosts(stream); err != nil {
+		t.Fatalf("ImportBlogPosts failed: %v", err)
+	}
+	if resp := stream.resp; !resp.GetSuccess() || resp.GetCreated() != 2 || len(created) != 2 || resp.GetPostIds()[1] != created[1].PostId {
+		t.Errorf("expected both posts to be created at once, got %+v", resp)
+	}
+
+	// Invalid posts fail the import without reaching the storage
+	stream = &mockImportStream{reqs: importRequests(mode, "First", "", "Taken")}
+	if err := server.ImportBlogPosts(stream); err != nil {
+		t.Fatalf("ImportBlogPosts failed: %v", err)
+	}
+	if resp := stream.resp; resp.GetSuccess() || resp.GetCreated() != 0 || resp.GetFailed() != 1 || resp.GetErrors()[0].GetIndex() != 1 {
+		t.Errorf("expected the invalid post to fail the import, got %+v", resp)
+	}
+	// Posts the storage rejects are reported at their position in the stream
+	stream = &mockImportStream{reqs: importRequests(mode, "First", "Taken")}
+	if err := server.ImportBlogPosts(stream); err != nil {
+		t.Fatalf("ImportBlogPosts failed: %v", err)
+	}
+	if resp := stream.resp; resp.GetSuccess() || resp.GetCreated() != 0 || resp.GetErrors()[0].GetIndex() != 1 ||
+		resp.GetErrors()[0].GetReason() != "DUPLICATE_POST" || resp.GetPostIds()[0] != "" {
+		t.Errorf("expected the duplicate to fail the import, got %+v", resp)
+	}
+}
+
 func TestGetBlogPost_Success(t *testing.T) {
 	mockStorage := &mockBlogStorage{
 		GetPostFunc: func(ctx context.Context, postID string) (*models.BlogPost, error) {
@@ -100,6 +462,53 @@ func TestGetBlogPost_NotFound(t *testing.T) {
 	}
 }
 
+func TestBatchGetBlogPosts_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		GetPostsFunc: func(ctx context.Context, postIDs []string) ([]*models.BlogPost, error) {
+			return []*models.BlogPost{{PostId: "p2", Title: "Title"}, nil, {PostId: "p1", Title: "Title"}}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.BatchGetBlogPosts(context.Background(), &pb.BatchGetBlogPostsRequest{
+		PostIds: []string{"p2", "missing", "p1"},
+	})
+	if err != nil || resp.Success || resp.Found != 2 || resp.Missing != 1 || len(resp.Results) != 3 {
+		t.Fatalf("expected 2 posts found and 1 missing, got error: %v, resp: %+v", err, resp)
+	}
+	for i, want := range []string{"p2", "", "p1"} {
+		if got := resp.Results[i].GetPost().GetPostId(); got != want {
+			t.Errorf("result %d: expected post %q, got %q", i, want, got)
+		}
+	}
+	if r := resp.Results[1]; r.PostId != "missing" || r.Reason != "POST_NOT_FOUND" {
+		t.Errorf("expected POST_NOT_FOUND for the missing post, got %+v", r)
+	}
+}
+
+func TestBatchGetBlogPosts_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	tooMany := make([]string, maxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("p%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		postIds []string
+		want    error
+	}{
+		{"empty", nil, models.ErrEmptyBatch},
+		{"too large", tooMany, models.ErrBatchTooLarge},
+		{"empty ID", []string{"p1", ""}, models.ErrInvalidPostID},
+	}
+	for _, tt := range tests {
+		resp, err := server.BatchGetBlogPosts(context.Background(), &pb.BatchGetBlogPostsRequest{PostIds: tt.postIds})
+		if !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("%s: expected %v, got: %v, resp: %+v", tt.name, tt.want, err, resp)
+		}
+	}
+}
+
 func TestUpdateBlogPost_Success(t *testing.T) {
 	mockStorage := &mockBlogStorage{
 		UpdatePostFunc: func(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
@@ -127,6 +536,88 @@ func TestUpdateBlogPost_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateBlogPost_InvalidTags(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	resp, err := server.UpdateBlogPost(context.Background(), &pb.UpdateBlogPostRequest{
+		PostId: "p1",
+		Tags:   []string{"go", " ", strings.Repeat("x", tagpolicy.DefaultMaxLength+1)},
+	})
+	var policyErr *tagpolicy.PolicyError
+	if !errors.As(err, &policyErr) || resp.Success {
+		t.Fatalf("expected a PolicyError, got: %v", err)
+	}
+	if len(policyErr.Violations) != 2 || policyErr.Violations[0].Field != "tags[1]" || policyErr.Violations[1].Field != "tags[2]" {
+		t.Errorf("unexpected violations: %+v", policyErr.Violations)
+	}
+}
+
+func TestUpdateBlogPost_ExpectedVersion(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		UpdatePostFunc: func(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
+			if req.ExpectedVersion != 3 {
+				return nil, models.ErrVersionMismatch
+			}
+			return &models.BlogPost{PostId: req.PostId, Title: req.Title, Version: 4}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.UpdateBlogPostRequest{PostId: "123", Title: "Updated Title", ExpectedVersion: 3}
+	resp, err := server.UpdateBlogPost(context.Background(), req)
+	if err != nil || resp.GetPost().GetVersion() != 4 {
+		t.Errorf("expected version 4, got error: %v, resp: %+v", err, resp)
+	}
+
+	req.ExpectedVersion = 2
+	resp, err = server.UpdateBlogPost(context.Background(), req)
+	if !errors.Is(err, models.ErrVersionMismatch) || resp.Success {
+		t.Errorf("expected ErrVersionMismatch, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestUpdateBlogPost_NegativeVersion(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	req := &pb.UpdateBlogPostRequest{PostId: "123", Title: "Updated Title", ExpectedVersion: -1}
+	if _, err := server.UpdateBlogPost(context.Background(), req); !errors.Is(err, models.ErrInvalidVersion) {
+		t.Errorf("expected ErrInvalidVersion, got: %v", err)
+	}
+}
+
+func TestUpdateBlogPost_UpdateMask(t *testing.T) {
+	var got *models.UpdateBlogPostRequest
+	mockStorage := &mockBlogStorage{
+		UpdatePostFunc: func(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
+			got = req
+			return &models.BlogPost{PostId: req.PostId, Title: "Title"}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+
+	// Clearing the tags is a valid update even though every field is empty
+	req := &pb.UpdateBlogPostRequest{PostId: "123", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags"}}}
+	if _, err := server.UpdateBlogPost(context.Background(), req); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(got.UpdateMask) != 1 || got.UpdateMask[0] != "tags" {
+		t.Errorf("expected the mask to reach storage, got: %+v", got)
+	}
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{"author", models.ErrImmutableField},
+		{"publication_date", models.ErrImmutableField},
+		{"summary", models.ErrInvalidUpdateMask},
+		{"title", models.ErrEmptyTitle},
+	}
+	for _, tt := range tests {
+		req := &pb.UpdateBlogPostRequest{PostId: "123", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{tt.path}}}
+		if _, err := server.UpdateBlogPost(context.Background(), req); !errors.Is(err, tt.want) {
+			t.Errorf("path %q: expected %v, got: %v", tt.path, tt.want, err)
+		}
+	}
+}
+
 func TestUpdateBlogPost_InvalidRequest(t *testing.T) {
 	mockStorage := &mockBlogStorage{}
 	server := NewBlogServiceServer(mockStorage)
@@ -141,7 +632,7 @@ func TestUpdateBlogPost_InvalidRequest(t *testing.T) {
 
 func TestDeleteBlogPost_Success(t *testing.T) {
 	mockStorage := &mockBlogStorage{
-		DeletePostFunc: func(ctx context.Context, postID string) error {
+		TrashPostFunc: func(ctx context.Context, req *models.DeleteBlogPostRequest) error {
 			return nil
 		},
 	}
@@ -155,7 +646,7 @@ func TestDeleteBlogPost_Success(t *testing.T) {
 
 func TestDeleteBlogPost_Failure(t *testing.T) {
 	mockStorage := &mockBlogStorage{
-		DeletePostFunc: func(ctx context.Context, postID string) error {
+		TrashPostFunc: func(ctx context.Context, req *models.DeleteBlogPostRequest) error {
 			return errors.New("delete failed")
 		},
 	}
@@ -167,6 +658,488 @@ func TestDeleteBlogPost_Failure(t *testing.T) {
 	}
 }
 
+func TestDeleteBlogPost_VersionMismatch(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		TrashPostFunc: func(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+			if req.ExpectedVersion != 1 {
+				return models.ErrVersionMismatch
+			}
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.DeleteBlogPostRequest{PostId: "123", ExpectedVersion: 2}
+	resp, err := server.DeleteBlogPost(context.Background(), req)
+	if !errors.Is(err, models.ErrVersionMismatch) || resp.Success {
+		t.Errorf("expected ErrVersionMismatch, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestDeleteBlogPost_Permanent(t *testing.T) {
+	var trashed, deleted bool
+	mockStorage := &mockBlogStorage{
+		TrashPostFunc: func(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+			trashed = true
+			return nil
+		},
+		DeletePostFunc: func(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+			deleted = true
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.DeleteBlogPostRequest{PostId: "123", Permanent: true}
+	resp, err := server.DeleteBlogPost(context.Background(), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if trashed || !deleted {
+		t.Errorf("expected a permanent delete to skip the trash")
+	}
+}
+
+func TestBatchDeleteBlogPosts_Success(t *testing.T) {
+	var got *models.BatchDeleteRequest
+	mockStorage := &mockBlogStorage{
+		DeletePostsFunc: func(ctx context.Context, req *models.BatchDeleteRequest) ([]error, error) {
+			got = req
+			return []error{nil, models.ErrPostNotFound}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.BatchDeleteBlogPosts(context.Background(), &pb.BatchDeleteBlogPostsRequest{
+		PostIds:   []string{"p1", "p1"},
+		Permanent: true,
+	})
+	if err != nil || resp.Success || resp.Deleted != 1 || resp.Missing != 1 {
+		t.Fatalf("expected 1 post deleted and 1 missing, got error: %v, resp: %+v", err, resp)
+	}
+	if !got.Permanent || len(got.PostIds) != 2 {
+		t.Errorf("expected a permanent delete of both IDs, got %+v", got)
+	}
+	if r := resp.Results[0]; !r.Deleted || r.Reason != "" {
+		t.Errorf("expected the first post deleted, got %+v", r)
+	}
+	if r := resp.Results[1]; r.Deleted || r.Reason != "POST_NOT_FOUND" {
+		t.Errorf("expected POST_NOT_FOUND for the repeated post, got %+v", r)
+	}
+}
+
+func TestRestoreBlogPost_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		RestorePostFunc: func(ctx context.Context, postID string) (*models.BlogPost, error) {
+			return &models.BlogPost{PostId: postID, Title: "Title"}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.RestoreBlogPost(context.Background(), &pb.RestoreBlogPostRequest{PostId: "123"})
+	if err != nil || !resp.Success || resp.GetPost().GetPostId() != "123" {
+		t.Errorf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if resp.GetPost().GetDeletedAt() != nil {
+		t.Errorf("expected a restored post without deleted_at, got: %v", resp.GetPost().GetDeletedAt())
+	}
+}
+
+func TestPurgeBlogPost_NotTrashed(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		PurgePostFunc: func(ctx context.Context, postID string) error {
+			return models.ErrPostNotTrashed
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.PurgeBlogPost(context.Background(), &pb.PurgeBlogPostRequest{PostId: "123"})
+	if !errors.Is(err, models.ErrPostNotTrashed) || resp.Success {
+		t.Errorf("expected ErrPostNotTrashed, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestPublishBlogPost_Scheduled(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+	mockStorage := &mockBlogStorage{
+		SetPostStatusFunc: func(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error) {
+			if req.Status != models.StatusPublished || !req.PublishAt.Equal(publishAt) || req.ExpectedVersion != 2 || req.Editor != "alice" {
+				t.Errorf("unexpected status request: %+v", req)
+			}
+			return &models.BlogPost{PostId: req.PostId, Status: models.StatusScheduled, PublicationDate: req.PublishAt}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.PublishBlogPost(context.Background(), &pb.PublishBlogPostRequest{
+		PostId:          "123",
+		PublishAt:       timestamppb.New(publishAt),
+		ExpectedVersion: 2,
+		Editor:          "alice",
+	})
+	if err != nil || !resp.Success || resp.GetPost().GetStatus() != pb.PostStatus_POST_STATUS_SCHEDULED {
+		t.Errorf("expected a scheduled post, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestArchiveBlogPost_InvalidTransition(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		SetPostStatusFunc: func(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error) {
+			if req.Status != models.StatusArchived {
+				t.Errorf("expected an archive request, got %+v", req)
+			}
+			return nil, models.ErrInvalidTransition
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.ArchiveBlogPost(context.Background(), &pb.ArchiveBlogPostRequest{PostId: "123"})
+	if !errors.Is(err, models.ErrInvalidTransition) || resp.Success {
+		t.Errorf("expected ErrInvalidTransition, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestUnpublishBlogPost_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	if _, err := server.UnpublishBlogPost(context.Background(), &pb.UnpublishBlogPostRequest{}); !errors.Is(err, models.ErrInvalidPostID) {
+		t.Errorf("expected ErrInvalidPostID, got: %v", err)
+	}
+	_, err := server.UnpublishBlogPost(context.Background(), &pb.UnpublishBlogPostRequest{PostId: "123", ExpectedVersion: -1})
+	if !errors.Is(err, models.ErrInvalidVersion) {
+		t.Errorf("expected ErrInvalidVersion, got: %v", err)
+	}
+}
+
+func TestListBlogPosts_ShowDeleted(t *testing.T) {
+	deletedAt := time.Now()
+	mockStorage := &mockBlogStorage{
+		ListPostsFunc: func(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error) {
+			if !req.ShowDeleted {
+				return nil, "", nil
+			}
+			return []*models.BlogPost{{PostId: "1", DeletedAt: deletedAt}}, "", nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.ListBlogPosts(context.Background(), &pb.ListBlogPostsRequest{ShowDeleted: true})
+	if err != nil || len(resp.Posts) != 1 {
+		t.Fatalf("expected the trashed post, got error: %v, resp: %+v", err, resp)
+	}
+	if !resp.Posts[0].GetDeletedAt().AsTime().Equal(deletedAt) {
+		t.Errorf("expected deleted_at %v, got %v", deletedAt, resp.Posts[0].GetDeletedAt())
+	}
+}
+
+func TestListBlogPosts_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		ListPostsFunc: func(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error) {
+			if req.PageSize != 2 || req.PageToken != "token" {
+				t.Errorf("unexpected list request: %+v", req)
+			}
+			return []*models.BlogPost{
+				{PostId: "1", Title: "First", PublicationDate: time.Now()},
+				{PostId: "2", Title: "Second", PublicationDate: time.Now()},
+			}, "next", nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.ListBlogPostsRequest{PageSize: 2, PageToken: "token"}
+	resp, err := server.ListBlogPosts(context.Background(), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if len(resp.Posts) != 2 || resp.NextPageToken != "next" {
+		t.Errorf("expected 2 posts and next page token, got: %+v", resp)
+	}
+}
+
+func TestListBlogPosts_InvalidPageToken(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		ListPostsFunc: func(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error) {
+			return nil, "", models.ErrInvalidPageToken
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.ListBlogPostsRequest{PageToken: "garbage"}
+	resp, err := server.ListBlogPosts(context.Background(), req)
+	if !errors.Is(err, models.ErrInvalidPageToken) || resp.Success {
+		t.Errorf("expected invalid page token error, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestListBlogPosts_NegativePageSize(t *testing.T) {
+	mockStorage := &mockBlogStorage{}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.ListBlogPostsRequest{PageSize: -1}
+	resp, err := server.ListBlogPosts(context.Background(), req)
+	if !errors.Is(err, models.ErrInvalidPageSize) || resp.Success {
+		t.Errorf("expected invalid page size error, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestListBlogPosts_InvalidFilter(t *testing.T) {
+	mockStorage := &mockBlogStorage{}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.ListBlogPostsRequest{Filter: `author = "x" AND`}
+	resp, err := server.ListBlogPosts(context.Background(), req)
+	var parseErr *query.ParseError
+	if !errors.As(err, &parseErr) || resp.Success {
+		t.Fatalf("expected filter parse error, got: %v, resp: %+v", err, resp)
+	}
+	if parseErr.Field != "filter" || parseErr.Pos != 17 {
+		t.Errorf("expected parse error in filter at position 17, got: %+v", parseErr)
+	}
+}
+
+func TestListBlogPostsByAuthor_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		ListPostsByAuthorFunc: func(ctx context.Context, req *models.ListPostsByAuthorRequest) ([]*models.BlogPost, string, error) {
+			if req.AuthorId != "a1" || req.PageSize != 1 || req.PageToken != "token" {
+				t.Errorf("unexpected list request: %+v", req)
+			}
+			return []*models.BlogPost{{PostId: "1", AuthorId: "a1"}}, "next", nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.ListBlogPostsByAuthorRequest{AuthorId: "a1", PageSize: 1, PageToken: "token"}
+	resp, err := server.ListBlogPostsByAuthor(context.Background(), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if len(resp.Posts) != 1 || resp.NextPageToken != "next" {
+		t.Errorf("expected 1 post and next page token, got: %+v", resp)
+	}
+}
+
+func TestListBlogPostsByAuthor_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	tests := []struct {
+		req  *pb.ListBlogPostsByAuthorRequest
+		want error
+	}{
+		{&pb.ListBlogPostsByAuthorRequest{}, models.ErrInvalidAuthorID},
+		{&pb.ListBlogPostsByAuthorRequest{AuthorId: "a1", PageSize: -1}, models.ErrInvalidPageSize},
+	}
+	for _, tt := range tests {
+		if resp, err := server.ListBlogPostsByAuthor(context.Background(), tt.req); !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("expected %v, got: %v, resp: %+v", tt.want, err, resp)
+		}
+	}
+}
+
+func TestListBlogPostsByTag_NormalizesTag(t *testing.T) {
+	var got []string
+	mockStorage := &mockBlogStorage{
+		ListPostsByTagFunc: func(ctx context.Context, req *models.ListPostsByTagRequest) ([]*models.BlogPost, string, error) {
+			got = append(got, req.Tag)
+			return nil, "", nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	for _, tag := range []string{" Cloud  Native ", strings.Repeat("x", tagpolicy.DefaultMaxLength+1)} {
+		if _, err := server.ListBlogPostsByTag(context.Background(), &pb.ListBlogPostsByTagRequest{Tag: tag}); err != nil {
+			t.Fatalf("ListBlogPostsByTag(%q) failed: %v", tag, err)
+		}
+	}
+	// A tag the policy rejects is looked up as given
+	if len(got) != 2 || got[0] != "cloud-native" || len(got[1]) != tagpolicy.DefaultMaxLength+1 {
+		t.Errorf("unexpected tags looked up: %q", got)
+	}
+}
+
+func TestListBlogPostsByTag_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	tests := []struct {
+		req  *pb.ListBlogPostsByTagRequest
+		want error
+	}{
+		{&pb.ListBlogPostsByTagRequest{Tag: "  "}, models.ErrEmptyTag},
+		{&pb.ListBlogPostsByTagRequest{Tag: "go", PageSize: -1}, models.ErrInvalidPageSize},
+	}
+	for _, tt := range tests {
+		if resp, err := server.ListBlogPostsByTag(context.Background(), tt.req); !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("expected %v, got: %v, resp: %+v", tt.want, err, resp)
+		}
+	}
+}
+
+func TestSearchBlogPosts_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		SearchPostsFunc: func(ctx context.Context, req *models.SearchPostsRequest) ([]*models.SearchResult, string, error) {
+			if req.Query != "grpc stream*" || req.PageSize != 1 || req.PageToken != "token" {
+				t.Errorf("unexpected search request: %+v", req)
+			}
+			post := &models.BlogPost{
+				PostId:  "1",
+				Title:   "Streaming with gRPC",
+				Content: strings.Repeat("Filler text. ", 20) + "Server streaming keeps the connection open. " + strings.Repeat("More text. ", 20),
+			}
+			return []*models.SearchResult{{Post: post, Score: 2.5}}, "next", nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.SearchBlogPostsRequest{Query: "grpc stream*", PageSize: 1, PageToken: "token"}
+	resp, err := server.SearchBlogPosts(context.Background(), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if len(resp.Results) != 1 || resp.NextPageToken != "next" || resp.Results[0].Score != 2.5 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	render := func(spans []*pb.HighlightSpan) string {
+		var b strings.Builder
+		for _, span := range spans {
+			if span.Highlighted {
+				b.WriteString("[" + span.Text + "]")
+			} else {
+				b.WriteString(span.Text)
+			}
+		}
+		return b.String()
+	}
+	if got := render(resp.Results[0].Title); got != "[Streaming] with [gRPC]" {
+		t.Errorf("unexpected title highlights: %q", got)
+	}
+	snippet := render(resp.Results[0].Snippet)
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") || !strings.Contains(snippet, "Server [streaming] keeps") {
+		t.Errorf("unexpected snippet: %q", snippet)
+	}
+}
+
+func TestSearchBlogPosts_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	tests := []struct {
+		req  *pb.SearchBlogPostsRequest
+		want error
+	}{
+		{&pb.SearchBlogPostsRequest{Query: ` "" !! `}, models.ErrEmptyQuery},
+		{&pb.SearchBlogPostsRequest{Query: "go", PageSize: -1}, models.ErrInvalidPageSize},
+	}
+	for _, tt := range tests {
+		if resp, err := server.SearchBlogPosts(context.Background(), tt.req); !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("expected %v, got: %v, resp: %+v", tt.want, err, resp)
+		}
+	}
+}
+
+func TestGetRelatedPosts_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		GetRelatedPostsFunc: func(ctx context.Context, req *models.GetRelatedPostsRequest) ([]*models.RelatedPost, error) {
+			if req.PostId != "p1" || req.Limit != 3 {
+				t.Errorf("unexpected related posts request: %+v", req)
+			}
+			return []*models.RelatedPost{
+				{Post: &models.BlogPost{PostId: "p2", Title: "Close"}, Score: 0.8},
+				{Post: &models.BlogPost{PostId: "p3", Title: "Further"}, Score: 0.2},
+			}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.GetRelatedPosts(context.Background(), &pb.GetRelatedPostsRequest{PostId: "p1", Limit: 3})
+	if err != nil || !resp.Success || len(resp.Posts) != 2 ||
+		resp.Posts[0].GetPost().GetPostId() != "p2" || resp.Posts[0].GetScore() != 0.8 {
+		t.Errorf("expected two related posts, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestGetRelatedPosts_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	tests := []struct {
+		req  *pb.GetRelatedPostsRequest
+		want error
+	}{
+		{&pb.GetRelatedPostsRequest{}, models.ErrInvalidPostID},
+		{&pb.GetRelatedPostsRequest{PostId: "p1", Limit: -1}, models.ErrInvalidLimit},
+	}
+	for _, tt := range tests {
+		if resp, err := server.GetRelatedPosts(context.Background(), tt.req); !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("expected %v, got: %v, resp: %+v", tt.want, err, resp)
+		}
+	}
+}
+
+func TestExportBlogPosts_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		ExportPostsFunc: func(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error {
+			if req.Filter != `status = "draft"` || !req.ShowDeleted {
+				t.Errorf("unexpected export request: %+v", req)
+			}
+			for _, id := range []string{"p1", "p2"} {
+				if err := fn(&models.BlogPost{PostId: id, Status: models.StatusDraft}, 7); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	stream := &mockExportStream{}
+	if err := server.ExportBlogPosts(&pb.ExportBlogPostsRequest{Filter: `status = "draft"`, ShowDeleted: true}, stream); err != nil {
+		t.Fatalf("ExportBlogPosts failed: %v", err)
+	}
+	if len(stream.sent) != 2 || stream.sent[1].GetPost().GetPostId() != "p2" || stream.sent[1].GetSequence() != 7 ||
+		stream.sent[0].GetPost().GetStatus() != pb.PostStatus_POST_STATUS_DRAFT {
+		t.Errorf("expected both posts at sequence 7, got %+v", stream.sent)
+	}
+}
+
+func TestExportBlogPosts_InvalidFilter(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		ExportPostsFunc: func(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error {
+			_, err := query.Parse(req.Filter)
+			return err
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	err := server.ExportBlogPosts(&pb.ExportBlogPostsRequest{Filter: "title ="}, &mockExportStream{})
+	var parseErr *query.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("expected a parse error, got: %v", err)
+	}
+}
+
+func TestWatchBlogPosts_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		WatchPostsFunc: func(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+			if req.AuthorId != "a1" || req.Tag != "go" || req.SinceSequence != 41 {
+				t.Errorf("unexpected watch request: %+v", req)
+			}
+			post := &models.BlogPost{PostId: "p1", Title: "Title"}
+			for _, event := range []*models.PostEvent{
+				{Sequence: 42, Type: models.EventCreated, PostId: "p1", Post: post},
+				{Sequence: 43, Type: models.EventDeleted, PostId: "p1", Post: post, Previous: post},
+			} {
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	stream := &mockWatchStream{ctx: context.Background()}
+	err := server.WatchBlogPosts(&pb.WatchBlogPostsRequest{AuthorId: "a1", Tag: " Go ", SinceSequence: 41}, stream)
+	if err != nil || len(stream.events) != 2 {
+		t.Fatalf("expected two events, got error: %v, events: %+v", err, stream.events)
+	}
+	created, deleted := stream.events[0], stream.events[1]
+	if created.GetSequence() != 42 || created.GetType() != pb.EventType_EVENT_TYPE_CREATED || created.GetPost().GetTitle() != "Title" || created.GetPrevious() != nil {
+		t.Errorf("unexpected created event: %+v", created)
+	}
+	if deleted.GetSequence() != 43 || deleted.GetType() != pb.EventType_EVENT_TYPE_DELETED || deleted.GetPostId() != "p1" {
+		t.Errorf("unexpected deleted event: %+v", deleted)
+	}
+}
+
+func TestWatchBlogPosts_StopWatches(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		WatchPostsFunc: func(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	server.StopWatches()
+	err := server.WatchBlogPosts(&pb.WatchBlogPostsRequest{}, &mockWatchStream{ctx: context.Background()})
+	if !errors.Is(err, models.ErrShuttingDown) {
+		t.Errorf("expected ErrShuttingDown, got: %v", err)
+	}
+}
+
 func TestModelToProtobuf(t *testing.T) {
 	server := NewBlogServiceServer(nil)
 	now := time.Now()
@@ -187,3 +1160,142 @@ func TestModelToProtobuf(t *testing.T) {
 		t.Errorf("PublicationDate not mapped correctly")
 	}
 }
+
+func TestListPostRevisions_Success(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		ListRevisionsFunc: func(ctx context.Context, postID string) ([]*models.PostRevision, error) {
+			return []*models.PostRevision{
+				{PostId: postID, Revision: 2, Title: "Second", Editor: "Editor"},
+				{PostId: postID, Revision: 1, Title: "First", Editor: "Author"},
+			}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.ListPostRevisions(context.Background(), &pb.ListPostRevisionsRequest{PostId: "123"})
+	if err != nil || !resp.Success {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if len(resp.Revisions) != 2 || resp.Revisions[0].GetRevision() != 2 || resp.Revisions[0].GetEditor() != "Editor" {
+		t.Errorf("unexpected revisions: %+v", resp.Revisions)
+	}
+}
+
+func TestGetPostRevision_InvalidRevision(t *testing.T) {
+	server := NewBlogServiceServer(&mockBlogStorage{})
+	resp, err := server.GetPostRevision(context.Background(), &pb.GetPostRevisionRequest{PostId: "123"})
+	if !errors.Is(err, models.ErrInvalidRevision) || resp.Success {
+		t.Errorf("expected ErrInvalidRevision, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestRestorePostRevision_Success(t *testing.T) {
+	var got *models.UpdateBlogPostRequest
+	mockStorage := &mockBlogStorage{
+		GetRevisionFunc: func(ctx context.Context, postID string, revision int64) (*models.PostRevision, error) {
+			return &models.PostRevision{PostId: postID, Revision: revision, Title: "Old", Content: "Old content"}, nil
+		},
+		UpdatePostFunc: func(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
+			got = req
+			return &models.BlogPost{PostId: req.PostId, Title: req.Title, Content: req.Content, Tags: req.Tags, Version: 5}, nil
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	req := &pb.RestorePostRevisionRequest{PostId: "123", Revision: 2, ExpectedVersion: 4, Editor: "Editor"}
+	resp, err := server.RestorePostRevision(context.Background(), req)
+	if err != nil || !resp.Success || resp.GetPost().GetTitle() != "Old" {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	// The restore must set every editable field, clearing tags the old
+	// revision did not have, and keep the version check and editor
+	if fmt.Sprint(got.UpdateMask) != "[title content tags]" || got.ExpectedVersion != 4 || got.Editor != "Editor" {
+		t.Errorf("unexpected update request: %+v", got)
+	}
+}
+
+func TestRestorePostRevision_NotFound(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		GetRevisionFunc: func(ctx context.Context, postID string, revision int64) (*models.PostRevision, error) {
+			return nil, models.ErrRevisionNotFound
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	resp, err := server.RestorePostRevision(context.Background(), &pb.RestorePostRevisionRequest{PostId: "123", Revision: 9})
+	if !errors.Is(err, models.ErrRevisionNotFound) || resp.Success {
+		t.Errorf("expected ErrRevisionNotFound, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func diffStorage() *mockBlogStorage {
+	revisions := map[int64]*models.PostRevision{
+		1: {PostId: "123", Revision: 1, Title: "Hello", Content: "one\ntwo\n", Tags: []string{"go", "grpc"}},
+		2: {PostId: "123", Revision: 2, Title: "Hello world", Content: "one\n2\n", Tags: []string{"go", "rust"}},
+	}
+	return &mockBlogStorage{
+		GetRevisionFunc: func(ctx context.Context, postID string, revision int64) (*models.PostRevision, error) {
+			if r, ok := revisions[revision]; ok {
+				return r, nil
+			}
+			return nil, models.ErrRevisionNotFound
+		},
+		GetPostFunc: func(ctx context.Context, postID string) (*models.BlogPost, error) {
+			r := revisions[2]
+			return &models.BlogPost{PostId: postID, Title: r.Title, Content: r.Content, Tags: r.Tags, Version: 2}, nil
+		},
+	}
+}
+
+func TestDiffPostRevisions_Unified(t *testing.T) {
+	server := NewBlogServiceServer(diffStorage())
+	// Revision 0 compares against the current state of the post
+	req := &pb.DiffPostRevisionsRequest{PostId: "123", FromRevision: 1, ContextLines: proto.Int32(0)}
+	resp, err := server.DiffPostRevisions(context.Background(), req)
+	if err != nil || !resp.Success {
+		t.Fatalf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+	if resp.GetToRevision() != 2 {
+		t.Errorf("expected current to resolve to revision 2, got %d", resp.GetToRevision())
+	}
+	wantContent := "--- content@1\n+++ content@2\n@@ -2 +2 @@\n-two\n+2\n"
+	if !resp.GetContent().GetChanged() || resp.GetContent().GetUnified() != wantContent {
+		t.Errorf("unexpected content diff:\n%s", resp.GetContent().GetUnified())
+	}
+	tags := resp.GetTags()
+	if !tags.GetChanged() || fmt.Sprint(tags.GetAdded()) != "[rust]" || fmt.Sprint(tags.GetRemoved()) != "[grpc]" {
+		t.Errorf("unexpected tags diff: %+v", tags)
+	}
+}
+
+func TestDiffPostRevisions_Words(t *testing.T) {
+	server := NewBlogServiceServer(diffStorage())
+	req := &pb.DiffPostRevisionsRequest{PostId: "123", FromRevision: 1, ToRevision: 2, Format: pb.DiffFormat_DIFF_FORMAT_WORDS}
+	resp, err := server.DiffPostRevisions(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	spans := resp.GetTitle().GetSpans()
+	if len(spans) != 2 || spans[0].GetText() != "Hello" || spans[1].GetOp() != pb.DiffOp_DIFF_OP_INSERT || spans[1].GetText() != " world" {
+		t.Errorf("unexpected title spans: %+v", spans)
+	}
+	if resp.GetTitle().GetUnified() != "" {
+		t.Errorf("word diffs must not include a unified diff")
+	}
+}
+
+func TestDiffPostRevisions_InvalidRequest(t *testing.T) {
+	server := NewBlogServiceServer(diffStorage())
+	tests := []struct {
+		req  *pb.DiffPostRevisionsRequest
+		want error
+	}{
+		{&pb.DiffPostRevisionsRequest{FromRevision: 1}, models.ErrInvalidPostID},
+		{&pb.DiffPostRevisionsRequest{PostId: "123", FromRevision: -1}, models.ErrInvalidRevision},
+		{&pb.DiffPostRevisionsRequest{PostId: "123", Format: 7}, models.ErrInvalidDiffFormat},
+		{&pb.DiffPostRevisionsRequest{PostId: "123", ContextLines: proto.Int32(-1)}, models.ErrInvalidContext},
+		{&pb.DiffPostRevisionsRequest{PostId: "123", FromRevision: 9}, models.ErrRevisionNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := server.DiffPostRevisions(context.Background(), tt.req); !errors.Is(err, tt.want) {
+			t.Errorf("%+v: expected %v, got: %v", tt.req, tt.want, err)
+		}
+	}
+}
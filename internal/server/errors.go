@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	models "github.com/pandae7/go-blogger/internal/models"
+	query "github.com/pandae7/go-blogger/internal/query"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is reported in the google.rpc.ErrorInfo of every error returned
+// by the service.
+const ErrorDomain = "blog.v1"
+
+// errorMapping describes how a storage or validation error is reported to
+// clients: its status code, a machine-readable reason and, for invalid
+// arguments, the request field at fault.
+type errorMapping struct {
+	err    error
+	code   codes.Code
+	reason string
+	field  string
+}
+
+// errorMappings covers every sentinel in models. Lookups use errors.Is, so
+// wrapped errors are translated as well.
+var errorMappings = []errorMapping{
+	{models.ErrPostNotFound, codes.NotFound, "POST_NOT_FOUND", ""},
+	{models.ErrRevisionNotFound, codes.NotFound, "REVISION_NOT_FOUND", ""},
+	{models.ErrAuthorNotFound, codes.NotFound, "AUTHOR_NOT_FOUND", ""},
+	{models.ErrTagNotFound, codes.NotFound, "TAG_NOT_FOUND", ""},
+	{models.ErrInvalidPostID, codes.InvalidArgument, "INVALID_POST_ID", "post_id"},
+	{models.ErrInvalidAuthorID, codes.InvalidArgument, "INVALID_AUTHOR_ID", "author_id"},
+	{models.ErrInvalidTagID, codes.InvalidArgument, "INVALID_TAG_ID", "tag_id"},
+	{models.ErrEmptyTitle, codes.InvalidArgument, "EMPTY_TITLE", "title"},
+	{models.ErrEmptyContent, codes.InvalidArgument, "EMPTY_CONTENT", "content"},
+	{models.ErrEmptyAuthor, codes.InvalidArgument, "EMPTY_AUTHOR", "author"},
+	{models.ErrDuplicatePost, codes.AlreadyExists, "DUPLICATE_POST", ""},
+	{models.ErrPostNotTrashed, codes.FailedPrecondition, "POST_NOT_TRASHED", ""},
+	{models.ErrInvalidPageSize, codes.InvalidArgument, "INVALID_PAGE_SIZE", "page_size"},
+	{models.ErrInvalidPageToken, codes.InvalidArgument, "INVALID_PAGE_TOKEN", "page_token"},
+	{models.ErrEmptyUpdate, codes.InvalidArgument, "EMPTY_UPDATE", ""},
+	{models.ErrInvalidVersion, codes.InvalidArgument, "INVALID_VERSION", "expected_version"},
+	{models.ErrVersionMismatch, codes.Aborted, "VERSION_MISMATCH", ""},
+	{models.ErrInvalidUpdateMask, codes.InvalidArgument, "INVALID_UPDATE_MASK", "update_mask"},
+	{models.ErrImmutableField, codes.InvalidArgument, "IMMUTABLE_FIELD", "update_mask"},
+	{models.ErrInvalidRevision, codes.InvalidArgument, "INVALID_REVISION", "revision"},
+	{models.ErrInvalidDiffFormat, codes.InvalidArgument, "INVALID_DIFF_FORMAT", "format"},
+	{models.ErrInvalidContext, codes.InvalidArgument, "INVALID_CONTEXT_LINES", "context_lines"},
+	{models.ErrInvalidStatus, codes.InvalidArgument, "INVALID_STATUS", "status"},
+	{models.ErrInvalidTransition, codes.FailedPrecondition, "INVALID_STATUS_TRANSITION", ""},
+	{models.ErrPublishInFuture, codes.InvalidArgument, "PUBLISH_IN_FUTURE", "publication_date"},
+	{models.ErrPublishInPast, codes.InvalidArgument, "PUBLISH_IN_PAST", "publication_date"},
+	{models.ErrEmptyAuthorName, codes.InvalidArgument, "EMPTY_AUTHOR_NAME", "name"},
+	{models.ErrEmptyProfileName, codes.InvalidArgument, "EMPTY_PROFILE_NAME", "profile_name"},
+	{models.ErrInvalidEmail, codes.InvalidArgument, "INVALID_EMAIL", "email"},
+	{models.ErrEmptyAuthorUpdate, codes.InvalidArgument, "EMPTY_AUTHOR_UPDATE", ""},
+	{models.ErrDuplicateAuthor, codes.AlreadyExists, "DUPLICATE_AUTHOR", ""},
+	{models.ErrAuthorHasPosts, codes.FailedPrecondition, "AUTHOR_HAS_POSTS", ""},
+	{models.ErrEmptyTagName, codes.InvalidArgument, "EMPTY_TAG_NAME", "name"},
+	{models.ErrEmptyTag, codes.InvalidArgument, "EMPTY_TAG", "tag"},
+	{models.ErrEmptyQuery, codes.InvalidArgument, "EMPTY_QUERY", "query"},
+	{models.ErrEmptyTagUpdate, codes.InvalidArgument, "EMPTY_TAG_UPDATE", ""},
+	{models.ErrDuplicateTag, codes.AlreadyExists, "DUPLICATE_TAG", ""},
+	{models.ErrMergeSameTag, codes.InvalidArgument, "MERGE_SAME_TAG", "target_tag_id"},
+	{models.ErrInvalidLimit, codes.InvalidArgument, "INVALID_LIMIT", "limit"},
+	{models.ErrSequenceExpired, codes.OutOfRange, "SEQUENCE_EXPIRED", "since_sequence"},
+	{models.ErrShuttingDown, codes.Unavailable, "SHUTTING_DOWN", ""},
+	{models.ErrImportTooLarge, codes.ResourceExhausted, "IMPORT_TOO_LARGE", ""},
+	{models.ErrEmptyBatch, codes.InvalidArgument, "EMPTY_BATCH", "post_ids"},
+	{models.ErrBatchTooLarge, codes.InvalidArgument, "BATCH_TOO_LARGE", "post_ids"},
+	{models.ErrInvalidRequestID, codes.InvalidArgument, "INVALID_REQUEST_ID", "request_id"},
+	{models.ErrRequestIDReused, codes.InvalidArgument, "REQUEST_ID_REUSED", "request_id"},
+}
+
+// errorMetadata holds the ErrorInfo metadata of the sentinels that come with
+// more than a reason, such as the limit a request exceeded.
+var errorMetadata = map[error]map[string]string{
+	models.ErrImportTooLarge: {"limit": strconv.Itoa(maxAtomicImport)},
+}
+
+// toStatusError converts an error returned by a handler into a gRPC status
+// error with google.rpc.ErrorInfo and, for invalid arguments,
+// google.rpc.BadRequest details. Errors that already carry a status are
+// returned unchanged.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var (
+		parseErr  *query.ParseError
+		policyErr *tagpolicy.PolicyError
+	)
+	switch {
+	case errors.As(err, &parseErr):
+		return newStatusError(codes.InvalidArgument, err.Error(), "INVALID_EXPRESSION", parseErr.Field,
+			map[string]string{"position": strconv.Itoa(parseErr.Pos)})
+	case errors.As(err, &policyErr):
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(policyErr.Violations))
+		for _, v := range policyErr.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+				Reason:      v.Reason,
+			})
+		}
+		return withDetails(status.New(codes.InvalidArgument, err.Error()), "INVALID_TAGS", nil, violations)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return newStatusError(m.code, err.Error(), m.reason, m.field, errorMetadata[m.err])
+		}
+	}
+
+	log.Errorf("Unmapped error: %v", err)
+	return newStatusError(codes.Internal, err.Error(), "INTERNAL", "", nil)
+}
+
+// errorReason returns the ErrorInfo reason clients would see for err.
+func errorReason(err error) string {
+	for _, detail := range status.Convert(toStatusError(err)).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func newStatusError(code codes.Code, msg, reason, field string, metadata map[string]string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if code == codes.InvalidArgument && field != "" {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: msg,
+			Reason:      reason,
+		})
+	}
+	return withDetails(status.New(code, msg), reason, metadata, violations)
+}
+
+// withDetails attaches the ErrorInfo and, when there are field violations, a
+// BadRequest to the status.
+func withDetails(st *status.Status, reason string, metadata map[string]string, violations []*errdetails.BadRequest_FieldViolation) error {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}}
+	if len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// UnaryErrorInterceptor translates the errors returned by unary handlers into
+// gRPC status errors, so clients see proper codes and can branch on the
+// ErrorInfo reason instead of matching message strings.
+func UnaryErrorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, toStatusError(err)
+	}
+	return resp, nil
+}
+
+// StreamErrorInterceptor is UnaryErrorInterceptor for streaming handlers.
+func StreamErrorInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return toStatusError(handler(srv, ss))
+}
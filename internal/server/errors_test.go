@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	models "github.com/pandae7/go-blogger/internal/models"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
+	pb "github.com/pandae7/go-blogger/proto/blog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("no ErrorInfo in error: %v", err)
+	return nil
+}
+
+func TestToStatusError_Sentinels(t *testing.T) {
+	for _, m := range errorMappings {
+		err := toStatusError(fmt.Errorf("wrapped: %w", m.err))
+		if status.Code(err) != m.code {
+			t.Errorf("%v: expected code %v, got %v", m.err, m.code, status.Code(err))
+		}
+		info := errorInfo(t, err)
+		if info.GetReason() != m.reason || info.GetDomain() != ErrorDomain {
+			t.Errorf("%v: unexpected ErrorInfo: %+v", m.err, info)
+		}
+	}
+}
+
+func TestToStatusError_BadRequest(t *testing.T) {
+	err := toStatusError(models.ErrEmptyTitle)
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			violations = br.GetFieldViolations()
+		}
+	}
+	if len(violations) != 1 || violations[0].GetField() != "title" {
+		t.Errorf("expected a field violation for title, got: %+v", violations)
+	}
+}
+
+func TestToStatusError_TagPolicy(t *testing.T) {
+	err := toStatusError(&tagpolicy.PolicyError{Violations: []tagpolicy.Violation{
+		{Field: "tags[0]", Reason: tagpolicy.ReasonEmptyTag, Description: "tag cannot be empty"},
+		{Field: "tags", Reason: tagpolicy.ReasonTooManyTags, Description: "too many tags"},
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got: %v", err)
+	}
+	if reason := errorInfo(t, err).GetReason(); reason != "INVALID_TAGS" {
+		t.Errorf("expected INVALID_TAGS reason, got %q", reason)
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			violations = br.GetFieldViolations()
+		}
+	}
+	if len(violations) != 2 || violations[0].GetField() != "tags[0]" || violations[0].GetReason() != "EMPTY_TAG" || violations[1].GetField() != "tags" {
+		t.Errorf("expected one field violation per tag violation, got: %+v", violations)
+	}
+}
+
+func TestToStatusError_ImportTooLarge(t *testing.T) {
+	err := toStatusError(models.ErrImportTooLarge)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got: %v", err)
+	}
+	if limit := errorInfo(t, err).GetMetadata()["limit"]; limit != "10000" {
+		t.Errorf("expected the import limit 10000, got %q", limit)
+	}
+}
+
+func TestToStatusError_Passthrough(t *testing.T) {
+	original := status.Error(codes.PermissionDenied, "nope")
+	if err := toStatusError(original); err != original {
+		t.Errorf("expected status errors to pass through, got: %v", err)
+	}
+	if code := status.Code(toStatusError(context.Canceled)); code != codes.Canceled {
+		t.Errorf("expected Canceled, got %v", code)
+	}
+	if code := status.Code(toStatusError(errors.New("boom"))); code != codes.Internal {
+		t.Errorf("expected Internal for unknown errors, got %v", code)
+	}
+}
+
+func TestUnaryErrorInterceptor(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		GetPostFunc: func(ctx context.Context, postID string) (*models.BlogPost, error) {
+			return nil, models.ErrPostNotFound
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	handler := func(ctx context.Context, req any) (any, error) {
+		return server.GetBlogPost(ctx, req.(*pb.GetBlogPostRequest))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: pb.BlogService_GetBlogPost_FullMethodName}
+
+	_, err := UnaryErrorInterceptor(context.Background(), &pb.GetBlogPostRequest{PostId: "missing"}, info, handler)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got: %v", err)
+	}
+	if reason := errorInfo(t, err).GetReason(); reason != "POST_NOT_FOUND" {
+		t.Errorf("expected POST_NOT_FOUND reason, got %q", reason)
+	}
+
+	_, err = UnaryErrorInterceptor(context.Background(), &pb.ListBlogPostsRequest{Filter: "title ="}, info,
+		func(ctx context.Context, req any) (any, error) {
+			return server.ListBlogPosts(ctx, req.(*pb.ListBlogPostsRequest))
+		})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got: %v", err)
+	}
+	if pos := errorInfo(t, err).GetMetadata()["position"]; pos != "8" {
+		t.Errorf("expected parse error position 8, got %q", pos)
+	}
+}
+
+func TestStreamErrorInterceptor(t *testing.T) {
+	mockStorage := &mockBlogStorage{
+		WatchPostsFunc: func(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+			return models.ErrSequenceExpired
+		},
+	}
+	server := NewBlogServiceServer(mockStorage)
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return server.WatchBlogPosts(&pb.WatchBlogPostsRequest{SinceSequence: 1}, ss.(*mockWatchStream))
+	}
+	info := &grpc.StreamServerInfo{FullMethod: pb.BlogService_WatchBlogPosts_FullMethodName, IsServerStream: true}
+
+	err := StreamErrorInterceptor(server, &mockWatchStream{ctx: context.Background()}, info, handler)
+	if status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected OutOfRange, got: %v", err)
+	}
+	if reason := errorInfo(t, err).GetReason(); reason != "SEQUENCE_EXPIRED" {
+		t.Errorf("expected SEQUENCE_EXPIRED reason, got %q", reason)
+	}
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	models "github.com/pandae7/go-blogger/internal/models"
+	storage "github.com/pandae7/go-blogger/internal/storage"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
+	pb "github.com/pandae7/go-blogger/proto/blog"
+	log "github.com/sirupsen/logrus"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type TagServiceServer struct {
+	pb.UnimplementedTagServiceServer
+	storage   storage.TagStorage
+	tagPolicy tagpolicy.Policy
+}
+
+func NewTagServiceServer(storage storage.TagStorage) *TagServiceServer {
+	return &TagServiceServer{
+		storage:   storage,
+		tagPolicy: tagpolicy.Default(),
+	}
+}
+
+// SetTagPolicy replaces the policy used to normalize new tag names and the
+// tags in suggest requests, tagpolicy.Default() unless set. It should match
+// the policy of the blog service, and must be called before the server starts
+// serving.
+func (s *TagServiceServer) SetTagPolicy(policy tagpolicy.Policy) {
+	s.tagPolicy = policy
+}
+
+func (s *TagServiceServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	log.Infof("Listing tags with page size: %d", req.GetPageSize())
+
+	if req.GetPageSize() < 0 {
+		return &pb.ListTagsResponse{
+			Success: false,
+			Message: models.ErrInvalidPageSize.Error(),
+		}, models.ErrInvalidPageSize
+	}
+
+	tags, nextPageToken, err := s.storage.ListTags(ctx, &models.ListTagsRequest{
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return &pb.ListTagsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbTags := make([]*pb.Tag, 0, len(tags))
+	for _, tag := range tags {
+		pbTags = append(pbTags, tagToProtobuf(tag))
+	}
+
+	return &pb.ListTagsResponse{
+		Tags:          pbTags,
+		NextPageToken: nextPageToken,
+		Success:       true,
+		Message:       "Tags listed successfully",
+	}, nil
+}
+
+func (s *TagServiceServer) GetTag(ctx context.Context, req *pb.GetTagRequest) (*pb.GetTagResponse, error) {
+	log.Infof("Fetching tag with ID: %s", req.GetTagId())
+
+	if req.GetTagId() == "" {
+		return &pb.GetTagResponse{
+			Success: false,
+			Message: models.ErrInvalidTagID.Error(),
+		}, models.ErrInvalidTagID
+	}
+
+	tag, err := s.storage.GetTag(ctx, req.GetTagId())
+	if err != nil {
+		return &pb.GetTagResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	return &pb.GetTagResponse{
+		Tag:     tagToProtobuf(tag),
+		Success: true,
+		Message: "Tag fetched successfully",
+	}, nil
+}
+
+func (s *TagServiceServer) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.RenameTagResponse, error) {
+	log.Infof("Renaming tag with ID: %s", req.GetTagId())
+
+	if err := s.validateRenameTagRequest(req); err != nil {
+		log.Errorf("Invalid request: %v", err)
+		return &pb.RenameTagResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	// The request was validated, so the name normalizes cleanly
+	var name string
+	if req.GetName() != "" {
+		name, _ = s.normalizeTagName(req.GetName())
+	}
+	tag, err := s.storage.RenameTag(ctx, &models.RenameTagRequest{
+		TagId:       req.GetTagId(),
+		Name:        name,
+		Description: req.Description,
+	})
+	if err != nil {
+		log.Errorf("Failed to rename tag: %v", err)
+		return &pb.RenameTagResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Tag renamed successfully to: %s", tag.Name)
+	return &pb.RenameTagResponse{
+		Tag:     tagToProtobuf(tag),
+		Success: true,
+		Message: "Tag renamed successfully",
+	}, nil
+}
+
+func (s *TagServiceServer) MergeTags(ctx context.Context, req *pb.MergeTagsRequest) (*pb.MergeTagsResponse, error) {
+	log.Infof("Merging tag %s into tag %s", req.GetSourceTagId(), req.GetTargetTagId())
+
+	if err := s.validateMergeTagsRequest(req); err != nil {
+		log.Errorf("Invalid request: %v", err)
+		return &pb.MergeTagsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	tag, err := s.storage.MergeTags(ctx, &models.MergeTagsRequest{
+		SourceTagId: req.GetSourceTagId(),
+		TargetTagId: req.GetTargetTagId(),
+	})
+	if err != nil {
+		log.Errorf("Failed to merge tags: %v", err)
+		return &pb.MergeTagsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	log.Infof("Tags merged successfully into: %s", tag.Name)
+	return &pb.MergeTagsResponse{
+		Tag:     tagToProtobuf(tag),
+		Success: true,
+		Message: "Tags merged successfully",
+	}, nil
+}
+
+func (s *TagServiceServer) SuggestTags(ctx context.Context, req *pb.SuggestTagsRequest) (*pb.SuggestTagsResponse, error) {
+	log.Infof("Suggesting tags for prefix: %q", req.GetPrefix())
+
+	if req.GetLimit() < 0 {
+		return &pb.SuggestTagsResponse{
+			Success: false,
+			Message: models.ErrInvalidLimit.Error(),
+		}, models.ErrInvalidLimit
+	}
+
+	// Match the prefix and the draft's tags against tags as they were
+	// stored. Tags the policy rejects cannot be on any post, so they are
+	// dropped from the draft. A prefix the policy rejects is used as given:
+	// an empty one matches every tag.
+	prefix := req.GetPrefix()
+	if normalized, err := s.tagPolicy.Normalize([]string{prefix}); err == nil {
+		prefix = normalized[0]
+	}
+	var tags []string
+	for _, tag := range req.GetTags() {
+		if normalized, err := s.tagPolicy.Normalize([]string{tag}); err == nil {
+			tags = append(tags, normalized[0])
+		}
+	}
+
+	suggestions, err := s.storage.SuggestTags(ctx, &models.SuggestTagsRequest{
+		Prefix:  prefix,
+		Title:   req.GetTitle(),
+		Content: req.GetContent(),
+		Tags:    tags,
+		Limit:   int(req.GetLimit()),
+	})
+	if err != nil {
+		return &pb.SuggestTagsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	pbSuggestions := make([]*pb.TagSuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		pbSuggestions = append(pbSuggestions, &pb.TagSuggestion{
+			Tag:   tagToProtobuf(suggestion.Tag),
+			Score: suggestion.Score,
+		})
+	}
+
+	return &pb.SuggestTagsResponse{
+		Suggestions: pbSuggestions,
+		Success:     true,
+		Message:     "Tags suggested successfully",
+	}, nil
+}
+
+func (s *TagServiceServer) validateRenameTagRequest(req *pb.RenameTagRequest) error {
+	if req.GetTagId() == "" {
+		return models.ErrInvalidTagID
+	}
+	if req.GetName() == "" && req.Description == nil {
+		return models.ErrEmptyTagUpdate
+	}
+	if req.GetName() == "" {
+		return nil
+	}
+	if strings.TrimSpace(req.GetName()) == "" {
+		return models.ErrEmptyTagName
+	}
+	_, err := s.normalizeTagName(req.GetName())
+	return err
+}
+
+// normalizeTagName runs a new tag name through the tag policy, so that the
+// renamed tag is the one posts and lookups normalize to. Violations are
+// reported against the name field.
+func (s *TagServiceServer) normalizeTagName(name string) (string, error) {
+	normalized, err := s.tagPolicy.Normalize([]string{name})
+	var policyErr *tagpolicy.PolicyError
+	if errors.As(err, &policyErr) {
+		for i := range policyErr.Violations {
+			policyErr.Violations[i].Field = "name"
+		}
+		return "", policyErr
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(normalized) != 1 {
+		return "", models.ErrEmptyTagName
+	}
+	return normalized[0], nil
+}
+
+func (s *TagServiceServer) validateMergeTagsRequest(req *pb.MergeTagsRequest) error {
+	if req.GetSourceTagId() == "" || req.GetTargetTagId() == "" {
+		return models.ErrInvalidTagID
+	}
+	if req.GetSourceTagId() == req.GetTargetTagId() {
+		return models.ErrMergeSameTag
+	}
+	return nil
+}
+
+func tagToProtobuf(tag *models.Tag) *pb.Tag {
+	return &pb.Tag{
+		TagId:       tag.TagId,
+		Name:        tag.Name,
+		Slug:        tag.Slug,
+		Description: tag.Description,
+		UsageCount:  int32(tag.UsageCount),
+		CreatedAt:   timestamppb.New(tag.CreatedAt),
+		UpdatedAt:   timestamppb.New(tag.UpdatedAt),
+	}
+}
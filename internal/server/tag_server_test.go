@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	models "github.com/pandae7/go-blogger/internal/models"
+	storage "github.com/pandae7/go-blogger/internal/storage"
+	tagpolicy "github.com/pandae7/go-blogger/internal/tagpolicy"
+	pb "github.com/pandae7/go-blogger/proto/blog"
+)
+
+// mockTagStorage is a mock implementation of the TagStorage interface
+type mockTagStorage struct {
+	ListTagsFunc  func(ctx context.Context, req *models.ListTagsRequest) ([]*models.Tag, string, error)
+	GetTagFunc    func(ctx context.Context, tagID string) (*models.Tag, error)
+	RenameTagFunc func(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error)
+	MergeTagsFunc func(ctx context.Context, req *models.MergeTagsRequest) (*models.Tag, error)
+
+	SuggestTagsFunc func(ctx context.Context, req *models.SuggestTagsRequest) ([]*models.TagSuggestion, error)
+}
+
+func (m *mockTagStorage) ListTags(ctx context.Context, req *models.ListTagsRequest) ([]*models.Tag, string, error) {
+	return m.ListTagsFunc(ctx, req)
+}
+func (m *mockTagStorage) GetTag(ctx context.Context, tagID string) (*models.Tag, error) {
+	return m.GetTagFunc(ctx, tagID)
+}
+func (m *mockTagStorage) RenameTag(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error) {
+	return m.RenameTagFunc(ctx, req)
+}
+func (m *mockTagStorage) MergeTags(ctx context.Context, req *models.MergeTagsRequest) (*models.Tag, error) {
+	return m.MergeTagsFunc(ctx, req)
+}
+func (m *mockTagStorage) SuggestTags(ctx context.Context, req *models.SuggestTagsRequest) ([]*models.TagSuggestion, error) {
+	return m.SuggestTagsFunc(ctx, req)
+}
+
+func TestListTags_Success(t *testing.T) {
+	mockStorage := &mockTagStorage{
+		ListTagsFunc: func(ctx context.Context, req *models.ListTagsRequest) ([]*models.Tag, string, error) {
+			if req.PageSize != 2 || req.PageToken != "token" {
+				t.Errorf("unexpected list request: %+v", req)
+			}
+			return []*models.Tag{{TagId: "t1", Name: "go", UsageCount: 3}, {TagId: "t2", Name: "grpc"}}, "next", nil
+		},
+	}
+	server := NewTagServiceServer(mockStorage)
+	resp, err := server.ListTags(context.Background(), &pb.ListTagsRequest{PageSize: 2, PageToken: "token"})
+	if err != nil || !resp.Success || len(resp.Tags) != 2 || resp.Tags[0].UsageCount != 3 || resp.NextPageToken != "next" {
+		t.Errorf("expected two tags and a next page token, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestGetTag_NotFound(t *testing.T) {
+	mockStorage := &mockTagStorage{
+		GetTagFunc: func(ctx context.Context, tagID string) (*models.Tag, error) {
+			return nil, models.ErrTagNotFound
+		},
+	}
+	server := NewTagServiceServer(mockStorage)
+	resp, err := server.GetTag(context.Background(), &pb.GetTagRequest{TagId: "missing"})
+	if !errors.Is(err, models.ErrTagNotFound) || resp.Success {
+		t.Errorf("expected ErrTagNotFound, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestRenameTag_Success(t *testing.T) {
+	mockStorage := &mockTagStorage{
+		RenameTagFunc: func(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error) {
+			if req.TagId != "t1" || req.Name != "go" || req.Description != nil {
+				t.Errorf("unexpected rename request: %+v", req)
+			}
+			return &models.Tag{TagId: req.TagId, Name: req.Name, Slug: "go"}, nil
+		},
+	}
+	server := NewTagServiceServer(mockStorage)
+	resp, err := server.RenameTag(context.Background(), &pb.RenameTagRequest{TagId: "t1", Name: " go "})
+	if err != nil || !resp.Success || resp.GetTag().GetSlug() != "go" {
+		t.Errorf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestRenameTag_InvalidRequest(t *testing.T) {
+	server := NewTagServiceServer(&mockTagStorage{})
+	tests := []struct {
+		req  *pb.RenameTagRequest
+		want error
+	}{
+		{&pb.RenameTagRequest{Name: "go"}, models.ErrInvalidTagID},
+		{&pb.RenameTagRequest{TagId: "t1"}, models.ErrEmptyTagUpdate},
+		{&pb.RenameTagRequest{TagId: "t1", Name: "  "}, models.ErrEmptyTagName},
+	}
+	for _, tt := range tests {
+		resp, err := server.RenameTag(context.Background(), tt.req)
+		if !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("RenameTag(%+v): expected %v, got: %v", tt.req, tt.want, err)
+		}
+	}
+}
+
+func TestRenameTag_PolicyViolation(t *testing.T) {
+	server := NewTagServiceServer(&mockTagStorage{})
+	resp, err := server.RenameTag(context.Background(), &pb.RenameTagRequest{TagId: "t1", Name: strings.Repeat("x", tagpolicy.DefaultMaxLength+1)})
+	var policyErr *tagpolicy.PolicyError
+	if !errors.As(err, &policyErr) || resp.Success {
+		t.Fatalf("expected a PolicyError, got: %v, resp: %+v", err, resp)
+	}
+	if v := policyErr.Violations; len(v) != 1 || v[0].Field != "name" || v[0].Reason != tagpolicy.ReasonTagTooLong {
+		t.Errorf("expected a TAG_TOO_LONG violation on name, got: %+v", v)
+	}
+}
+
+func TestRenameTag_NormalizesName(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewBlogStorage()
+	blogServer := NewBlogServiceServer(s)
+	tagServer := NewTagServiceServer(s)
+	if err := s.CreateAuthor(ctx, &models.Author{AuthorId: "a1", Name: "A", ProfileName: "a"}); err != nil {
+		t.Fatalf("CreateAuthor failed: %v", err)
+	}
+	if _, err := blogServer.CreateBlogPost(ctx, &pb.CreateBlogPostRequest{Title: "t", Content: "c", AuthorId: "a1", Tags: []string{"golang"}}); err != nil {
+		t.Fatalf("CreateBlogPost failed: %v", err)
+	}
+	tags, err := tagServer.ListTags(ctx, &pb.ListTagsRequest{})
+	if err != nil || len(tags.Tags) != 1 {
+		t.Fatalf("expected one tag, got %+v, %v", tags, err)
+	}
+
+	renamed, err := tagServer.RenameTag(ctx, &pb.RenameTagRequest{TagId: tags.Tags[0].TagId, Name: "Go Lang"})
+	if err != nil || renamed.GetTag().GetName() != "go-lang" {
+		t.Fatalf("expected the tag to be renamed to go-lang, got %+v, %v", renamed, err)
+	}
+	resp, err := blogServer.ListBlogPostsByTag(ctx, &pb.ListBlogPostsByTagRequest{Tag: "go-lang"})
+	if err != nil || len(resp.GetPosts()) != 1 {
+		t.Errorf("expected the post under go-lang, got %+v, %v", resp, err)
+	}
+}
+
+func TestRenameTag_Duplicate(t *testing.T) {
+	mockStorage := &mockTagStorage{
+		RenameTagFunc: func(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error) {
+			return nil, models.ErrDuplicateTag
+		},
+	}
+	server := NewTagServiceServer(mockStorage)
+	resp, err := server.RenameTag(context.Background(), &pb.RenameTagRequest{TagId: "t1", Name: "go"})
+	if !errors.Is(err, models.ErrDuplicateTag) || resp.Success {
+		t.Errorf("expected ErrDuplicateTag, got: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestMergeTags_Success(t *testing.T) {
+	mockStorage := &mockTagStorage{
+		MergeTagsFunc: func(ctx context.Context, req *models.MergeTagsRequest) (*models.Tag, error) {
+			if req.SourceTagId != "t1" || req.TargetTagId != "t2" {
+				t.Errorf("unexpected merge request: %+v", req)
+			}
+			return &models.Tag{TagId: "t2", Name: "go", UsageCount: 5}, nil
+		},
+	}
+	server := NewTagServiceServer(mockStorage)
+	resp, err := server.MergeTags(context.Background(), &pb.MergeTagsRequest{SourceTagId: "t1", TargetTagId: "t2"})
+	if err != nil || !resp.Success || resp.GetTag().GetTagId() != "t2" || resp.GetTag().GetUsageCount() != 5 {
+		t.Errorf("expected success, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestMergeTags_InvalidRequest(t *testing.T) {
+	server := NewTagServiceServer(&mockTagStorage{})
+	tests := []struct {
+		req  *pb.MergeTagsRequest
+		want error
+	}{
+		{&pb.MergeTagsRequest{TargetTagId: "t2"}, models.ErrInvalidTagID},
+		{&pb.MergeTagsRequest{SourceTagId: "t1"}, models.ErrInvalidTagID},
+		{&pb.MergeTagsRequest{SourceTagId: "t1", TargetTagId: "t1"}, models.ErrMergeSameTag},
+	}
+	for _, tt := range tests {
+		resp, err := server.MergeTags(context.Background(), tt.req)
+		if !errors.Is(err, tt.want) || resp.Success {
+			t.Errorf("MergeTags(%+v): expected %v, got: %v", tt.req, tt.want, err)
+		}
+	}
+}
+
+func TestSuggestTags_Success(t *testing.T) {
+	mockStorage := &mockTagStorage{
+		SuggestTagsFunc: func(ctx context.Context, req *models.SuggestTagsRequest) ([]*models.TagSuggestion, error) {
+			// The prefix and tags are normalized, and tags the policy rejects dropped
+			if req.Prefix != "cloud-na" || fmt.Sprint(req.Tags) != "[kubernetes]" || req.Title != "Draft" || req.Limit != 5 {
+				t.Errorf("unexpected suggest request: %+v", req)
+			}
+			return []*models.TagSuggestion{{Tag: &models.Tag{TagId: "t1", Name: "cloud-native", UsageCount: 4}, Score: 1.5}}, nil
+		},
+	}
+	server := NewTagServiceServer(mockStorage)
+	resp, err := server.SuggestTags(context.Background(), &pb.SuggestTagsRequest{
+		Prefix: "Cloud Na",
+		Title:  "Draft",
+		Tags:   []string{" Kubernetes", "  "},
+		Limit:  5,
+	})
+	if err != nil || !resp.Success || len(resp.Suggestions) != 1 ||
+		resp.Suggestions[0].GetTag().GetName() != "cloud-native" || resp.Suggestions[0].GetScore() != 1.5 {
+		t.Errorf("expected one suggestion, got error: %v, resp: %+v", err, resp)
+	}
+}
+
+func TestSuggestTags_InvalidLimit(t *testing.T) {
+	server := NewTagServiceServer(&mockTagStorage{})
+	resp, err := server.SuggestTags(context.Background(), &pb.SuggestTagsRequest{Prefix: "go", Limit: -1})
+	if !errors.Is(err, models.ErrInvalidLimit) || resp.Success {
+		t.Errorf("expected ErrInvalidLimit, got: %v, resp: %+v", err, resp)
+	}
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+// AuthorStorage defines the storage operations for the authors that posts
+// reference.
+type AuthorStorage interface {
+	// CreateAuthor creates a new author. Profile names and emails identify
+	// authors: they are compared case-insensitively and ignoring surrounding
+	// space, and a taken one fails with models.ErrDuplicateAuthor.
+	CreateAuthor(ctx context.Context, author *models.Author) error
+
+	// GetAuthor retrieves an author by ID.
+	GetAuthor(ctx context.Context, authorId string) (*models.Author, error)
+
+	// UpdateAuthor applies the non-empty fields of the request, subject to
+	// the same uniqueness rules as CreateAuthor. A new name is copied to the
+	// author's posts.
+	UpdateAuthor(ctx context.Context, req *models.UpdateAuthorRequest) (*models.Author, error)
+
+	// DeleteAuthor deletes an author. It fails with models.ErrAuthorHasPosts
+	// while any post, including those in the trash, references the author.
+	DeleteAuthor(ctx context.Context, authorId string) error
+
+	// ListAuthors returns one page of authors sorted by name, with the author
+	// ID as tiebreaker, and the token for the next page.
+	ListAuthors(ctx context.Context, req *models.ListAuthorsRequest) ([]*models.Author, string, error)
+}
+
+// Storage is implemented by every backend. Posts, the authors they reference
+// and the tag registry are kept in the same storage, so that posts, authors
+// and tags change together atomically.
+type Storage interface {
+	BlogStorage
+	AuthorStorage
+	TagStorage
+}
+
+func (s *BlogStorageImpl) CreateAuthor(ctx context.Context, author *models.Author) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.authors[author.AuthorId]; exists {
+		return models.ErrDuplicateAuthor
+	}
+	if s.authorTaken(author) {
+		return models.ErrDuplicateAuthor
+	}
+
+	now := time.Now()
+	author.CreatedAt = now
+	author.UpdatedAt = now
+	return s.apply(change{Op: opPutAuthor, AuthorId: author.AuthorId, Author: author.Clone()})
+}
+
+func (s *BlogStorageImpl) GetAuthor(ctx context.Context, authorId string) (*models.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	author, exists := s.authors[authorId]
+	if !exists {
+		return nil, models.ErrAuthorNotFound
+	}
+	return author.Clone(), nil
+}
+
+func (s *BlogStorageImpl) UpdateAuthor(ctx context.Context, req *models.UpdateAuthorRequest) (*models.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingAuthor, exists := s.authors[req.AuthorId]
+	if !exists {
+		return nil, models.ErrAuthorNotFound
+	}
+	updatedAuthor := existingAuthor.Clone()
+	applyAuthorUpdate(updatedAuthor, req)
+	if s.authorTaken(updatedAuthor) {
+		return nil, models.ErrDuplicateAuthor
+	}
+
+	changes := []change{{Op: opPutAuthor, AuthorId: updatedAuthor.AuthorId, Author: updatedAuthor}}
+	if updatedAuthor.Name != existingAuthor.Name {
+		for _, post := range s.indexedPosts(s.byAuthor, updatedAuthor.AuthorId) {
+			renamedPost := post.Clone()
+			renamedPost.Author = updatedAuthor.Name
+			changes = append(changes, change{Op: opPutPost, PostId: post.PostId, Post: renamedPost})
+		}
+	}
+	if err := s.apply(changes...); err != nil {
+		return nil, err
+	}
+	return updatedAuthor.Clone(), nil
+}
+
+func (s *BlogStorageImpl) DeleteAuthor(ctx context.Context, authorId string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.authors[authorId]; !exists {
+		return models.ErrAuthorNotFound
+	}
+	if len(s.byAuthor[authorId]) > 0 {
+		return models.ErrAuthorHasPosts
+	}
+	return s.apply(change{Op: opDeleteAuthor, AuthorId: authorId})
+}
+
+func (s *BlogStorageImpl) ListAuthors(ctx context.Context, req *models.ListAuthorsRequest) ([]*models.Author, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	q, err := parseNameListRequest(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	authors := make([]*models.Author, 0, len(s.authors))
+	for _, author := range s.authors {
+		authors = append(authors, author)
+	}
+	page, next := paginateByName(q, authors, authorSortKey)
+	for i, author := range page {
+		page[i] = author.Clone()
+	}
+	return page, next, nil
+}
+
+// authorTaken reports whether another author already uses the profile name
+// or email of author. The caller must hold the lock.
+func (s *BlogStorageImpl) authorTaken(author *models.Author) bool {
+	profileKey, emailKey := authorKeys(author)
+	for _, other := range s.authors {
+		if other.AuthorId == author.AuthorId {
+			continue
+		}
+		otherProfile, otherEmail := authorKeys(other)
+		if otherProfile == profileKey || (emailKey != "" && otherEmail == emailKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorKeys returns the normalized profile name and email that identify an
+// author. The email key is empty for authors without an email.
+func authorKeys(author *models.Author) (string, string) {
+	return normalizeAuthorKey(author.ProfileName), normalizeAuthorKey(author.Email)
+}
+
+func normalizeAuthorKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// applyAuthorUpdate copies the non-empty fields of an update request onto an
+// author and sets updated_at. An empty field keeps its value, so an update
+// cannot clear the email of an author.
+func applyAuthorUpdate(author *models.Author, req *models.UpdateAuthorRequest) {
+	if req.Name != "" {
+		author.Name = req.Name
+	}
+	if req.Email != "" {
+		author.Email = req.Email
+	}
+	if req.ProfileName != "" {
+		author.ProfileName = req.ProfileName
+	}
+	author.UpdatedAt = time.Now()
+}
+
+// authorSortKey orders author listings by name.
+func authorSortKey(author *models.Author) (string, string) {
+	return author.Name, author.AuthorId
+}
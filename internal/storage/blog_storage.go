@@ -2,46 +2,252 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/search"
 )
 
 // BlogStorage defines the interface for blog-related storage operations.
 type BlogStorage interface {
-	// CreatePost creates a new blog post in the storage.
+	// CreatePost creates a new blog post in the storage. Posts without a
+	// status are scheduled when their publication date is in the future and
+	// published otherwise. A post with an AuthorId takes the author's name,
+	// and fails with models.ErrAuthorNotFound if there is no such author.
 	CreatePost(ctx context.Context, post *models.BlogPost) error
 
-	// GetPost retrieves a blog post by its ID.
+	// CreatePosts creates several posts like CreatePost, all or none of
+	// them. When a post cannot be created, it fails with a
+	// *models.ItemError giving the position of the post and the reason,
+	// which also applies to posts with the same ID within the batch.
+	CreatePosts(ctx context.Context, posts []*models.BlogPost) error
+
+	// CreatePostOnce is CreatePost for a request that may be retried. The
+	// first call with key.RequestId creates post and remembers the key until
+	// key.ExpiresAt. Until then, later calls with the same request ID create
+	// nothing and return the post the first call created, as it is now, or
+	// fail with models.ErrRequestIDReused if their fingerprint differs, or
+	// with models.ErrPostNotFound if the post has since been deleted. The
+	// returned bool reports whether this call created the post.
+	CreatePostOnce(ctx context.Context, post *models.BlogPost, key *models.IdempotencyKey) (*models.BlogPost, bool, error)
+
+	// ExpireIdempotencyKeys forgets the keys that expired before now, so
+	// their request IDs create new posts again, and returns how many there
+	// were. Expired keys are ignored even before they are forgotten.
+	ExpireIdempotencyKeys(ctx context.Context, now time.Time) (int, error)
+
+	// GetPost retrieves a blog post by its ID. Like every method but
+	// DeletePost and the trash methods below, it treats posts in the trash as
+	// not found.
 	GetPost(ctx context.Context, postId string) (*models.BlogPost, error)
 
-	// UpdatePost updates an existing blog post.
+	// GetPosts is GetPost for several posts, read at a single point in time.
+	// It returns one post per ID, in order, nil for a post that does not
+	// exist or is in the trash.
+	GetPosts(ctx context.Context, postIds []string) ([]*models.BlogPost, error)
+
+	// UpdatePost updates an existing blog post and increments its version.
+	// When the request has an expected version and the post is no longer at
+	// that version, it fails with models.ErrVersionMismatch.
 	UpdatePost(ctx context.Context, post *models.UpdateBlogPostRequest) (*models.BlogPost, error)
 
-	// DeletePost deletes a blog post by its ID.
-	DeletePost(ctx context.Context, postId string) error
+	// DeletePost permanently deletes a blog post by its ID, whether or not it
+	// is in the trash, subject to the same version check as UpdatePost.
+	DeletePost(ctx context.Context, req *models.DeleteBlogPostRequest) error
+
+	// TrashPost moves a post to the trash, subject to the same version check
+	// as UpdatePost.
+	TrashPost(ctx context.Context, req *models.DeleteBlogPostRequest) error
+
+	// DeletePosts deletes several posts in a single write, as DeletePost or,
+	// unless the request is permanent, TrashPost would without a version
+	// check. It returns one error per ID, in order: nil if the post was
+	// deleted and models.ErrPostNotFound if there was none to delete, as for
+	// an ID repeated in the request. When it fails as a whole, nothing was
+	// deleted.
+	DeletePosts(ctx context.Context, req *models.BatchDeleteRequest) ([]error, error)
+
+	// RestorePost moves a post out of the trash. It fails with
+	// models.ErrPostNotTrashed if the post is not in the trash.
+	RestorePost(ctx context.Context, postId string) (*models.BlogPost, error)
+
+	// PurgePost permanently deletes a post in the trash. It fails with
+	// models.ErrPostNotTrashed if the post is not in the trash.
+	PurgePost(ctx context.Context, postId string) error
+
+	// PurgeTrashedBefore permanently deletes every post moved to the trash
+	// before cutoff and returns how many were deleted.
+	PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// SetPostStatus moves a post to another stage of its lifecycle and
+	// increments its version, subject to the same version check as
+	// UpdatePost. It fails with models.ErrInvalidTransition if the post cannot
+	// move to the requested status from its current one.
+	SetPostStatus(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error)
+
+	// PublishScheduled publishes every scheduled post whose publication date
+	// is not after now and returns how many were published.
+	PublishScheduled(ctx context.Context, now time.Time) (int, error)
+
+	// ListPosts returns one page of the posts matching the request's filter,
+	// sorted by its order_by (publication date, newest first, by default) with
+	// the post ID as tiebreaker, and the token for the next page. Only
+	// published posts are listed unless the filter mentions the status.
+	ListPosts(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error)
+
+	// ListPostsByAuthor returns one page of the published posts of an author,
+	// newest first, and the token for the next page. It looks the posts up
+	// in an index instead of scanning every post.
+	ListPostsByAuthor(ctx context.Context, req *models.ListPostsByAuthorRequest) ([]*models.BlogPost, string, error)
+
+	// ListPostsByTag is ListPostsByAuthor for the published posts carrying a
+	// tag.
+	ListPostsByTag(ctx context.Context, req *models.ListPostsByTagRequest) ([]*models.BlogPost, string, error)
+
+	// SearchPosts returns one page of the published posts that match a
+	// full-text search query, most relevant first, and the token for the
+	// next page. It fails with models.ErrEmptyQuery if the query has no
+	// words. Every backend ranks posts the same way, with the search package.
+	SearchPosts(ctx context.Context, req *models.SearchPostsRequest) ([]*models.SearchResult, string, error)
+
+	// GetRelatedPosts returns up to req.Limit published posts related to a
+	// post, best first. Posts are scored by the overlap of their tags with
+	// those of the post, as a Jaccard index, combined with the similarity of
+	// their title and content. The post itself is never included. It fails
+	// with models.ErrPostNotFound if the post does not exist or is in the
+	// trash.
+	GetRelatedPosts(ctx context.Context, req *models.GetRelatedPostsRequest) ([]*models.RelatedPost, error)
+
+	// ExportPosts calls fn with every post matching the request, in post ID
+	// order, as they all were at a single point in time: writes made while
+	// the export runs are not seen, and do not wait for it. sequence is the
+	// sequence number of the last event published before that point, the
+	// same for every post, so that WatchPosts from it follows the changes
+	// the export does not include.
+	ExportPosts(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error
+
+	// WatchPosts calls fn with the events of the posts that match the
+	// request, in order, until the context is done or fn returns an error,
+	// and returns the reason it stopped. Every write that changes what
+	// GetPost returns produces an event once it commits. The most recent
+	// DefaultFeedHistory events are kept, so a watcher can resume from the
+	// sequence number of the last event it saw; it fails with
+	// models.ErrSequenceExpired if the events after it are gone. fn must not
+	// modify the event.
+	WatchPosts(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error
+
+	// ListRevisions returns the revision history of a post, newest first.
+	// CreatePost records the first revision and every later change that
+	// bumps the version another; DeletePost removes the history along with
+	// the post.
+	ListRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error)
+
+	// GetRevision retrieves a single revision of a post.
+	GetRevision(ctx context.Context, postId string, revision int64) (*models.PostRevision, error)
 }
 
 type BlogStorageImpl struct {
 	// In Memory storage
 	posts map[string]*models.BlogPost
 
-	// mu protects concurrent access to the posts map
+	// idempotencyKeys holds the keys of creates that may be retried, by
+	// request ID
+	idempotencyKeys map[string]*models.IdempotencyKey
+
+	// revisions holds the history of every post, oldest first
+	revisions map[string][]*models.PostRevision
+
+	// authors holds the authors that posts reference
+	authors map[string]*models.Author
+
+	// tags is the tag registry, tagIds maps tag names to their IDs and
+	// tagNames keeps the names sorted
+	tags     map[string]*models.Tag
+	tagIds   map[string]string
+	tagNames nameIndex
+
+	// byAuthor and byTag index the posts, including those in the trash, by
+	// author ID and by tag, and fullText indexes the text of the published
+	// posts. They are kept up to date by applyChanges.
+	byAuthor postIndex
+	byTag    postIndex
+	fullText *search.Index
+
+	// mu protects concurrent access to the posts, revisions, idempotency
+	// keys, authors and tags maps and the indexes
 	mu sync.RWMutex
 
+	// feed hands the events of every applied change out to watchers
+	feed *feed
+
 	// createdAt tracks when the Blogs storage was created.
 	createdAt time.Time
+
+	// commit, when set, is called with the changes of every mutation while
+	// the write lock is held and before they are applied. Durable backends
+	// use it to persist the changes; an error aborts the mutation.
+	commit func(changes []change) error
+}
+
+// changeOp is the kind of state transition recorded by a change.
+type changeOp string
+
+const (
+	opPutPost      changeOp = "put_post"
+	opDeletePost   changeOp = "delete_post"
+	opAddRevision  changeOp = "add_revision"
+	opPutAuthor    changeOp = "put_author"
+	opDeleteAuthor changeOp = "delete_author"
+	opPutTag       changeOp = "put_tag"
+	opDeleteTag    changeOp = "delete_tag"
+	opPutKey       changeOp = "put_key"
+	opDeleteKey    changeOp = "delete_key"
+)
+
+// change is a single state transition produced by a mutation. Stored posts
+// are never modified in place and never shared with callers: posts are
+// copied on the way in and out, and updates put a new copy. A post pointer
+// handed to apply therefore stays valid for readers that already hold it.
+// Revisions are immutable once added. Authors and tags are handled like
+// posts.
+type change struct {
+	Op       changeOp             `json:"op"`
+	PostId   string               `json:"post_id"`
+	Post     *models.BlogPost     `json:"post,omitempty"`
+	Revision *models.PostRevision `json:"revision,omitempty"`
+	AuthorId string               `json:"author_id,omitempty"`
+	Author   *models.Author       `json:"author,omitempty"`
+	TagId    string               `json:"tag_id,omitempty"`
+	Tag      *models.Tag          `json:"tag,omitempty"`
+	// RequestId identifies the idempotency key of opPutKey and opDeleteKey
+	RequestId string                 `json:"request_id,omitempty"`
+	Key       *models.IdempotencyKey `json:"key,omitempty"`
 }
 
 func NewBlogStorage() *BlogStorageImpl {
 	return &BlogStorageImpl{
-		posts:     make(map[string]*models.BlogPost),
-		createdAt: time.Now(),
+		posts:           make(map[string]*models.BlogPost),
+		revisions:       make(map[string][]*models.PostRevision),
+		idempotencyKeys: make(map[string]*models.IdempotencyKey),
+		authors:         make(map[string]*models.Author),
+		tags:            make(map[string]*models.Tag),
+		tagIds:          make(map[string]string),
+		byAuthor:        make(postIndex),
+		byTag:           make(postIndex),
+		fullText:        search.NewIndex(),
+		feed:            newFeed(DefaultFeedHistory),
+		createdAt:       time.Now(),
 	}
 }
 
 func (s *BlogStorageImpl) CreatePost(ctx context.Context, post *models.BlogPost) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -51,65 +257,816 @@ func (s *BlogStorageImpl) CreatePost(ctx context.Context, post *models.BlogPost)
 	}
 
 	now := time.Now()
+	changes, err := s.createChanges(post, now)
+	if err != nil {
+		return err
+	}
+	return s.apply(append(changes, s.registerTags(post.Tags, now)...)...)
+}
+
+func (s *BlogStorageImpl) CreatePosts(ctx context.Context, posts []*models.BlogPost) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var (
+		changes []change
+		tags    []string
+	)
+	batch := make(map[string]bool, len(posts))
+	for i, post := range posts {
+		if _, exists := s.posts[post.PostId]; exists || batch[post.PostId] {
+			return &models.ItemError{Index: i, Err: models.ErrDuplicatePost}
+		}
+		batch[post.PostId] = true
+		postChanges, err := s.createChanges(post, now)
+		if err != nil {
+			return &models.ItemError{Index: i, Err: err}
+		}
+		changes = append(changes, postChanges...)
+		tags = append(tags, post.Tags...)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	// Register the tags of the whole batch at once, so that a new tag
+	// carried by several posts is only created once
+	return s.apply(append(changes, s.registerTags(tags, now)...)...)
+}
+
+func (s *BlogStorageImpl) CreatePostOnce(ctx context.Context, post *models.BlogPost, key *models.IdempotencyKey) (*models.BlogPost, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if previous, exists := s.idempotencyKeys[key.RequestId]; exists && now.Before(previous.ExpiresAt) {
+		if previous.Fingerprint != key.Fingerprint {
+			return nil, false, models.ErrRequestIDReused
+		}
+		existingPost, exists := s.livePost(previous.PostId)
+		if !exists {
+			return nil, false, models.ErrPostNotFound
+		}
+		return existingPost.Clone(), false, nil
+	}
+
+	if _, exists := s.posts[post.PostId]; exists {
+		return nil, false, models.ErrDuplicatePost
+	}
+	changes, err := s.createChanges(post, now)
+	if err != nil {
+		return nil, false, err
+	}
+	stored := *key
+	stored.PostId = post.PostId
+	changes = append(changes, change{Op: opPutKey, RequestId: key.RequestId, Key: &stored})
+	if err := s.apply(append(changes, s.registerTags(post.Tags, now)...)...); err != nil {
+		return nil, false, err
+	}
+	return post, true, nil
+}
+
+func (s *BlogStorageImpl) ExpireIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes []change
+	for id, key := range s.idempotencyKeys {
+		if key.ExpiresAt.Before(now) {
+			changes = append(changes, change{Op: opDeleteKey, RequestId: id})
+		}
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	if err := s.apply(changes...); err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+// createChanges fills in the fields of a new post set by the storage and
+// returns the changes that store it with its first revision, leaving its tags
+// to the caller. The caller must hold the write lock.
+func (s *BlogStorageImpl) createChanges(post *models.BlogPost, now time.Time) ([]change, error) {
+	// Posts that reference an author carry its current name
+	if post.AuthorId != "" {
+		author, exists := s.authors[post.AuthorId]
+		if !exists {
+			return nil, models.ErrAuthorNotFound
+		}
+		post.Author = author.Name
+	}
+
 	// Set the publication date if not provided
 	if post.PublicationDate.IsZero() {
 		post.PublicationDate = now
 	}
+	if err := initStatus(post, now); err != nil {
+		return nil, err
+	}
 	// Set the updated at time
 	post.UpdatedAt = now
+	// Every post starts at version 1
+	post.Version = 1
 
-	// Add the post to the storage
-	s.posts[post.PostId] = post
-	return nil
+	// Add a copy of the post to the storage, so the caller cannot modify it
+	// behind the lock, along with its first revision
+	return []change{
+		{Op: opPutPost, PostId: post.PostId, Post: post.Clone()},
+		{Op: opAddRevision, PostId: post.PostId, Revision: models.NewPostRevision(post, post.Author)},
+	}, nil
 }
 
 func (s *BlogStorageImpl) GetPost(ctx context.Context, postId string) (*models.BlogPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// Retrieve the post by ID
-	post, exists := s.posts[postId]
+	post, exists := s.livePost(postId)
 	if !exists {
 		return nil, models.ErrPostNotFound
 	}
-	return post, nil
+	return post.Clone(), nil
+}
+
+func (s *BlogStorageImpl) GetPosts(ctx context.Context, postIds []string) ([]*models.BlogPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	posts := make([]*models.BlogPost, len(postIds))
+	for i, id := range postIds {
+		if post, exists := s.livePost(id); exists {
+			posts[i] = post.Clone()
+		}
+	}
+	return posts, nil
 }
 
 func (s *BlogStorageImpl) UpdatePost(ctx context.Context, post *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Retrieve the existing post
-	existingPost, exists := s.posts[post.PostId]
+	existingPost, exists := s.livePost(post.PostId)
 	if !exists {
 		return nil, models.ErrPostNotFound
 	}
+	if err := checkVersion(existingPost, post.ExpectedVersion); err != nil {
+		return nil, err
+	}
 
-	// Update fields if provided
-	if post.Title != "" {
-		existingPost.Title = post.Title
+	// Update fields if provided, on a copy of the stored post
+	updatedPost := existingPost.Clone()
+	if err := applyUpdate(updatedPost, post); err != nil {
+		return nil, err
 	}
-	if post.Content != "" {
-		existingPost.Content = post.Content
+
+	changes := []change{
+		{Op: opPutPost, PostId: updatedPost.PostId, Post: updatedPost},
+		{Op: opAddRevision, PostId: updatedPost.PostId, Revision: models.NewPostRevision(updatedPost, post.Editor)},
 	}
-	if len(post.Tags) > 0 {
-		existingPost.Tags = post.Tags
+	if err := s.apply(append(changes, s.registerTags(updatedPost.Tags, updatedPost.UpdatedAt)...)...); err != nil {
+		return nil, err
 	}
-	existingPost.UpdatedAt = time.Now()
-
-	return existingPost, nil
+	return updatedPost.Clone(), nil
 }
 
-func (s *BlogStorageImpl) DeletePost(ctx context.Context, postId string) error {
+func (s *BlogStorageImpl) DeletePost(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Check if the post exists
-	if _, exists := s.posts[postId]; !exists {
+	existingPost, exists := s.posts[req.PostId]
+	if !exists {
 		return models.ErrPostNotFound
 	}
+	if err := checkVersion(existingPost, req.ExpectedVersion); err != nil {
+		return err
+	}
 
 	// Delete the post
-	delete(s.posts, postId)
+	return s.apply(change{Op: opDeletePost, PostId: req.PostId})
+}
+
+func (s *BlogStorageImpl) TrashPost(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingPost, exists := s.livePost(req.PostId)
+	if !exists {
+		return models.ErrPostNotFound
+	}
+	if err := checkVersion(existingPost, req.ExpectedVersion); err != nil {
+		return err
+	}
+
+	trashedPost := existingPost.Clone()
+	trashedPost.DeletedAt = time.Now()
+	return s.apply(change{Op: opPutPost, PostId: req.PostId, Post: trashedPost})
+}
+
+func (s *BlogStorageImpl) DeletePosts(ctx context.Context, req *models.BatchDeleteRequest) ([]error, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	errs := make([]error, len(req.PostIds))
+	deleted := make(map[string]bool, len(req.PostIds))
+	var changes []change
+	now := time.Now()
+	for i, id := range req.PostIds {
+		existingPost, exists := s.posts[id]
+		if !exists || deleted[id] || !req.Permanent && existingPost.Trashed() {
+			errs[i] = models.ErrPostNotFound
+			continue
+		}
+		deleted[id] = true
+		if req.Permanent {
+			changes = append(changes, change{Op: opDeletePost, PostId: id})
+			continue
+		}
+		trashedPost := existingPost.Clone()
+		trashedPost.DeletedAt = now
+		changes = append(changes, change{Op: opPutPost, PostId: id, Post: trashedPost})
+	}
+	if len(changes) > 0 {
+		if err := s.apply(changes...); err != nil {
+			return nil, err
+		}
+	}
+	return errs, nil
+}
+
+func (s *BlogStorageImpl) RestorePost(ctx context.Context, postId string) (*models.BlogPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingPost, exists := s.posts[postId]
+	if !exists {
+		return nil, models.ErrPostNotFound
+	}
+	if !existingPost.Trashed() {
+		return nil, models.ErrPostNotTrashed
+	}
+
+	restoredPost := existingPost.Clone()
+	restoredPost.DeletedAt = time.Time{}
+	if err := s.apply(change{Op: opPutPost, PostId: postId, Post: restoredPost}); err != nil {
+		return nil, err
+	}
+	return restoredPost.Clone(), nil
+}
+
+func (s *BlogStorageImpl) PurgePost(ctx context.Context, postId string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingPost, exists := s.posts[postId]
+	if !exists {
+		return models.ErrPostNotFound
+	}
+	if !existingPost.Trashed() {
+		return models.ErrPostNotTrashed
+	}
+	return s.apply(change{Op: opDeletePost, PostId: postId})
+}
+
+func (s *BlogStorageImpl) PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes []change
+	for id, post := range s.posts {
+		if post.Trashed() && post.DeletedAt.Before(cutoff) {
+			changes = append(changes, change{Op: opDeletePost, PostId: id})
+		}
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	if err := s.apply(changes...); err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+func (s *BlogStorageImpl) SetPostStatus(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingPost, exists := s.livePost(req.PostId)
+	if !exists {
+		return nil, models.ErrPostNotFound
+	}
+	if err := checkVersion(existingPost, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	updatedPost := existingPost.Clone()
+	if err := applyStatus(updatedPost, req, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.apply(
+		change{Op: opPutPost, PostId: updatedPost.PostId, Post: updatedPost},
+		change{Op: opAddRevision, PostId: updatedPost.PostId, Revision: models.NewPostRevision(updatedPost, req.Editor)},
+	); err != nil {
+		return nil, err
+	}
+	return updatedPost.Clone(), nil
+}
+
+func (s *BlogStorageImpl) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes []change
+	for id, post := range s.posts {
+		if post.Status != models.StatusScheduled || post.Trashed() || post.PublicationDate.After(now) {
+			continue
+		}
+		publishedPost := post.Clone()
+		publishedPost.Status = models.StatusPublished
+		publishedPost.UpdatedAt = now
+		publishedPost.Version++
+		changes = append(changes,
+			change{Op: opPutPost, PostId: id, Post: publishedPost},
+			change{Op: opAddRevision, PostId: id, Revision: models.NewPostRevision(publishedPost, models.SystemEditor)},
+		)
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	if err := s.apply(changes...); err != nil {
+		return 0, err
+	}
+	return len(changes) / 2, nil
+}
+
+func (s *BlogStorageImpl) ListPosts(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	q, err := parseListRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Collect the matching posts, then let the query pick the requested page
+	posts := make([]*models.BlogPost, 0, len(s.posts))
+	for _, post := range s.posts {
+		if q.match(post) {
+			posts = append(posts, post)
+		}
+	}
+	page, next := q.paginate(posts)
+	for i, post := range page {
+		page[i] = post.Clone()
+	}
+	return page, next, nil
+}
+
+func (s *BlogStorageImpl) ListPostsByAuthor(ctx context.Context, req *models.ListPostsByAuthorRequest) ([]*models.BlogPost, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	q, err := parseScopedListRequest("author:"+req.AuthorId, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	page, next := s.listIndexed(q, s.byAuthor, req.AuthorId)
+	return page, next, nil
+}
+
+func (s *BlogStorageImpl) ListPostsByTag(ctx context.Context, req *models.ListPostsByTagRequest) ([]*models.BlogPost, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	q, err := parseScopedListRequest("tag:"+req.Tag, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	page, next := s.listIndexed(q, s.byTag, req.Tag)
+	return page, next, nil
+}
+
+func (s *BlogStorageImpl) SearchPosts(ctx context.Context, req *models.SearchPostsRequest) ([]*models.SearchResult, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	q, err := parseSearchRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	page, next := q.paginate(s.fullText.Search(q.query, search.DefaultBoosts))
+	results := make([]*models.SearchResult, len(page))
+	for i, hit := range page {
+		results[i] = &models.SearchResult{Post: s.posts[hit.Id].Clone(), Score: hit.Score}
+	}
+	return results, next, nil
+}
+
+func (s *BlogStorageImpl) GetRelatedPosts(ctx context.Context, req *models.GetRelatedPostsRequest) ([]*models.RelatedPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	limit, err := relatedLimit(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	post, exists := s.livePost(req.PostId)
+	if !exists {
+		return nil, models.ErrPostNotFound
+	}
+
+	similar := s.fullText.Similar(searchDocument(post), relatedBoosts, relatedCandidates)
+	candidates := make(map[string][]string, len(similar))
+	for _, hit := range similar {
+		candidates[hit.Id] = s.posts[hit.Id].Tags
+	}
+	for _, tag := range post.Tags {
+		for id := range s.byTag[tag] {
+			if other := s.posts[id]; searchable(other) {
+				candidates[id] = other.Tags
+			}
+		}
+	}
+
+	hits := relatedHits(post, similar, candidates, limit)
+	related := make([]*models.RelatedPost, len(hits))
+	for i, hit := range hits {
+		related[i] = &models.RelatedPost{Post: s.posts[hit.Id].Clone(), Score: hit.Score}
+	}
+	return related, nil
+}
+
+func (s *BlogStorageImpl) ExportPosts(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error {
+	q, err := parseExportRequest(req)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Stored posts are replaced rather than modified, so the posts present
+	// under the read lock stay as they were after it is released. Events are
+	// published under the write lock, so the sequence matches them.
+	s.mu.RLock()
+	posts := make([]*models.BlogPost, 0, len(s.posts))
+	for _, post := range s.posts {
+		posts = append(posts, post)
+	}
+	sequence := s.feed.lastSequence()
+	s.mu.RUnlock()
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].PostId < posts[j].PostId
+	})
+	for _, post := range posts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !q.match(post) {
+			continue
+		}
+		if err := fn(post.Clone(), sequence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BlogStorageImpl) WatchPosts(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+	return s.feed.watch(ctx, req, fn)
+}
+
+// Update mask paths that can be set by an update, and those that name post
+// fields that never change after creation or are maintained by the storage.
+var (
+	mutablePaths   = map[string]bool{"title": true, "content": true, "tags": true}
+	immutablePaths = map[string]bool{"post_id": true, "author": true, "publication_date": true, "updated_at": true, "version": true}
+)
+
+// ValidateUpdateMask checks the update mask of a request: every path must
+// name a mutable field, and masked title and content must not be cleared.
+func ValidateUpdateMask(req *models.UpdateBlogPostRequest) error {
+	for _, path := range req.UpdateMask {
+		switch {
+		case immutablePaths[path]:
+			return fmt.Errorf("%w: %q", models.ErrImmutableField, path)
+		case !mutablePaths[path]:
+			return fmt.Errorf("%w: %q", models.ErrInvalidUpdateMask, path)
+		case path == "title" && req.Title == "":
+			return models.ErrEmptyTitle
+		case path == "content" && req.Content == "":
+			return models.ErrEmptyContent
+		}
+	}
+	return nil
+}
+
+func (s *BlogStorageImpl) ListRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.livePost(postId); !exists {
+		return nil, models.ErrPostNotFound
+	}
+	history := s.revisions[postId]
+	revisions := make([]*models.PostRevision, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		revisions = append(revisions, history[i].Clone())
+	}
+	return revisions, nil
+}
+
+func (s *BlogStorageImpl) GetRevision(ctx context.Context, postId string, revision int64) (*models.PostRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.livePost(postId); !exists {
+		return nil, models.ErrPostNotFound
+	}
+	for _, r := range s.revisions[postId] {
+		if r.Revision == revision {
+			return r.Clone(), nil
+		}
+	}
+	return nil, models.ErrRevisionNotFound
+}
+
+// livePost looks up a post that is not in the trash. The caller must hold
+// the lock.
+func (s *BlogStorageImpl) livePost(postId string) (*models.BlogPost, bool) {
+	post, exists := s.posts[postId]
+	if !exists || post.Trashed() {
+		return nil, false
+	}
+	return post, true
+}
+
+// applyUpdate copies the fields of an update request onto a post: exactly the
+// masked ones, or the non-empty ones when there is no mask. It then bumps the
+// version and updated_at, and leaves the post untouched if the mask is
+// invalid.
+func applyUpdate(post *models.BlogPost, req *models.UpdateBlogPostRequest) error {
+	if err := ValidateUpdateMask(req); err != nil {
+		return err
+	}
+	if len(req.UpdateMask) == 0 {
+		if req.Title != "" {
+			post.Title = req.Title
+		}
+		if req.Content != "" {
+			post.Content = req.Content
+		}
+		if len(req.Tags) > 0 {
+			post.Tags = append([]string(nil), req.Tags...)
+		}
+	}
+	for _, path := range req.UpdateMask {
+		switch path {
+		case "title":
+			post.Title = req.Title
+		case "content":
+			post.Content = req.Content
+		case "tags":
+			post.Tags = append([]string(nil), req.Tags...)
+		}
+	}
+	post.UpdatedAt = time.Now()
+	post.Version++
 	return nil
 }
+
+// initStatus sets the status of a new post from its publication date when it
+// has none, and otherwise checks that the two agree. Posts cannot be created
+// archived.
+func initStatus(post *models.BlogPost, now time.Time) error {
+	future := post.PublicationDate.After(now)
+	switch post.Status {
+	case "":
+		post.Status = models.StatusPublished
+		if future {
+			post.Status = models.StatusScheduled
+		}
+	case models.StatusDraft:
+	case models.StatusScheduled:
+		if !future {
+			return models.ErrPublishInPast
+		}
+	case models.StatusPublished:
+		if future {
+			return models.ErrPublishInFuture
+		}
+	default:
+		return models.ErrInvalidStatus
+	}
+	return nil
+}
+
+// applyStatus moves a post to the status of the request. The allowed
+// transitions are:
+//
+//	draft, scheduled, archived -> published (or scheduled for a future date)
+//	scheduled, published, archived -> draft
+//	published -> archived
+//
+// Publishing a scheduled post again reschedules it.
+func applyStatus(post *models.BlogPost, req *models.SetPostStatusRequest, now time.Time) error {
+	from := post.Status
+	switch req.Status {
+	case models.StatusPublished:
+		if from == models.StatusPublished {
+			return models.ErrInvalidTransition
+		}
+		switch {
+		case !req.PublishAt.IsZero():
+			post.PublicationDate = req.PublishAt
+		case from != models.StatusArchived:
+			post.PublicationDate = now
+		}
+		post.Status = models.StatusPublished
+		if post.PublicationDate.After(now) {
+			post.Status = models.StatusScheduled
+		}
+	case models.StatusDraft:
+		if from == models.StatusDraft {
+			return models.ErrInvalidTransition
+		}
+		post.Status = models.StatusDraft
+	case models.StatusArchived:
+		if from != models.StatusPublished {
+			return models.ErrInvalidTransition
+		}
+		post.Status = models.StatusArchived
+	default:
+		return models.ErrInvalidStatus
+	}
+	post.UpdatedAt = now
+	post.Version++
+	return nil
+}
+
+// checkVersion enforces the expected version of an update or delete. Zero
+// means the caller did not ask for a check.
+func checkVersion(post *models.BlogPost, expected int64) error {
+	if expected != 0 && post.Version != expected {
+		return models.ErrVersionMismatch
+	}
+	return nil
+}
+
+// apply commits and then applies the changes of a single mutation. The caller
+// must hold the write lock.
+func (s *BlogStorageImpl) apply(changes ...change) error {
+	if s.commit != nil {
+		if err := s.commit(changes); err != nil {
+			return err
+		}
+	}
+	s.feed.publish(s.applyChanges(changes))
+	return nil
+}
+
+// applyChanges updates the in-memory state without committing, and returns
+// the events of the posts that changed. It is also used to replay changes
+// recovered from durable storage, whose events are dropped.
+func (s *BlogStorageImpl) applyChanges(changes []change) []*models.PostEvent {
+	var events []*models.PostEvent
+	now := time.Now()
+	for _, c := range changes {
+		switch c.Op {
+		case opPutPost:
+			old, exists := s.posts[c.PostId]
+			if exists {
+				s.unindexPost(old)
+			}
+			s.posts[c.PostId] = c.Post
+			s.indexPost(c.Post)
+			if event := postEvent(old, c.Post, now); event != nil {
+				events = append(events, event)
+			}
+		case opDeletePost:
+			old, exists := s.posts[c.PostId]
+			if exists {
+				s.unindexPost(old)
+			}
+			delete(s.posts, c.PostId)
+			delete(s.revisions, c.PostId)
+			if event := postEvent(old, nil, now); event != nil {
+				events = append(events, event)
+			}
+		case opAddRevision:
+			s.revisions[c.PostId] = append(s.revisions[c.PostId], c.Revision)
+		case opPutAuthor:
+			s.authors[c.AuthorId] = c.Author
+		case opDeleteAuthor:
+			delete(s.authors, c.AuthorId)
+		case opPutTag:
+			if old, exists := s.tags[c.TagId]; exists {
+				delete(s.tagIds, old.Name)
+				s.tagNames.remove(old.Name)
+			}
+			s.tags[c.TagId] = c.Tag
+			s.tagIds[c.Tag.Name] = c.TagId
+			s.tagNames.add(c.Tag.Name)
+		case opPutKey:
+			s.idempotencyKeys[c.RequestId] = c.Key
+		case opDeleteKey:
+			delete(s.idempotencyKeys, c.RequestId)
+		case opDeleteTag:
+			if old, exists := s.tags[c.TagId]; exists {
+				delete(s.tagIds, old.Name)
+				s.tagNames.remove(old.Name)
+				delete(s.tags, c.TagId)
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+// These stress tests only prove something under the race detector:
+//
+//	go test -race ./internal/storage
+//
+// Callers mutate every post they get back while other goroutines update and
+// list the same posts. Any memory shared between the caller and the store is
+// reported as a data race.
+
+const (
+	stressPosts      = 4
+	stressGoroutines = 8
+	stressIterations = 200
+)
+
+func TestBlogStorageImpl_StressMutateReturnedPosts(t *testing.T) {
+	ctx := context.Background()
+	s := NewBlogStorage()
+	for i := 0; i < stressPosts; i++ {
+		if err := s.CreatePost(ctx, &models.BlogPost{PostId: fmt.Sprint(i), Title: "t", Tags: []string{"a", "b"}}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < stressIterations; i++ {
+				id := fmt.Sprint(i % stressPosts)
+				switch (g + i) % 3 {
+				case 0:
+					post, err := s.GetPost(ctx, id)
+					if err != nil {
+						t.Errorf("GetPost failed: %v", err)
+						return
+					}
+					post.Title = "mutated"
+					post.Tags[0] = "mutated"
+				case 1:
+					tags := []string{fmt.Sprint(g), fmt.Sprint(i)}
+					post, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: id, Tags: tags})
+					if err != nil {
+						t.Errorf("UpdatePost failed: %v", err)
+						return
+					}
+					tags[0] = "mutated"
+					post.Tags[1] = "mutated"
+				case 2:
+					page, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{Filter: `tags:"a" OR title:"t"`})
+					if err != nil {
+						t.Errorf("ListPosts failed: %v", err)
+						return
+					}
+					for _, post := range page {
+						post.Title = "mutated"
+						post.Tags[0] = "mutated"
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for i := 0; i < stressPosts; i++ {
+		post, err := s.GetPost(ctx, fmt.Sprint(i))
+		if err != nil {
+			t.Fatalf("GetPost failed: %v", err)
+		}
+		if post.Title != "t" {
+			t.Errorf("caller mutation leaked into storage: %+v", post)
+		}
+		for _, tag := range post.Tags {
+			if tag == "mutated" {
+				t.Errorf("caller mutation leaked into stored tags: %+v", post)
+			}
+		}
+	}
+}
+
+func TestBlogStorageImpl_StressCreateWhileCallerMutates(t *testing.T) {
+	ctx := context.Background()
+	s := NewBlogStorage()
+
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < stressIterations; i++ {
+				post := &models.BlogPost{PostId: fmt.Sprintf("%d-%d", g, i), Title: "t", Tags: []string{"a"}}
+				if err := s.CreatePost(ctx, post); err != nil {
+					t.Errorf("CreatePost failed: %v", err)
+					return
+				}
+				// Keep using the post after handing it to the store, while
+				// other goroutines list it.
+				post.Title = "mutated"
+				post.Tags[0] = "mutated"
+				if i%20 == 0 {
+					if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{Filter: `title = "t"`}); err != nil {
+						t.Errorf("ListPosts failed: %v", err)
+						return
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	page, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{Filter: `title = "mutated" OR tags:"mutated"`})
+	if err != nil {
+		t.Fatalf("ListPosts failed: %v", err)
+	}
+	if len(page) != 0 {
+		t.Errorf("expected no caller mutations in storage, found %d posts", len(page))
+	}
+}
@@ -0,0 +1,37 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pandae7/go-blogger/internal/storage"
+	"github.com/pandae7/go-blogger/internal/storage/storagetest"
+)
+
+func TestBlogStorageImpl_Conformance(t *testing.T) {
+	storagetest.RunConformance(t, func(t *testing.T) storage.Storage {
+		return storage.NewBlogStorage()
+	})
+}
+
+func TestFileBlogStorage_Conformance(t *testing.T) {
+	storagetest.RunConformance(t, func(t *testing.T) storage.Storage {
+		s, err := storage.NewFileBlogStorage(storage.FileStorageOptions{Dir: t.TempDir()})
+		if err != nil {
+			t.Fatalf("NewFileBlogStorage failed: %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	})
+}
+
+func TestSQLBlogStorage_Conformance(t *testing.T) {
+	storagetest.RunConformance(t, func(t *testing.T) storage.Storage {
+		s, err := storage.NewSQLBlogStorage(filepath.Join(t.TempDir(), "blog.db"))
+		if err != nil {
+			t.Fatalf("NewSQLBlogStorage failed: %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	})
+}
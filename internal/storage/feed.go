@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+// DefaultFeedHistory is the number of recent events a storage keeps for
+// watchers that resume after a disconnect or fall behind.
+const DefaultFeedHistory = 10000
+
+// feed hands the post events of a storage out to watchers. It keeps the most
+// recent events, so that a watcher that resumes from a sequence number, or
+// that is slower than the writers, reads the events it missed from the
+// history instead of losing them. A watcher only fails when the events it
+// needs have already left the history.
+//
+// The history lives in memory, so sequence numbers start from the time the
+// feed was created, in nanoseconds: a sequence handed out before a restart
+// is lower than any sequence after it, and is reported as expired rather
+// than resumed from the wrong place.
+type feed struct {
+	mu       sync.Mutex
+	history  []*models.PostEvent
+	capacity int
+	// last is the sequence number of the last event published
+	last uint64
+	// wake is closed, and replaced, whenever events are published
+	wake chan struct{}
+}
+
+func newFeed(capacity int) *feed {
+	return &feed{
+		capacity: capacity,
+		last:     uint64(time.Now().UnixNano()),
+		wake:     make(chan struct{}),
+	}
+}
+
+// publish numbers the events and wakes every watcher.
+func (f *feed) publish(events []*models.PostEvent) {
+	if len(events) == 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, event := range events {
+		f.last++
+		event.Sequence = f.last
+	}
+	f.history = append(f.history, events...)
+	if excess := len(f.history) - f.capacity; excess > 0 {
+		// Copy rather than reslice, so the dropped events can be collected
+		f.history = append([]*models.PostEvent(nil), f.history[excess:]...)
+	}
+	close(f.wake)
+	f.wake = make(chan struct{})
+}
+
+// lastSequence returns the sequence number of the last event published.
+func (f *feed) lastSequence() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.last
+}
+
+// since returns the events after the sequence number, and a channel that is
+// closed when more are published.
+func (f *feed) since(sequence uint64) ([]*models.PostEvent, <-chan struct{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	oldest := f.last + 1 - uint64(len(f.history))
+	if sequence > f.last || sequence+1 < oldest {
+		return nil, nil, models.ErrSequenceExpired
+	}
+	return f.history[sequence+1-oldest:], f.wake, nil
+}
+
+// watch calls fn with every event that matches the request, in order, until
+// the context is done or fn fails, and returns the reason it stopped.
+func (f *feed) watch(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	after := req.SinceSequence
+	if after == 0 {
+		after = f.lastSequence()
+	}
+
+	for {
+		events, wake, err := f.since(after)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			after = event.Sequence
+			if matchEvent(req, event) {
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// matchEvent reports whether the post had the author and tag asked for,
+// before or after the change, so that a watcher also learns about posts
+// that stop matching.
+func matchEvent(req *models.WatchPostsRequest, event *models.PostEvent) bool {
+	match := func(post *models.BlogPost) bool {
+		if post == nil {
+			return false
+		}
+		if req.AuthorId != "" && post.AuthorId != req.AuthorId {
+			return false
+		}
+		return req.Tag == "" || hasTag(post.Tags, req.Tag)
+	}
+	return match(event.Post) || match(event.Previous)
+}
+
+// postEvent returns the event for a post that changed from before to after,
+// either of which is nil when the post did not or does not exist, or nil if
+// the change cannot be seen through GetPost. Posts in the trash count as
+// missing, so trashing a post deletes it and restoring it creates it again.
+func postEvent(before, after *models.BlogPost, now time.Time) *models.PostEvent {
+	visible := func(post *models.BlogPost) bool {
+		return post != nil && !post.Trashed()
+	}
+	event := &models.PostEvent{Post: after, Previous: before, Time: now}
+	switch {
+	case !visible(before) && visible(after):
+		event.Type = models.EventCreated
+		event.Previous = nil
+	case visible(before) && visible(after):
+		event.Type = models.EventUpdated
+	case visible(before):
+		event.Type = models.EventDeleted
+		event.Post = before
+	default:
+		return nil
+	}
+	event.PostId = event.Post.PostId
+	return event
+}
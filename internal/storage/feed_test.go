@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+func TestFeed_HistoryIsTrimmed(t *testing.T) {
+	f := newFeed(3)
+	start := f.last
+	for i := 0; i < 5; i++ {
+		f.publish([]*models.PostEvent{{PostId: "p1"}})
+	}
+	if len(f.history) != 3 || f.last != start+5 {
+		t.Fatalf("expected the last 3 of 5 events, got %d ending at %d", len(f.history), f.last-start)
+	}
+
+	// Resuming after the newest dropped event still reads every event kept
+	events, _, err := f.since(start + 2)
+	if err != nil || len(events) != 3 || events[0].Sequence != start+3 {
+		t.Errorf("expected 3 events from sequence %d, got %+v, %v", start+3, events, err)
+	}
+	if _, _, err := f.since(start + 1); !errors.Is(err, models.ErrSequenceExpired) {
+		t.Errorf("expected ErrSequenceExpired once an event is dropped, got: %v", err)
+	}
+	if events, _, err := f.since(f.last); err != nil || len(events) != 0 {
+		t.Errorf("expected no events after the last one, got %+v, %v", events, err)
+	}
+}
+
+func TestPostEvent(t *testing.T) {
+	now := time.Now()
+	live := &models.BlogPost{PostId: "p1"}
+	trashed := &models.BlogPost{PostId: "p1", DeletedAt: now}
+
+	tests := []struct {
+		name          string
+		before, after *models.BlogPost
+		want          models.EventType
+	}{
+		{"create", nil, live, models.EventCreated},
+		{"restore", trashed, live, models.EventCreated},
+		{"update", live, live, models.EventUpdated},
+		{"trash", live, trashed, models.EventDeleted},
+		{"delete", live, nil, models.EventDeleted},
+	}
+	for _, tt := range tests {
+		event := postEvent(tt.before, tt.after, now)
+		if event == nil || event.Type != tt.want || event.PostId != "p1" || event.Post == nil {
+			t.Errorf("%s: expected a %s event, got %+v", tt.name, tt.want, event)
+		}
+	}
+	if event := postEvent(trashed, nil, now); event != nil {
+		t.Errorf("expected no event for purging a trashed post, got %+v", event)
+	}
+}
@@ -0,0 +1,411 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// SyncPolicy controls when the write-ahead log is flushed to disk.
+type SyncPolicy int
+
+const (
+	// SyncAlways fsyncs the log before every mutation returns. Nothing that
+	// was acknowledged is lost on a crash.
+	SyncAlways SyncPolicy = iota
+
+	// SyncInterval fsyncs the log in the background every SyncInterval. A
+	// crash can lose the mutations of the last interval.
+	SyncInterval
+
+	// SyncNever leaves flushing to the operating system.
+	SyncNever
+)
+
+// ParseSyncPolicy parses "always", "interval" or "never".
+func ParseSyncPolicy(s string) (SyncPolicy, error) {
+	switch s {
+	case "always":
+		return SyncAlways, nil
+	case "interval":
+		return SyncInterval, nil
+	case "never":
+		return SyncNever, nil
+	}
+	return 0, fmt.Errorf("unknown sync policy %q", s)
+}
+
+const (
+	DefaultSyncInterval      = time.Second
+	DefaultSnapshotInterval  = 5 * time.Minute
+	DefaultSnapshotThreshold = 10000
+
+	snapshotFileName = "snapshot.json"
+)
+
+// FileStorageOptions configures a FileBlogStorage.
+type FileStorageOptions struct {
+	// Dir holds the snapshot and the write-ahead log segments.
+	Dir string
+
+	// SyncPolicy controls when the log is flushed to disk.
+	SyncPolicy SyncPolicy
+
+	// SyncInterval is the flush period for SyncInterval. Defaults to
+	// DefaultSyncInterval.
+	SyncInterval time.Duration
+
+	// SnapshotInterval is how often a snapshot is taken and the log
+	// compacted. Zero uses DefaultSnapshotInterval, negative disables
+	// periodic snapshots.
+	SnapshotInterval time.Duration
+
+	// SnapshotThreshold triggers a snapshot once this many records have been
+	// logged since the last one. Zero uses DefaultSnapshotThreshold, negative
+	// disables it.
+	SnapshotThreshold int
+}
+
+// FileBlogStorage is a durable BlogStorage. It serves reads from an
+// in-memory BlogStorageImpl and appends every mutation to a write-ahead log
+// before applying it. On startup the latest snapshot is loaded and the log
+// replayed on top of it. Snapshots are taken periodically, after which the
+// log segments they cover are deleted.
+type FileBlogStorage struct {
+	*BlogStorageImpl
+
+	opts FileStorageOptions
+	wal  *wal
+
+	// seq is the sequence number of the last logged record and
+	// sinceSnapshot the number of records logged since the last snapshot.
+	// Both are guarded by the write lock of BlogStorageImpl.
+	seq           uint64
+	sinceSnapshot int
+
+	// snapshotMu ensures only one snapshot runs at a time.
+	snapshotMu sync.Mutex
+
+	compact   chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// snapshotFile is the on-disk format of a snapshot. Seq is the sequence
+// number of the last log record it includes. Revisions are grouped by post,
+// oldest first.
+type snapshotFile struct {
+	Seq       uint64                   `json:"seq"`
+	Posts     []*models.BlogPost       `json:"posts"`
+	Revisions []*models.PostRevision   `json:"revisions,omitempty"`
+	Authors   []*models.Author         `json:"authors,omitempty"`
+	Tags      []*models.Tag            `json:"tags,omitempty"`
+	Keys      []*models.IdempotencyKey `json:"idempotency_keys,omitempty"`
+}
+
+// NewFileBlogStorage opens or creates a file-backed storage in opts.Dir,
+// recovering its state from the snapshot and the write-ahead log.
+func NewFileBlogStorage(opts FileStorageOptions) (*FileBlogStorage, error) {
+	if opts.Dir == "" {
+		return nil, errors.New("file storage: directory is required")
+	}
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = DefaultSyncInterval
+	}
+	if opts.SnapshotInterval == 0 {
+		opts.SnapshotInterval = DefaultSnapshotInterval
+	}
+	if opts.SnapshotThreshold == 0 {
+		opts.SnapshotThreshold = DefaultSnapshotThreshold
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("file storage: %w", err)
+	}
+
+	s := &FileBlogStorage{
+		BlogStorageImpl: NewBlogStorage(),
+		opts:            opts,
+		compact:         make(chan struct{}, 1),
+		done:            make(chan struct{}),
+	}
+	if err := s.recover(); err != nil {
+		return nil, fmt.Errorf("file storage: %w", err)
+	}
+	s.BlogStorageImpl.commit = s.commit
+
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// recover loads the snapshot, replays the log and opens the last segment for
+// appending.
+func (s *FileBlogStorage) recover() error {
+	raw, err := os.ReadFile(filepath.Join(s.opts.Dir, snapshotFileName))
+	switch {
+	case err == nil:
+		var snap snapshotFile
+		if err := json.Unmarshal(raw, &snap); err != nil {
+			return fmt.Errorf("reading snapshot: %w", err)
+		}
+		for _, post := range snap.Posts {
+			upgradePost(post)
+			s.posts[post.PostId] = post
+			s.indexPost(post)
+		}
+		for _, r := range snap.Revisions {
+			s.revisions[r.PostId] = append(s.revisions[r.PostId], r)
+		}
+		for _, author := range snap.Authors {
+			s.authors[author.AuthorId] = author
+		}
+		for _, tag := range snap.Tags {
+			s.tags[tag.TagId] = tag
+			s.tagIds[tag.Name] = tag.TagId
+			s.tagNames.add(tag.Name)
+		}
+		for _, key := range snap.Keys {
+			s.idempotencyKeys[key.RequestId] = key
+		}
+		s.seq = snap.Seq
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	segments, err := walSegments(s.opts.Dir)
+	if err != nil {
+		return err
+	}
+	for i, path := range segments {
+		offset, err := readWALSegment(path, func(record walRecord) error {
+			if record.Seq <= s.seq {
+				// Already part of the snapshot
+				return nil
+			}
+			if record.Seq != s.seq+1 {
+				return fmt.Errorf("%w: expected record %d, found %d in %s", errCorruptWAL, s.seq+1, record.Seq, path)
+			}
+			for _, c := range record.Changes {
+				upgradePost(c.Post)
+			}
+			s.applyChanges(record.Changes)
+			s.seq = record.Seq
+			s.sinceSnapshot++
+			return nil
+		})
+		if errors.Is(err, errTornWAL) && i == len(segments)-1 {
+			// A torn write at the tail of the log never completed, so it was
+			// never acknowledged. Drop it and carry on from there.
+			log.Warnf("Discarding incomplete write-ahead log record at %s:%d", path, offset)
+			if err := os.Truncate(path, offset); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	path := filepath.Join(s.opts.Dir, walSegmentName(s.seq+1))
+	if len(segments) > 0 {
+		path = segments[len(segments)-1]
+	}
+	if s.wal, err = openWAL(s.opts.Dir, path); err != nil {
+		return err
+	}
+
+	// Posts written before revision history start with their current state,
+	// and tags used before the tag registry are registered. Log the changes
+	// so later records always build on them.
+	var backfill []change
+	for id, post := range s.posts {
+		if len(s.revisions[id]) == 0 {
+			backfill = append(backfill, change{Op: opAddRevision, PostId: id, Revision: models.NewPostRevision(post, "")})
+		}
+	}
+	backfill = append(backfill, s.registerTags(postTags(s.posts), time.Now())...)
+	if len(backfill) > 0 {
+		if err := s.commit(backfill); err != nil {
+			s.wal.close()
+			return err
+		}
+		s.applyChanges(backfill)
+	}
+	return nil
+}
+
+// upgradePost fills in fields missing from posts written by older versions of
+// the server. Posts logged before versioning start at version 1, and posts
+// logged before the publishing lifecycle are published.
+func upgradePost(post *models.BlogPost) {
+	if post == nil {
+		return
+	}
+	if post.Version == 0 {
+		post.Version = 1
+	}
+	if post.Status == "" {
+		post.Status = models.StatusPublished
+	}
+}
+
+// commit logs the changes of one mutation. It is called by BlogStorageImpl
+// with the write lock held.
+func (s *FileBlogStorage) commit(changes []change) error {
+	record := walRecord{Seq: s.seq + 1, Changes: changes}
+	if err := s.wal.append(record, s.opts.SyncPolicy == SyncAlways); err != nil {
+		return fmt.Errorf("write-ahead log: %w", err)
+	}
+	s.seq = record.Seq
+	s.sinceSnapshot++
+	if s.opts.SnapshotThreshold > 0 && s.sinceSnapshot >= s.opts.SnapshotThreshold {
+		select {
+		case s.compact <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// run flushes the log and takes snapshots in the background until Close.
+func (s *FileBlogStorage) run() {
+	defer s.wg.Done()
+
+	var syncC, snapshotC <-chan time.Time
+	if s.opts.SyncPolicy == SyncInterval {
+		ticker := time.NewTicker(s.opts.SyncInterval)
+		defer ticker.Stop()
+		syncC = ticker.C
+	}
+	if s.opts.SnapshotInterval > 0 {
+		ticker := time.NewTicker(s.opts.SnapshotInterval)
+		defer ticker.Stop()
+		snapshotC = ticker.C
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-syncC:
+			if err := s.wal.sync(); err != nil {
+				log.Errorf("Failed to sync write-ahead log: %v", err)
+			}
+		case <-snapshotC:
+			if err := s.Snapshot(); err != nil {
+				log.Errorf("Failed to snapshot storage: %v", err)
+			}
+		case <-s.compact:
+			if err := s.Snapshot(); err != nil {
+				log.Errorf("Failed to snapshot storage: %v", err)
+			}
+		}
+	}
+}
+
+// Snapshot writes the current state to disk and deletes the log segments it
+// covers. Writers are only blocked while the post map is copied; the snapshot
+// itself is written without holding the storage lock.
+func (s *FileBlogStorage) Snapshot() error {
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	s.mu.Lock()
+	if s.sinceSnapshot == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	// Stored posts, revisions, authors, tags and idempotency keys are never
+	// modified in place, so copying the pointers is enough to capture a
+	// consistent state.
+	snap := snapshotFile{Seq: s.seq, Posts: make([]*models.BlogPost, 0, len(s.posts))}
+	for id, post := range s.posts {
+		snap.Posts = append(snap.Posts, post)
+		snap.Revisions = append(snap.Revisions, s.revisions[id]...)
+	}
+	for _, author := range s.authors {
+		snap.Authors = append(snap.Authors, author)
+	}
+	for _, tag := range s.tags {
+		snap.Tags = append(snap.Tags, tag)
+	}
+	for _, key := range s.idempotencyKeys {
+		snap.Keys = append(snap.Keys, key)
+	}
+	err := s.wal.rotate(s.seq + 1)
+	if err == nil {
+		s.sinceSnapshot = 0
+	}
+	current := s.wal.currentSegment()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := writeSnapshot(s.opts.Dir, &snap); err != nil {
+		return err
+	}
+
+	// Every segment before the current one only holds records up to
+	// snap.Seq, which are now covered by the snapshot.
+	segments, err := walSegments(s.opts.Dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range segments {
+		if path == current {
+			break
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return syncDir(s.opts.Dir)
+}
+
+// writeSnapshot atomically replaces the snapshot file.
+func writeSnapshot(dir string, snap *snapshotFile) error {
+	tmp, err := os.CreateTemp(dir, snapshotFileName+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(snap); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, snapshotFileName)); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// Close stops the background work and flushes the log. The storage must not
+// be used afterwards.
+func (s *FileBlogStorage) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		err = s.wal.close()
+	})
+	return err
+}
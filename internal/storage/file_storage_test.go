@@ -0,0 +1,358 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+)
+
+func openFileStorage(t *testing.T, dir string, opts FileStorageOptions) *FileBlogStorage {
+	t.Helper()
+	opts.Dir = dir
+	s, err := NewFileBlogStorage(opts)
+	if err != nil {
+		t.Fatalf("NewFileBlogStorage failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestFileBlogStorage_ReplayAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := openFileStorage(t, dir, FileStorageOptions{SyncPolicy: SyncNever})
+	for _, id := range []string{"p1", "p2", "p3"} {
+		if err := s.CreatePost(ctx, &models.BlogPost{PostId: id, Title: id}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "updated"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p2"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	got, err := reopened.GetPost(ctx, "p1")
+	if err != nil || got.Title != "updated" {
+		t.Errorf("expected updated p1 after replay, got: %+v, %v", got, err)
+	}
+	if _, err := reopened.GetPost(ctx, "p2"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected p2 to stay deleted, got: %v", err)
+	}
+	if _, err := reopened.GetPost(ctx, "p3"); err != nil {
+		t.Errorf("expected p3 after replay, got: %v", err)
+	}
+}
+
+func TestFileBlogStorage_SnapshotCompactsLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := openFileStorage(t, dir, FileStorageOptions{SnapshotInterval: -1, SnapshotThreshold: -1})
+	for _, id := range []string{"p1", "p2"} {
+		if err := s.CreatePost(ctx, &models.BlogPost{PostId: id, Title: "Before the snapshot"}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	if err := s.Snapshot(); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p3", Title: "After the snapshot"}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+
+	segments, err := walSegments(dir)
+	if err != nil {
+		t.Fatalf("walSegments failed: %v", err)
+	}
+	if len(segments) != 1 || filepath.Base(segments[0]) != walSegmentName(3) {
+		t.Errorf("expected only the post-snapshot segment to remain, got: %v", segments)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	// Posts from the snapshot and from the log are both searchable
+	if results, _, err := reopened.SearchPosts(ctx, &models.SearchPostsRequest{Query: "snapshot"}); err != nil || len(results) != 3 {
+		t.Errorf("expected to find 3 posts after restart, got %d, %v", len(results), err)
+	}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		if _, err := reopened.GetPost(ctx, id); err != nil {
+			t.Errorf("expected %s after restart, got: %v", id, err)
+		}
+		// Revisions from the snapshot and from the log are both restored
+		if revisions, err := reopened.ListRevisions(ctx, id); err != nil || len(revisions) != 1 {
+			t.Errorf("expected one revision of %s after restart, got %d, %v", id, len(revisions), err)
+		}
+	}
+}
+
+func TestFileBlogStorage_AuthorsSurviveRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := openFileStorage(t, dir, FileStorageOptions{SnapshotInterval: -1, SnapshotThreshold: -1})
+	if err := s.CreateAuthor(ctx, &models.Author{AuthorId: "a1", Name: "First", ProfileName: "first"}); err != nil {
+		t.Fatalf("CreateAuthor failed: %v", err)
+	}
+	if err := s.Snapshot(); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if err := s.CreateAuthor(ctx, &models.Author{AuthorId: "a2", Name: "Second", ProfileName: "second"}); err != nil {
+		t.Fatalf("CreateAuthor failed: %v", err)
+	}
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p1", AuthorId: "a1"}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	if _, err := s.UpdateAuthor(ctx, &models.UpdateAuthorRequest{AuthorId: "a1", Name: "Renamed"}); err != nil {
+		t.Fatalf("UpdateAuthor failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// a1 comes from the snapshot, a2 and the rename from the log
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	for id, name := range map[string]string{"a1": "Renamed", "a2": "Second"} {
+		if got, err := reopened.GetAuthor(ctx, id); err != nil || got.Name != name {
+			t.Errorf("expected author %s named %q after restart, got %+v, %v", id, name, got, err)
+		}
+	}
+	if got, err := reopened.GetPost(ctx, "p1"); err != nil || got.Author != "Renamed" {
+		t.Errorf("expected p1 by Renamed after restart, got %+v, %v", got, err)
+	}
+}
+
+func TestFileBlogStorage_IdempotencyKeysSurviveRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	expiresAt := time.Now().Add(time.Hour)
+
+	s := openFileStorage(t, dir, FileStorageOptions{SnapshotInterval: -1, SnapshotThreshold: -1})
+	createOnce := func(s *FileBlogStorage, postId, requestId string) (*models.BlogPost, bool, error) {
+		return s.CreatePostOnce(ctx, &models.BlogPost{PostId: postId}, &models.IdempotencyKey{RequestId: requestId, Fingerprint: "f", ExpiresAt: expiresAt})
+	}
+	if _, _, err := createOnce(s, "p1", "r1"); err != nil {
+		t.Fatalf("CreatePostOnce failed: %v", err)
+	}
+	if err := s.Snapshot(); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if _, _, err := createOnce(s, "p2", "r2"); err != nil {
+		t.Fatalf("CreatePostOnce failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// r1 comes from the snapshot, r2 from the log
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	for requestId, postId := range map[string]string{"r1": "p1", "r2": "p2"} {
+		if got, created, err := createOnce(reopened, "retry", requestId); err != nil || created || got.PostId != postId {
+			t.Errorf("expected %s to return %s after restart, got %+v, %v, %v", requestId, postId, got, created, err)
+		}
+	}
+}
+
+func TestFileBlogStorage_TagsSurviveRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	// A snapshot written before the tag registry
+	legacy := `{"seq":1,"posts":[{"post_id":"p1","title":"old","content":"","author":"","publication_date":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","tags":["golang","sql"],"version":1}]}`
+	if err := os.WriteFile(filepath.Join(dir, snapshotFileName), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := openFileStorage(t, dir, FileStorageOptions{SnapshotInterval: -1, SnapshotThreshold: -1})
+	tags, _, err := s.ListTags(ctx, &models.ListTagsRequest{})
+	if err != nil || len(tags) != 2 || tags[0].Name != "golang" || tags[0].UsageCount != 1 {
+		t.Fatalf("expected the legacy tags to be registered, got %+v, %v", tags, err)
+	}
+	if _, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: tags[0].TagId, Name: "go"}); err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// The registered tags keep their IDs, and the rename is replayed
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	if got, err := reopened.GetTag(ctx, tags[0].TagId); err != nil || got.Name != "go" || got.Slug != "go" {
+		t.Errorf("expected the renamed tag after restart, got %+v, %v", got, err)
+	}
+	if got, err := reopened.GetTag(ctx, tags[1].TagId); err != nil || got.Name != "sql" {
+		t.Errorf("expected the other tag after restart, got %+v, %v", got, err)
+	}
+	if got, err := reopened.GetPost(ctx, "p1"); err != nil || len(got.Tags) != 2 || got.Tags[0] != "go" {
+		t.Errorf("expected p1 tagged go after restart, got %+v, %v", got, err)
+	}
+	if got, err := reopened.SuggestTags(ctx, &models.SuggestTagsRequest{Prefix: "g"}); err != nil || len(got) != 1 || got[0].Tag.Name != "go" {
+		t.Errorf("expected go to be suggested after restart, got %+v, %v", got, err)
+	}
+}
+
+func TestFileBlogStorage_SnapshotThreshold(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := openFileStorage(t, dir, FileStorageOptions{SnapshotInterval: -1, SnapshotThreshold: 1})
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p1"}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	// The write should trigger a snapshot in the background
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("snapshot was not written after reaching the threshold")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	if _, err := reopened.GetPost(ctx, "p1"); err != nil {
+		t.Errorf("expected p1 after restart, got: %v", err)
+	}
+}
+
+func TestFileBlogStorage_TornTailIsDiscarded(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := openFileStorage(t, dir, FileStorageOptions{})
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p1"}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Simulate a crash in the middle of writing the next record
+	segments, _ := walSegments(dir)
+	f, err := os.OpenFile(segments[len(segments)-1], os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("open segment failed: %v", err)
+	}
+	f.Write([]byte{42, 0, 0, 0, 1, 2})
+	f.Close()
+
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	if _, err := reopened.GetPost(ctx, "p1"); err != nil {
+		t.Errorf("expected p1 to survive, got: %v", err)
+	}
+	if err := reopened.CreatePost(ctx, &models.BlogPost{PostId: "p2"}); err != nil {
+		t.Fatalf("CreatePost after recovery failed: %v", err)
+	}
+	if err := reopened.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	again := openFileStorage(t, dir, FileStorageOptions{})
+	if _, err := again.GetPost(ctx, "p2"); err != nil {
+		t.Errorf("expected p2 written after recovery to replay, got: %v", err)
+	}
+}
+
+func TestFileBlogStorage_CorruptionIsNotDiscarded(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		corrupt func(segment []byte, second, third int) []byte
+	}{
+		{"checksum", func(segment []byte, second, third int) []byte {
+			segment[second+walHeaderSize+1] ^= 0xff
+			return segment
+		}},
+		{"sequence gap", func(segment []byte, second, third int) []byte {
+			return append(segment[:second:second], segment[third:]...)
+		}},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		s := openFileStorage(t, dir, FileStorageOptions{})
+		for _, id := range []string{"p1", "p2", "p3"} {
+			if err := s.CreatePost(ctx, &models.BlogPost{PostId: id}); err != nil {
+				t.Fatalf("CreatePost failed: %v", err)
+			}
+		}
+		if err := s.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		// Damage the second of the three records of the active segment
+		segments, _ := walSegments(dir)
+		path := segments[len(segments)-1]
+		segment, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read segment failed: %v", err)
+		}
+		recordEnd := func(offset int) int {
+			return offset + walHeaderSize + int(binary.LittleEndian.Uint32(segment[offset:]))
+		}
+		second := recordEnd(0)
+		damaged := tt.corrupt(segment, second, recordEnd(second))
+		if err := os.WriteFile(path, damaged, 0o644); err != nil {
+			t.Fatalf("write segment failed: %v", err)
+		}
+
+		if _, err := NewFileBlogStorage(FileStorageOptions{Dir: dir}); !errors.Is(err, errCorruptWAL) {
+			t.Errorf("%s: expected recovery to fail with errCorruptWAL, got: %v", tt.name, err)
+		}
+		if info, err := os.Stat(path); err != nil || info.Size() != int64(len(damaged)) {
+			t.Errorf("%s: expected the segment to be left as it was, got %v, %v", tt.name, info, err)
+		}
+	}
+}
+
+func TestFileBlogStorage_LegacyPostsStartAtVersion1(t *testing.T) {
+	dir := t.TempDir()
+	// A snapshot written before posts had versions
+	legacy := `{"seq":1,"posts":[{"post_id":"p1","title":"old","content":"","author":"","publication_date":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","tags":null}]}`
+	if err := os.WriteFile(filepath.Join(dir, snapshotFileName), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := openFileStorage(t, dir, FileStorageOptions{})
+	got, err := s.GetPost(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.Version != 1 {
+		t.Errorf("expected legacy post at version 1, got %d", got.Version)
+	}
+
+	// The current state becomes the first revision, and stays so after a
+	// restart even though no snapshot was taken
+	if _, err := s.UpdatePost(context.Background(), &models.UpdateBlogPostRequest{PostId: "p1", Title: "new"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	reopened := openFileStorage(t, dir, FileStorageOptions{})
+	revisions, err := reopened.ListRevisions(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("ListRevisions failed: %v", err)
+	}
+	if len(revisions) != 2 || revisions[1].Revision != 1 || revisions[1].Title != "old" {
+		t.Errorf("expected the legacy state as revision 1, got: %+v", revisions)
+	}
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/search"
+)
+
+// postIndex maps a key, such as an author ID or a tag, to the IDs of the
+// posts that have it. Keys without posts are removed, so the size of the
+// index stays proportional to the number of distinct keys in use.
+type postIndex map[string]map[string]struct{}
+
+func (idx postIndex) add(key, postId string) {
+	ids, exists := idx[key]
+	if !exists {
+		ids = make(map[string]struct{})
+		idx[key] = ids
+	}
+	ids[postId] = struct{}{}
+}
+
+func (idx postIndex) remove(key, postId string) {
+	ids, exists := idx[key]
+	if !exists {
+		return
+	}
+	delete(ids, postId)
+	if len(ids) == 0 {
+		delete(idx, key)
+	}
+}
+
+// indexPost adds a post to the author and tag indexes and, if it is
+// searchable, to the full-text index. The caller must hold the write lock.
+func (s *BlogStorageImpl) indexPost(post *models.BlogPost) {
+	if post.AuthorId != "" {
+		s.byAuthor.add(post.AuthorId, post.PostId)
+	}
+	for _, tag := range post.Tags {
+		s.byTag.add(tag, post.PostId)
+	}
+	if searchable(post) {
+		s.fullText.Add(searchDocument(post))
+	}
+}
+
+// unindexPost removes a post from every index. The caller must hold the
+// write lock.
+func (s *BlogStorageImpl) unindexPost(post *models.BlogPost) {
+	if post.AuthorId != "" {
+		s.byAuthor.remove(post.AuthorId, post.PostId)
+	}
+	for _, tag := range post.Tags {
+		s.byTag.remove(tag, post.PostId)
+	}
+	s.fullText.Remove(post.PostId)
+}
+
+// searchable reports whether a post belongs in the full-text index: only
+// published posts outside the trash can be found by searching.
+func searchable(post *models.BlogPost) bool {
+	return post.Status == models.StatusPublished && !post.Trashed()
+}
+
+func searchDocument(post *models.BlogPost) search.Document {
+	return search.Document{Id: post.PostId, Title: post.Title, Content: post.Content, Tags: post.Tags}
+}
+
+// indexedPosts returns the posts filed under key in the index. The caller
+// must hold the lock.
+func (s *BlogStorageImpl) indexedPosts(idx postIndex, key string) []*models.BlogPost {
+	ids := idx[key]
+	posts := make([]*models.BlogPost, 0, len(ids))
+	for id := range ids {
+		posts = append(posts, s.posts[id])
+	}
+	return posts
+}
+
+// listIndexed returns the page selected by the query among the posts filed
+// under key in the index, copied for the caller. The caller must hold the
+// lock.
+func (s *BlogStorageImpl) listIndexed(q *listQuery, idx postIndex, key string) ([]*models.BlogPost, string) {
+	posts := s.indexedPosts(idx, key)
+	matched := posts[:0]
+	for _, post := range posts {
+		if q.match(post) {
+			matched = append(matched, post)
+		}
+	}
+	page, next := q.paginate(matched)
+	for i, post := range page {
+		page[i] = post.Clone()
+	}
+	return page, next
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/search"
+)
+
+// rebuiltIndexes indexes the posts of the store from scratch.
+func rebuiltIndexes(s *BlogStorageImpl) (byAuthor, byTag postIndex) {
+	rebuilt := &BlogStorageImpl{posts: s.posts, byAuthor: make(postIndex), byTag: make(postIndex), fullText: search.NewIndex()}
+	for _, post := range s.posts {
+		rebuilt.indexPost(post)
+	}
+	return rebuilt.byAuthor, rebuilt.byTag
+}
+
+func TestBlogStorageImpl_IndexesFollowWrites(t *testing.T) {
+	ctx := context.Background()
+	s := NewBlogStorage()
+	if err := s.CreateAuthor(ctx, &models.Author{AuthorId: "a1", Name: "A", ProfileName: "a"}); err != nil {
+		t.Fatalf("CreateAuthor failed: %v", err)
+	}
+	check := func(step string) {
+		t.Helper()
+		byAuthor, byTag := rebuiltIndexes(s)
+		if !reflect.DeepEqual(s.byAuthor, byAuthor) || !reflect.DeepEqual(s.byTag, byTag) {
+			t.Errorf("%s: indexes out of sync, got %v %v, want %v %v", step, s.byAuthor, s.byTag, byAuthor, byTag)
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		post := &models.BlogPost{PostId: fmt.Sprint(i), Tags: []string{"go", fmt.Sprint("t", i%2)}}
+		if i%2 == 0 {
+			post.AuthorId = "a1"
+		}
+		if err := s.CreatePost(ctx, post); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	check("create")
+
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "0", Tags: []string{"rust"}}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	check("update")
+
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "1"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "2"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	check("delete")
+
+	tags := make(map[string]string)
+	for id, tag := range s.tags {
+		tags[tag.Name] = id
+	}
+	if _, err := s.MergeTags(ctx, &models.MergeTagsRequest{SourceTagId: tags["t1"], TargetTagId: tags["go"]}); err != nil {
+		t.Fatalf("MergeTags failed: %v", err)
+	}
+	check("merge")
+	if _, ok := s.byTag["t1"]; ok {
+		t.Errorf("expected the merged tag to leave the index")
+	}
+}
+
+// The benchmarks below compare listing the posts of one tag or author
+// through the index with filtering every post. The tag and the author always
+// match the same number of posts, so the indexed lookups should take about
+// the same time whatever the size of the store, while the scan grows with it:
+//
+//	go test -run XXX -bench 'ByTag|ByAuthor' ./internal/storage
+
+const benchMatches = 10
+
+var benchSizes = []int{1_000, 10_000, 100_000}
+
+// newBenchStorage creates a store of n published posts, benchMatches of
+// which are tagged "needle" and written by the author "needle".
+func newBenchStorage(b *testing.B, n int) *BlogStorageImpl {
+	b.Helper()
+	ctx := context.Background()
+	s := NewBlogStorage()
+	if err := s.CreateAuthor(ctx, &models.Author{AuthorId: "needle", Name: "Needle", ProfileName: "needle"}); err != nil {
+		b.Fatalf("CreateAuthor failed: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		tag, author := "hay", ""
+		if i%(n/benchMatches) == 0 {
+			tag, author = "needle", "needle"
+		}
+		post := &models.BlogPost{PostId: fmt.Sprint(i), Title: "t", AuthorId: author, Tags: []string{tag, fmt.Sprint("t", i%100)}}
+		if err := s.CreatePost(ctx, post); err != nil {
+			b.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	return s
+}
+
+func BenchmarkListPostsByTag(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			s := newBenchStorage(b, n)
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				page, _, err := s.ListPostsByTag(ctx, &models.ListPostsByTagRequest{Tag: "needle"})
+				if err != nil || len(page) != benchMatches {
+					b.Fatalf("expected %d posts, got %d, %v", benchMatches, len(page), err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkListPostsByAuthor(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			s := newBenchStorage(b, n)
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				page, _, err := s.ListPostsByAuthor(ctx, &models.ListPostsByAuthorRequest{AuthorId: "needle"})
+				if err != nil || len(page) != benchMatches {
+					b.Fatalf("expected %d posts, got %d, %v", benchMatches, len(page), err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkListPostsFilteredByTag(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			s := newBenchStorage(b, n)
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				page, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{Filter: `tags:"needle"`})
+				if err != nil || len(page) != benchMatches {
+					b.Fatalf("expected %d posts, got %d, %v", benchMatches, len(page), err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/query"
+	"github.com/pandae7/go-blogger/internal/search"
+)
+
+const (
+	// DefaultPageSize is used when a list request does not set a page size.
+	DefaultPageSize = 50
+
+	// MaxPageSize caps the number of posts returned in a single page.
+	MaxPageSize = 1000
+)
+
+// pageToken is the cursor handed out to clients. It records the sort key of
+// the last post in a page, so the next page starts strictly after it no matter
+// how many posts were created or deleted in between. Query is a fingerprint of
+// the filter and order the token was issued for.
+type pageToken struct {
+	Query           uint64    `json:"q"`
+	PostId          string    `json:"i"`
+	Title           string    `json:"t,omitempty"`
+	Author          string    `json:"a,omitempty"`
+	PublicationDate time.Time `json:"d"`
+	UpdatedAt       time.Time `json:"u"`
+}
+
+// listQuery is a parsed and validated list request.
+type listQuery struct {
+	filter      query.Expr
+	showDeleted bool
+	// published limits the results to published posts. It is set unless the
+	// filter mentions the status.
+	published   bool
+	order       *query.OrderBy
+	size        int
+	fingerprint uint64
+	after       *models.BlogPost
+}
+
+func parseListRequest(req *models.ListBlogPostsRequest) (*listQuery, error) {
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := query.Parse(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	order, err := query.ParseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &listQuery{
+		filter:      filter,
+		showDeleted: req.ShowDeleted,
+		published:   !query.References(filter, "status"),
+		order:       order,
+		size:        size,
+	}
+	h := fnv.New64a()
+	h.Write([]byte(req.Filter))
+	h.Write([]byte{0})
+	h.Write([]byte(order.String()))
+	if req.ShowDeleted {
+		h.Write([]byte{1})
+	}
+	q.fingerprint = h.Sum64()
+
+	if err := q.resume(req.PageToken); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// parseScopedListRequest parses a request for one page of the published
+// posts of a single author or tag, newest first. The scope names the author
+// or tag, so that a page token only resumes the listing it was issued for.
+func parseScopedListRequest(scope string, size int, token string) (*listQuery, error) {
+	size, err := pageSize(size)
+	if err != nil {
+		return nil, err
+	}
+	order, err := query.ParseOrderBy("")
+	if err != nil {
+		return nil, err
+	}
+
+	q := &listQuery{published: true, order: order, size: size}
+	h := fnv.New64a()
+	h.Write([]byte(scope))
+	h.Write([]byte{0})
+	h.Write([]byte(order.String()))
+	q.fingerprint = h.Sum64()
+
+	if err := q.resume(token); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// parseExportRequest parses the filter of an export. Only its match method is
+// used: exports are neither sorted by the query nor paginated.
+func parseExportRequest(req *models.ExportPostsRequest) (*listQuery, error) {
+	filter, err := query.Parse(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &listQuery{filter: filter, showDeleted: req.ShowDeleted}, nil
+}
+
+// resume makes the query start after the last post of the page the token
+// was issued for.
+func (q *listQuery) resume(token string) error {
+	if token == "" {
+		return nil
+	}
+	cursor, err := decodePageToken(token)
+	if err != nil || cursor.Query != q.fingerprint {
+		return models.ErrInvalidPageToken
+	}
+	q.after = &models.BlogPost{
+		PostId:          cursor.PostId,
+		Title:           cursor.Title,
+		Author:          cursor.Author,
+		PublicationDate: cursor.PublicationDate,
+		UpdatedAt:       cursor.UpdatedAt,
+	}
+	return nil
+}
+
+// match reports whether the post passes the filter. Posts in the trash and
+// posts that are not published only match when they were asked for.
+func (q *listQuery) match(post *models.BlogPost) bool {
+	if post.Trashed() && !q.showDeleted {
+		return false
+	}
+	if q.published && post.Status != models.StatusPublished {
+		return false
+	}
+	return query.Match(q.filter, post)
+}
+
+func (q *listQuery) encodeToken(post *models.BlogPost) string {
+	cursor := pageToken{
+		Query:           q.fingerprint,
+		PostId:          post.PostId,
+		PublicationDate: post.PublicationDate,
+		UpdatedAt:       post.UpdatedAt,
+	}
+	// Only carry the string fields the order actually depends on
+	switch q.order.Field {
+	case "title":
+		cursor.Title = post.Title
+	case "author":
+		cursor.Author = post.Author
+	}
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodePageToken(token string) (*pageToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, models.ErrInvalidPageToken
+	}
+	var cursor pageToken
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.PostId == "" {
+		return nil, models.ErrInvalidPageToken
+	}
+	return &cursor, nil
+}
+
+// pageSize validates and normalizes the requested page size.
+func pageSize(size int) (int, error) {
+	switch {
+	case size < 0:
+		return 0, models.ErrInvalidPageSize
+	case size == 0:
+		return DefaultPageSize, nil
+	case size > MaxPageSize:
+		return MaxPageSize, nil
+	}
+	return size, nil
+}
+
+// paginate sorts the posts that already passed the filter and returns the
+// page selected by the query together with the token for the following page.
+func (q *listQuery) paginate(posts []*models.BlogPost) ([]*models.BlogPost, string) {
+	sort.Slice(posts, func(i, j int) bool {
+		return q.order.Less(posts[i], posts[j])
+	})
+
+	start := 0
+	if q.after != nil {
+		// Skip everything up to and including the last post of the previous page.
+		start = sort.Search(len(posts), func(i int) bool {
+			return q.order.Less(q.after, posts[i])
+		})
+	}
+
+	end := start + q.size
+	if end >= len(posts) {
+		return posts[start:], ""
+	}
+	page := posts[start:end]
+	return page, q.encodeToken(page[len(page)-1])
+}
+
+// searchPageToken is the cursor handed out for search results. It records the
+// score and ID of the last hit in a page. Query is a fingerprint of the search
+// query the token was issued for.
+type searchPageToken struct {
+	Query uint64  `json:"q"`
+	Score float64 `json:"s"`
+	Id    string  `json:"i"`
+}
+
+// searchQuery is a parsed and validated search request.
+type searchQuery struct {
+	query       *search.Query
+	size        int
+	fingerprint uint64
+	after       *search.Hit
+}
+
+func parseSearchRequest(req *models.SearchPostsRequest) (*searchQuery, error) {
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	parsed := search.ParseQuery(req.Query)
+	if parsed.Empty() {
+		return nil, models.ErrEmptyQuery
+	}
+
+	q := &searchQuery{query: parsed, size: size}
+	h := fnv.New64a()
+	h.Write([]byte(parsed.String()))
+	q.fingerprint = h.Sum64()
+
+	if req.PageToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, models.ErrInvalidPageToken
+		}
+		var cursor searchPageToken
+		if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Id == "" || cursor.Query != q.fingerprint {
+			return nil, models.ErrInvalidPageToken
+		}
+		q.after = &search.Hit{Id: cursor.Id, Score: cursor.Score}
+	}
+	return q, nil
+}
+
+// paginate returns the page of the hits, which are sorted best first,
+// selected by the query together with the token for the following page.
+// Scores move as posts are written, so a page may skip or repeat a post that
+// changed in between.
+func (q *searchQuery) paginate(hits []search.Hit) ([]search.Hit, string) {
+	start := 0
+	if q.after != nil {
+		start = sort.Search(len(hits), func(i int) bool {
+			return search.HitLess(*q.after, hits[i])
+		})
+	}
+
+	end := start + q.size
+	if end >= len(hits) {
+		return hits[start:], ""
+	}
+	page := hits[start:end]
+	last := page[len(page)-1]
+	raw, _ := json.Marshal(searchPageToken{Query: q.fingerprint, Score: last.Score, Id: last.Id})
+	return page, base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// namePageToken is the cursor handed out for listings sorted by name, such as
+// authors and tags. It records the sort key of the last item in a page.
+type namePageToken struct {
+	Name string `json:"n"`
+	Id   string `json:"i"`
+}
+
+// nameListQuery is a parsed and validated request for a listing sorted by
+// name.
+type nameListQuery struct {
+	size  int
+	after *namePageToken
+}
+
+func parseNameListRequest(size int, token string) (*nameListQuery, error) {
+	size, err := pageSize(size)
+	if err != nil {
+		return nil, err
+	}
+	q := &nameListQuery{size: size}
+	if token != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, models.ErrInvalidPageToken
+		}
+		var cursor namePageToken
+		if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Id == "" {
+			return nil, models.ErrInvalidPageToken
+		}
+		q.after = &cursor
+	}
+	return q, nil
+}
+
+// nameLess orders by case-insensitive name, then by ID.
+func nameLess(name, id, otherName, otherId string) bool {
+	name, otherName = strings.ToLower(name), strings.ToLower(otherName)
+	if name != otherName {
+		return name < otherName
+	}
+	return id < otherId
+}
+
+// paginateByName sorts the items by the name and ID returned by key and
+// returns the page selected by the query together with the token for the
+// following page.
+func paginateByName[T any](q *nameListQuery, items []T, key func(T) (string, string)) ([]T, string) {
+	sort.Slice(items, func(i, j int) bool {
+		name, id := key(items[i])
+		otherName, otherId := key(items[j])
+		return nameLess(name, id, otherName, otherId)
+	})
+
+	start := 0
+	if q.after != nil {
+		start = sort.Search(len(items), func(i int) bool {
+			name, id := key(items[i])
+			return nameLess(q.after.Name, q.after.Id, name, id)
+		})
+	}
+
+	end := start + q.size
+	if end >= len(items) {
+		return items[start:], ""
+	}
+	page := items[start:end]
+	name, id := key(page[len(page)-1])
+	raw, _ := json.Marshal(namePageToken{Name: name, Id: id})
+	return page, base64.RawURLEncoding.EncodeToString(raw)
+}
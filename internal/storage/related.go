@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/search"
+)
+
+const (
+	// DefaultRelatedLimit is used when a related posts request does not set
+	// a limit.
+	DefaultRelatedLimit = 5
+
+	// MaxRelatedLimit caps the number of related posts returned at once.
+	MaxRelatedLimit = 50
+
+	// relatedCandidates is the number of posts with the most similar text
+	// considered as related posts, on top of those sharing a tag.
+	relatedCandidates = 100
+
+	// relatedTagWeight and relatedTextWeight weigh the overlap of the tags
+	// and the similarity of the text in the score of a related post. Both
+	// parts range from 0 to 1, and so does the score.
+	relatedTagWeight  = 0.5
+	relatedTextWeight = 0.5
+)
+
+// relatedBoosts compare the title and the content of posts. Tags are left to
+// the Jaccard index.
+var relatedBoosts = search.Boosts{Title: 2, Content: 1}
+
+// relatedLimit validates and normalizes the requested number of related
+// posts.
+func relatedLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, models.ErrInvalidLimit
+	case limit == 0:
+		return DefaultRelatedLimit, nil
+	case limit > MaxRelatedLimit:
+		return MaxRelatedLimit, nil
+	}
+	return limit, nil
+}
+
+// relatedHits scores the candidates for posts related to post and returns the
+// best limit of them, best first, with the post ID as tiebreaker. similar
+// holds the posts with the most similar text, best first, and candidates the
+// tags of every candidate, by post ID. The score of a candidate combines the
+// Jaccard index of its tags and those of the post with the similarity of its
+// text relative to the most similar one.
+func relatedHits(post *models.BlogPost, similar []search.Hit, candidates map[string][]string, limit int) []search.Hit {
+	text := make(map[string]float64, len(similar))
+	if len(similar) > 0 && similar[0].Score > 0 {
+		for _, hit := range similar {
+			text[hit.Id] = hit.Score / similar[0].Score
+		}
+	}
+
+	hits := make([]search.Hit, 0, len(candidates))
+	for id, tags := range candidates {
+		if id == post.PostId {
+			continue
+		}
+		score := relatedTagWeight*jaccard(post.Tags, tags) + relatedTextWeight*text[id]
+		if score > 0 {
+			hits = append(hits, search.Hit{Id: id, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		return search.HitLess(hits[i], hits[j])
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// jaccard returns the size of the intersection of two sets of tags over the
+// size of their union, or 0 when both are empty.
+func jaccard(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[tag] = true
+	}
+	union := len(set)
+	shared := 0
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		if set[tag] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqlMigrations are applied in order on startup. Each entry is run once, in
+// its own transaction, and recorded in schema_migrations. Never edit an
+// existing migration; append a new one instead.
+var sqlMigrations = []string{
+	// 1: posts and their tags
+	`CREATE TABLE posts (
+		post_id          TEXT PRIMARY KEY,
+		title            TEXT NOT NULL,
+		content          TEXT NOT NULL,
+		author           TEXT NOT NULL,
+		publication_date TEXT NOT NULL,
+		updated_at       TEXT NOT NULL
+	);
+	CREATE INDEX posts_by_publication_date ON posts (publication_date DESC, post_id);
+	CREATE INDEX posts_by_author ON posts (author);
+
+	CREATE TABLE post_tags (
+		post_id  TEXT NOT NULL REFERENCES posts (post_id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		tag      TEXT NOT NULL,
+		PRIMARY KEY (post_id, position)
+	);
+	CREATE INDEX post_tags_by_tag ON post_tags (tag);`,
+
+	// 2: post versions for optimistic concurrency control
+	`ALTER TABLE posts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;`,
+
+	// 3: revision history, with tags stored as a JSON array. Existing posts
+	// start with their current state as the only revision.
+	`CREATE TABLE post_revisions (
+		post_id    TEXT NOT NULL REFERENCES posts (post_id) ON DELETE CASCADE,
+		revision   INTEGER NOT NULL,
+		title      TEXT NOT NULL,
+		content    TEXT NOT NULL,
+		tags       TEXT NOT NULL,
+		editor     TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		PRIMARY KEY (post_id, revision)
+	);
+	INSERT INTO post_revisions (post_id, revision, title, content, tags, editor, created_at)
+		SELECT p.post_id, p.version, p.title, p.content,
+			(SELECT json_group_array(tag) FROM (SELECT tag FROM post_tags t WHERE t.post_id = p.post_id ORDER BY position)),
+			'', p.updated_at
+		FROM posts p;`,
+
+	// 4: trash
+	`ALTER TABLE posts ADD COLUMN deleted_at TEXT;
+	CREATE INDEX posts_by_deleted_at ON posts (deleted_at) WHERE deleted_at IS NOT NULL;`,
+
+	// 5: publishing lifecycle. Existing posts are published; the index serves
+	// the scheduler's lookup of posts that are due.
+	`ALTER TABLE posts ADD COLUMN status TEXT NOT NULL DEFAULT 'published';
+	CREATE INDEX posts_by_scheduled_date ON posts (publication_date) WHERE status = 'scheduled';`,
+
+	// 6: authors. profile_key and email_key hold the normalized profile name
+	// and email that must be unique; email_key is NULL for authors without an
+	// email. Existing posts keep their free-form author name.
+	`CREATE TABLE authors (
+		author_id    TEXT PRIMARY KEY,
+		name         TEXT NOT NULL,
+		email        TEXT NOT NULL,
+		profile_name TEXT NOT NULL,
+		profile_key  TEXT NOT NULL UNIQUE,
+		email_key    TEXT UNIQUE,
+		created_at   TEXT NOT NULL,
+		updated_at   TEXT NOT NULL
+	);
+	ALTER TABLE posts ADD COLUMN author_id TEXT REFERENCES authors (author_id);
+	CREATE INDEX posts_by_author_id ON posts (author_id) WHERE author_id IS NOT NULL;`,
+
+	// 7: tag registry. Tags already on posts are registered on startup by
+	// backfillTags, since deriving unique slugs needs Go.
+	`CREATE TABLE tags (
+		tag_id      TEXT PRIMARY KEY,
+		name        TEXT NOT NULL UNIQUE,
+		slug        TEXT NOT NULL UNIQUE,
+		description TEXT NOT NULL DEFAULT '',
+		created_at  TEXT NOT NULL,
+		updated_at  TEXT NOT NULL
+	);`,
+
+	// 8: idempotency keys of creates. Keys outlive the post they created, so
+	// a retry after a delete does not create the post again.
+	`CREATE TABLE idempotency_keys (
+		request_id  TEXT PRIMARY KEY,
+		fingerprint TEXT NOT NULL,
+		post_id     TEXT NOT NULL,
+		expires_at  TEXT NOT NULL
+	);
+	CREATE INDEX idempotency_keys_by_expiry ON idempotency_keys (expires_at);`,
+}
+
+// migrate brings the schema up to date.
+func migrate(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+	if current > len(sqlMigrations) {
+		return fmt.Errorf("database schema version %d is newer than this server supports (%d)", current, len(sqlMigrations))
+	}
+
+	for version := current + 1; version <= len(sqlMigrations); version++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, sqlMigrations[version-1]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+			version, formatSQLTime(time.Now())); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1684 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/query"
+	"github.com/pandae7/go-blogger/internal/search"
+	_ "modernc.org/sqlite"
+)
+
+// sqlTimeFormat stores timestamps as fixed-width UTC text, which sorts
+// correctly and stays readable in ad-hoc queries.
+const sqlTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+func formatSQLTime(t time.Time) string {
+	return t.UTC().Format(sqlTimeFormat)
+}
+
+func parseSQLTime(s string) (time.Time, error) {
+	return time.Parse(sqlTimeFormat, s)
+}
+
+// SQLBlogStorage is a BlogStorage backed by an embedded SQLite database,
+// accessed through a cgo-free driver. Posts live in the posts table and their
+// tags in post_tags, one row per tag, by name; the tags table is the tag
+// registry.
+//
+// Full-text search is served from an in-process index of the published
+// posts, built when the database is opened and brought up to date after
+// every write that commits. Watchers are told about the changes to posts at
+// the same time; only writes made through this SQLBlogStorage are seen.
+type SQLBlogStorage struct {
+	db *sql.DB
+
+	// commitMu serializes the commits that change posts with the updates
+	// they make to fullText and feed, so that both see the changes in the
+	// order they were committed.
+	commitMu sync.Mutex
+
+	// searchMu protects fullText from searches while it is updated
+	searchMu sync.RWMutex
+	fullText *search.Index
+
+	feed *feed
+}
+
+// NewSQLBlogStorage opens or creates the SQLite database at path and runs any
+// pending schema migrations.
+func NewSQLBlogStorage(path string) (*SQLBlogStorage, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	// Take the write lock when a transaction starts, so concurrent
+	// read-modify-write transactions queue up instead of failing to upgrade.
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("sql storage: %w", err)
+	}
+	if err := migrate(context.Background(), db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sql storage: %w", err)
+	}
+	if err := backfillTags(context.Background(), db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sql storage: %w", err)
+	}
+	s := &SQLBlogStorage{db: db, fullText: search.NewIndex(), feed: newFeed(DefaultFeedHistory)}
+	posts, err := queryPosts(context.Background(), db, `WHERE p.status = 'published' AND p.deleted_at IS NULL`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sql storage: %w", err)
+	}
+	for _, post := range posts {
+		s.fullText.Add(searchDocument(post))
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *SQLBlogStorage) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLBlogStorage) CreatePost(ctx context.Context, post *models.BlogPost) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPost(ctx, tx, post, time.Now()); err != nil {
+		return err
+	}
+	return s.commit(ctx, tx, map[string]*models.BlogPost{post.PostId: nil})
+}
+
+func (s *SQLBlogStorage) CreatePosts(ctx context.Context, posts []*models.BlogPost) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	created := make(map[string]*models.BlogPost, len(posts))
+	for i, post := range posts {
+		// Posts inserted earlier in the batch are duplicates too
+		if err := insertPost(ctx, tx, post, now); err != nil {
+			return &models.ItemError{Index: i, Err: err}
+		}
+		created[post.PostId] = nil
+	}
+	return s.commit(ctx, tx, created)
+}
+
+func (s *SQLBlogStorage) CreatePostOnce(ctx context.Context, post *models.BlogPost, key *models.IdempotencyKey) (*models.BlogPost, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var fingerprint, postId string
+	err = tx.QueryRowContext(ctx, `SELECT fingerprint, post_id FROM idempotency_keys WHERE request_id = ? AND expires_at > ?`,
+		key.RequestId, formatSQLTime(now)).Scan(&fingerprint, &postId)
+	switch {
+	case err == nil:
+		if fingerprint != key.Fingerprint {
+			return nil, false, models.ErrRequestIDReused
+		}
+		posts, err := queryPosts(ctx, tx, `WHERE p.post_id = ? AND p.deleted_at IS NULL`, postId)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(posts) == 0 {
+			return nil, false, models.ErrPostNotFound
+		}
+		return posts[0], false, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, false, err
+	}
+
+	if err := insertPost(ctx, tx, post, now); err != nil {
+		return nil, false, err
+	}
+	// An expired key for the same request ID is replaced
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO idempotency_keys (request_id, fingerprint, post_id, expires_at) VALUES (?, ?, ?, ?)`,
+		key.RequestId, key.Fingerprint, post.PostId, formatSQLTime(key.ExpiresAt)); err != nil {
+		return nil, false, err
+	}
+	if err := s.commit(ctx, tx, map[string]*models.BlogPost{post.PostId: nil}); err != nil {
+		return nil, false, err
+	}
+	return post, true, nil
+}
+
+func (s *SQLBlogStorage) ExpireIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at < ?`, formatSQLTime(now))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// insertPost fills in the fields of a new post set by the storage and inserts
+// it with its tags and first revision.
+func insertPost(ctx context.Context, tx *sql.Tx, post *models.BlogPost, now time.Time) error {
+	// Check if post already exists
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM posts WHERE post_id = ?)`, post.PostId).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return models.ErrDuplicatePost
+	}
+
+	// Posts that reference an author carry its current name
+	if post.AuthorId != "" {
+		err := tx.QueryRowContext(ctx, `SELECT name FROM authors WHERE author_id = ?`, post.AuthorId).Scan(&post.Author)
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrAuthorNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Set the publication date if not provided
+	if post.PublicationDate.IsZero() {
+		post.PublicationDate = now
+	}
+	if err := initStatus(post, now); err != nil {
+		return err
+	}
+	// Set the updated at time
+	post.UpdatedAt = now
+	// Every post starts at version 1
+	post.Version = 1
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO posts (post_id, title, content, author, author_id, publication_date, updated_at, version, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		post.PostId, post.Title, post.Content, post.Author, nullIfEmpty(post.AuthorId),
+		formatSQLTime(post.PublicationDate), formatSQLTime(post.UpdatedAt), post.Version, post.Status); err != nil {
+		return err
+	}
+	if err := insertTags(ctx, tx, post.PostId, post.Tags); err != nil {
+		return err
+	}
+	if err := registerTags(ctx, tx, post.Tags, now); err != nil {
+		return err
+	}
+	return insertRevision(ctx, tx, models.NewPostRevision(post, post.Author))
+}
+
+func (s *SQLBlogStorage) GetPost(ctx context.Context, postId string) (*models.BlogPost, error) {
+	posts, err := queryPosts(ctx, s.db, `WHERE p.post_id = ? AND p.deleted_at IS NULL`, postId)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, models.ErrPostNotFound
+	}
+	return posts[0], nil
+}
+
+func (s *SQLBlogStorage) GetPosts(ctx context.Context, postIds []string) ([]*models.BlogPost, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	byId, err := loadPosts(ctx, tx, postIds)
+	if err != nil {
+		return nil, err
+	}
+	posts := make([]*models.BlogPost, len(postIds))
+	for i, id := range postIds {
+		if post, ok := byId[id]; ok && !post.Trashed() {
+			// Repeated IDs get copies of their own
+			posts[i] = post.Clone()
+		}
+	}
+	return posts, nil
+}
+
+func (s *SQLBlogStorage) UpdatePost(ctx context.Context, req *models.UpdateBlogPostRequest) (*models.BlogPost, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	posts, err := queryPosts(ctx, tx, `WHERE p.post_id = ? AND p.deleted_at IS NULL`, req.PostId)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, models.ErrPostNotFound
+	}
+	post := posts[0]
+	before := postsById(post.Clone())
+	if err := checkVersion(post, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	if err := applyUpdate(post, req); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET title = ?, content = ?, updated_at = ?, version = ? WHERE post_id = ?`,
+		post.Title, post.Content, formatSQLTime(post.UpdatedAt), post.Version, post.PostId); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM post_tags WHERE post_id = ?`, post.PostId); err != nil {
+		return nil, err
+	}
+	if err := insertTags(ctx, tx, post.PostId, post.Tags); err != nil {
+		return nil, err
+	}
+	if err := registerTags(ctx, tx, post.Tags, post.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, models.NewPostRevision(post, req.Editor)); err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, tx, before); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *SQLBlogStorage) DeletePost(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	post, err := lockPost(ctx, tx, req.PostId)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(post, req.ExpectedVersion); err != nil {
+		return err
+	}
+
+	before, err := loadPosts(ctx, tx, []string{req.PostId})
+	if err != nil {
+		return err
+	}
+
+	// Tags and revisions are removed by ON DELETE CASCADE
+	if _, err := tx.ExecContext(ctx, `DELETE FROM posts WHERE post_id = ?`, req.PostId); err != nil {
+		return err
+	}
+	return s.commit(ctx, tx, before)
+}
+
+func (s *SQLBlogStorage) TrashPost(ctx context.Context, req *models.DeleteBlogPostRequest) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	post, err := lockPost(ctx, tx, req.PostId)
+	if err != nil {
+		return err
+	}
+	if post.Trashed() {
+		return models.ErrPostNotFound
+	}
+	if err := checkVersion(post, req.ExpectedVersion); err != nil {
+		return err
+	}
+
+	before, err := loadPosts(ctx, tx, []string{req.PostId})
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET deleted_at = ? WHERE post_id = ?`,
+		formatSQLTime(time.Now()), req.PostId); err != nil {
+		return err
+	}
+	return s.commit(ctx, tx, before)
+}
+
+func (s *SQLBlogStorage) DeletePosts(ctx context.Context, req *models.BatchDeleteRequest) ([]error, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	existing, err := loadPosts(ctx, tx, req.PostIds)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(req.PostIds))
+	before := make(map[string]*models.BlogPost, len(req.PostIds))
+	now := formatSQLTime(time.Now())
+	for i, id := range req.PostIds {
+		post, exists := existing[id]
+		if _, deleted := before[id]; !exists || deleted || !req.Permanent && post.Trashed() {
+			errs[i] = models.ErrPostNotFound
+			continue
+		}
+		before[id] = post
+		// Tags and revisions of deleted posts are removed by ON DELETE CASCADE
+		if req.Permanent {
+			_, err = tx.ExecContext(ctx, `DELETE FROM posts WHERE post_id = ?`, id)
+		} else {
+			_, err = tx.ExecContext(ctx, `UPDATE posts SET deleted_at = ? WHERE post_id = ?`, now, id)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.commit(ctx, tx, before); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (s *SQLBlogStorage) RestorePost(ctx context.Context, postId string) (*models.BlogPost, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	post, err := lockPost(ctx, tx, postId)
+	if err != nil {
+		return nil, err
+	}
+	if !post.Trashed() {
+		return nil, models.ErrPostNotTrashed
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET deleted_at = NULL WHERE post_id = ?`, postId); err != nil {
+		return nil, err
+	}
+	posts, err := queryPosts(ctx, tx, `WHERE p.post_id = ?`, postId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, tx, postsById(post)); err != nil {
+		return nil, err
+	}
+	return posts[0], nil
+}
+
+func (s *SQLBlogStorage) PurgePost(ctx context.Context, postId string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	post, err := lockPost(ctx, tx, postId)
+	if err != nil {
+		return err
+	}
+	if !post.Trashed() {
+		return models.ErrPostNotTrashed
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM posts WHERE post_id = ?`, postId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLBlogStorage) PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM posts WHERE deleted_at IS NOT NULL AND deleted_at < ?`, formatSQLTime(cutoff))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (s *SQLBlogStorage) SetPostStatus(ctx context.Context, req *models.SetPostStatusRequest) (*models.BlogPost, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	posts, err := queryPosts(ctx, tx, `WHERE p.post_id = ? AND p.deleted_at IS NULL`, req.PostId)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, models.ErrPostNotFound
+	}
+	post := posts[0]
+	before := postsById(post.Clone())
+	if err := checkVersion(post, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	if err := applyStatus(post, req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET status = ?, publication_date = ?, updated_at = ?, version = ? WHERE post_id = ?`,
+		post.Status, formatSQLTime(post.PublicationDate), formatSQLTime(post.UpdatedAt), post.Version, post.PostId); err != nil {
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, models.NewPostRevision(post, req.Editor)); err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, tx, before); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *SQLBlogStorage) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// The status is spelled out so that posts_by_scheduled_date can be used
+	due, err := queryPosts(ctx, tx, `WHERE p.status = 'scheduled' AND p.deleted_at IS NULL AND p.publication_date <= ?`,
+		formatSQLTime(now))
+	if err != nil {
+		return 0, err
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET status = 'published', updated_at = ?, version = version + 1
+		WHERE status = 'scheduled' AND deleted_at IS NULL AND publication_date <= ?`,
+		formatSQLTime(now), formatSQLTime(now)); err != nil {
+		return 0, err
+	}
+	before := postsById(due...)
+	for _, post := range due {
+		published := post.Clone()
+		published.Status = models.StatusPublished
+		published.UpdatedAt = now
+		published.Version++
+		if err := insertRevision(ctx, tx, models.NewPostRevision(published, models.SystemEditor)); err != nil {
+			return 0, err
+		}
+	}
+	if err := s.commit(ctx, tx, before); err != nil {
+		return 0, err
+	}
+	return len(due), nil
+}
+
+func (s *SQLBlogStorage) ListPosts(ctx context.Context, req *models.ListBlogPostsRequest) ([]*models.BlogPost, string, error) {
+	q, err := parseListRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The filter is narrowed down in SQL as far as it translates exactly and
+	// always evaluated in Go, so that every backend accepts exactly the same
+	// expressions. Posts are read in pages that start after the cursor.
+	filter, filterArgs, exact := sqlFilter(q.filter)
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter != "" {
+		conditions, args = append(conditions, filter), append(args, filterArgs...)
+	}
+	if !q.showDeleted {
+		conditions = append(conditions, `p.deleted_at IS NULL`)
+	}
+	if q.published {
+		conditions, args = append(conditions, `p.status = ?`), append(args, models.StatusPublished)
+	}
+	column := sqlColumns[q.order.Field].column
+	orderBy := column + `, p.post_id`
+	if q.order.Desc {
+		orderBy = column + ` DESC, p.post_id`
+	}
+	batch := q.size + 1
+	if !exact {
+		batch = max(batch, listScanBatch)
+	}
+
+	var matched []*models.BlogPost
+	for after := q.after; ; {
+		where, whereArgs := slices.Clone(conditions), slices.Clone(args)
+		if after != nil {
+			keyset, keysetArgs := sqlKeyset(q.order, after)
+			where, whereArgs = append(where, keyset), append(whereArgs, keysetArgs...)
+		}
+		selected := `SELECT p.post_id FROM posts p`
+		if len(where) > 0 {
+			selected += ` WHERE ` + strings.Join(where, ` AND `)
+		}
+		selected += ` ORDER BY ` + orderBy + ` LIMIT ?`
+		posts, err := queryPosts(ctx, s.db, `WHERE p.post_id IN (`+selected+`)`, append(whereArgs, batch)...)
+		if err != nil {
+			return nil, "", err
+		}
+		sort.Slice(posts, func(i, j int) bool {
+			return q.order.Less(posts[i], posts[j])
+		})
+		for _, post := range posts {
+			if q.match(post) {
+				matched = append(matched, post)
+			}
+		}
+		// One post beyond the page tells paginate there is a next page
+		if len(matched) > q.size || len(posts) < batch {
+			break
+		}
+		after = posts[len(posts)-1]
+	}
+	page, next := q.paginate(matched)
+	return page, next, nil
+}
+
+// listScanBatch is the number of posts read at a time by a listing whose
+// filter has to be evaluated in Go.
+const listScanBatch = 500
+
+// sqlColumns maps the fields of filters and orders to the columns that SQLite
+// compares the same way as the query package: strings bytewise, and
+// timestamps as stored, in a fixed-width UTC format that sorts by time.
+var sqlColumns = map[string]struct {
+	column string
+	time   bool
+}{
+	"post_id":          {column: "p.post_id"},
+	"title":            {column: "p.title"},
+	"content":          {column: "p.content"},
+	"author":           {column: "p.author"},
+	"author_id":        {column: "COALESCE(p.author_id, '')"},
+	"status":           {column: "p.status"},
+	"publication_date": {column: "p.publication_date", time: true},
+	"updated_at":       {column: "p.updated_at", time: true},
+}
+
+// sqlFilter translates a filter into a condition on the posts table that every
+// post matching the filter satisfies. exact reports whether the condition is
+// the filter itself; otherwise part of the filter has no SQL equivalent with
+// the same semantics, such as the case-insensitive ':' operator, and the
+// selected posts must still be matched in Go. An empty condition selects every
+// post.
+func sqlFilter(expr query.Expr) (condition string, args []any, exact bool) {
+	switch e := expr.(type) {
+	case nil:
+		return "", nil, true
+	case *query.AndExpr:
+		left, leftArgs, leftExact := sqlFilter(e.Left)
+		right, rightArgs, rightExact := sqlFilter(e.Right)
+		switch {
+		case left == "":
+			return right, rightArgs, leftExact && rightExact
+		case right == "":
+			return left, leftArgs, leftExact && rightExact
+		}
+		return "(" + left + " AND " + right + ")", append(leftArgs, rightArgs...), leftExact && rightExact
+	case *query.OrExpr:
+		left, leftArgs, leftExact := sqlFilter(e.Left)
+		right, rightArgs, rightExact := sqlFilter(e.Right)
+		if left == "" || right == "" {
+			return "", nil, false
+		}
+		return "(" + left + " OR " + right + ")", append(leftArgs, rightArgs...), leftExact && rightExact
+	case *query.NotExpr:
+		operand, operandArgs, operandExact := sqlFilter(e.Expr)
+		if operand == "" || !operandExact {
+			return "", nil, false
+		}
+		return "NOT " + operand, operandArgs, true
+	case *query.Comparison:
+		field, ok := sqlColumns[e.Field]
+		if !ok || e.Op == ":" {
+			return "", nil, false
+		}
+		if field.time {
+			return field.column + " " + e.Op + " ?", []any{formatSQLTime(e.Time())}, true
+		}
+		return field.column + " " + e.Op + " ?", []any{e.Value}, true
+	}
+	return "", nil, false
+}
+
+// sqlKeyset returns the condition selecting the posts that sort after the
+// given post in the order.
+func sqlKeyset(order *query.OrderBy, after *models.BlogPost) (string, []any) {
+	var value any
+	switch order.Field {
+	case "post_id":
+		value = after.PostId
+	case "title":
+		value = after.Title
+	case "author":
+		value = after.Author
+	case "publication_date":
+		value = formatSQLTime(after.PublicationDate)
+	case "updated_at":
+		value = formatSQLTime(after.UpdatedAt)
+	}
+	column, op := sqlColumns[order.Field].column, ">"
+	if order.Desc {
+		op = "<"
+	}
+	// Ties are broken by post ID in ascending order whatever the direction
+	return "(" + column + " " + op + " ? OR (" + column + " = ? AND p.post_id > ?))", []any{value, value, after.PostId}
+}
+
+func (s *SQLBlogStorage) ListPostsByAuthor(ctx context.Context, req *models.ListPostsByAuthorRequest) ([]*models.BlogPost, string, error) {
+	q, err := parseScopedListRequest("author:"+req.AuthorId, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	posts, err := queryPosts(ctx, s.db, `WHERE p.author_id = ? AND p.deleted_at IS NULL`, req.AuthorId)
+	if err != nil {
+		return nil, "", err
+	}
+	return listScoped(q, posts)
+}
+
+func (s *SQLBlogStorage) ListPostsByTag(ctx context.Context, req *models.ListPostsByTagRequest) ([]*models.BlogPost, string, error) {
+	q, err := parseScopedListRequest("tag:"+req.Tag, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	posts, err := queryPosts(ctx, s.db, `WHERE p.post_id IN (SELECT post_id FROM post_tags WHERE tag = ?) AND p.deleted_at IS NULL`, req.Tag)
+	if err != nil {
+		return nil, "", err
+	}
+	return listScoped(q, posts)
+}
+
+func (s *SQLBlogStorage) SearchPosts(ctx context.Context, req *models.SearchPostsRequest) ([]*models.SearchResult, string, error) {
+	q, err := parseSearchRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.searchMu.RLock()
+	page, next := q.paginate(s.fullText.Search(q.query, search.DefaultBoosts))
+	s.searchMu.RUnlock()
+	if len(page) == 0 {
+		return nil, next, nil
+	}
+
+	postIds := make([]any, len(page))
+	for i, hit := range page {
+		postIds[i] = hit.Id
+	}
+	posts, err := queryPosts(ctx, s.db, `WHERE p.post_id IN (`+placeholders(len(postIds))+`)`, postIds...)
+	if err != nil {
+		return nil, "", err
+	}
+	byId := make(map[string]*models.BlogPost, len(posts))
+	for _, post := range posts {
+		byId[post.PostId] = post
+	}
+	results := make([]*models.SearchResult, 0, len(page))
+	for _, hit := range page {
+		// Skip posts written since the index was searched
+		if post, ok := byId[hit.Id]; ok && searchable(post) {
+			results = append(results, &models.SearchResult{Post: post, Score: hit.Score})
+		}
+	}
+	return results, next, nil
+}
+
+func (s *SQLBlogStorage) GetRelatedPosts(ctx context.Context, req *models.GetRelatedPostsRequest) ([]*models.RelatedPost, error) {
+	limit, err := relatedLimit(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	post, err := s.GetPost(ctx, req.PostId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.searchMu.RLock()
+	similar := s.fullText.Similar(searchDocument(post), relatedBoosts, relatedCandidates)
+	s.searchMu.RUnlock()
+
+	postIds := make([]string, len(similar))
+	for i, hit := range similar {
+		postIds[i] = hit.Id
+	}
+	candidates, err := queryPostTags(ctx, s.db, postIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range postIds {
+		// Similar posts without tags have no rows
+		if _, ok := candidates[id]; !ok {
+			candidates[id] = nil
+		}
+	}
+	tagged, err := queryTaggedPosts(ctx, s.db, post.Tags, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, posts := range tagged {
+		for id, tags := range posts {
+			candidates[id] = tags
+		}
+	}
+
+	hits := relatedHits(post, similar, candidates, limit)
+	if len(hits) == 0 {
+		return nil, nil
+	}
+	ids := make([]any, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.Id
+	}
+	posts, err := queryPosts(ctx, s.db, `WHERE p.post_id IN (`+placeholders(len(ids))+`)`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[string]*models.BlogPost, len(posts))
+	for _, loaded := range posts {
+		byId[loaded.PostId] = loaded
+	}
+	related := make([]*models.RelatedPost, 0, len(hits))
+	for _, hit := range hits {
+		// Skip posts written since they were scored
+		if other, ok := byId[hit.Id]; ok && searchable(other) {
+			related = append(related, &models.RelatedPost{Post: other, Score: hit.Score})
+		}
+	}
+	return related, nil
+}
+
+// commit commits the transaction and then brings the search index up to
+// date and notifies watchers. before maps the ID of every post the
+// transaction changed to the post as it was when the transaction started,
+// nil for a post it created. The posts are read again within the
+// transaction, so the index and the events reflect what was committed.
+func (s *SQLBlogStorage) commit(ctx context.Context, tx *sql.Tx, before map[string]*models.BlogPost) error {
+	postIds := make([]string, 0, len(before))
+	for id := range before {
+		postIds = append(postIds, id)
+	}
+	sort.Strings(postIds)
+	after, err := loadPosts(ctx, tx, postIds)
+	if err != nil {
+		return err
+	}
+
+	s.commitMu.Lock()
+	defer s.commitMu.Unlock()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.searchMu.Lock()
+	for _, id := range postIds {
+		s.fullText.Remove(id)
+		if post, ok := after[id]; ok && searchable(post) {
+			s.fullText.Add(searchDocument(post))
+		}
+	}
+	s.searchMu.Unlock()
+
+	var events []*models.PostEvent
+	now := time.Now()
+	for _, id := range postIds {
+		if event := postEvent(before[id], after[id], now); event != nil {
+			events = append(events, event)
+		}
+	}
+	s.feed.publish(events)
+	return nil
+}
+
+// postsById maps the IDs of the posts to the posts.
+func postsById(posts ...*models.BlogPost) map[string]*models.BlogPost {
+	byId := make(map[string]*models.BlogPost, len(posts))
+	for _, post := range posts {
+		byId[post.PostId] = post
+	}
+	return byId
+}
+
+// loadPostsChunk is the number of posts loadPosts reads per query, to stay
+// well below the limit SQLite puts on the number of parameters.
+const loadPostsChunk = 500
+
+// loadPosts returns the posts with the given IDs that exist, including those
+// in the trash, by ID.
+func loadPosts(ctx context.Context, q queryer, postIds []string) (map[string]*models.BlogPost, error) {
+	byId := make(map[string]*models.BlogPost, len(postIds))
+	for start := 0; start < len(postIds); start += loadPostsChunk {
+		chunk := postIds[start:min(start+loadPostsChunk, len(postIds))]
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		posts, err := queryPosts(ctx, q, `WHERE p.post_id IN (`+placeholders(len(args))+`)`, args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			byId[post.PostId] = post
+		}
+	}
+	return byId, nil
+}
+
+// exportPageSize is the number of posts ExportPosts reads per query.
+const exportPageSize = 500
+
+func (s *SQLBlogStorage) ExportPosts(ctx context.Context, req *models.ExportPostsRequest, fn func(post *models.BlogPost, sequence uint64) error) error {
+	q, err := parseExportRequest(req)
+	if err != nil {
+		return err
+	}
+
+	// A read-only transaction sees the database as of its first read, and
+	// does not block writers. Events are published under commitMu, so
+	// taking that first read under it makes the sequence match the snapshot.
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	s.commitMu.Lock()
+	var count int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts`).Scan(&count)
+	sequence := s.feed.lastSequence()
+	s.commitMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Read the posts a page at a time, so a large export is not held in
+	// memory at once
+	after := ""
+	for {
+		posts, err := queryPosts(ctx, tx, `WHERE p.post_id IN (SELECT post_id FROM posts WHERE post_id > ? ORDER BY post_id LIMIT ?)`,
+			after, exportPageSize)
+		if err != nil {
+			return err
+		}
+		if len(posts) == 0 {
+			return nil
+		}
+		for _, post := range posts {
+			if !q.match(post) {
+				continue
+			}
+			if err := fn(post, sequence); err != nil {
+				return err
+			}
+		}
+		after = posts[len(posts)-1].PostId
+	}
+}
+
+func (s *SQLBlogStorage) WatchPosts(ctx context.Context, req *models.WatchPostsRequest, fn func(*models.PostEvent) error) error {
+	return s.feed.watch(ctx, req, fn)
+}
+
+// listScoped returns the page selected by the query among posts already
+// narrowed down by an index.
+func listScoped(q *listQuery, posts []*models.BlogPost) ([]*models.BlogPost, string, error) {
+	matched := posts[:0]
+	for _, post := range posts {
+		if q.match(post) {
+			matched = append(matched, post)
+		}
+	}
+	page, next := q.paginate(matched)
+	return page, next, nil
+}
+
+func (s *SQLBlogStorage) ListRevisions(ctx context.Context, postId string) ([]*models.PostRevision, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkPostExists(ctx, tx, postId); err != nil {
+		return nil, err
+	}
+	return queryRevisions(ctx, tx, `WHERE post_id = ? ORDER BY revision DESC`, postId)
+}
+
+func (s *SQLBlogStorage) GetRevision(ctx context.Context, postId string, revision int64) (*models.PostRevision, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkPostExists(ctx, tx, postId); err != nil {
+		return nil, err
+	}
+	revisions, err := queryRevisions(ctx, tx, `WHERE post_id = ? AND revision = ?`, postId, revision)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, models.ErrRevisionNotFound
+	}
+	return revisions[0], nil
+}
+
+func (s *SQLBlogStorage) CreateAuthor(ctx context.Context, author *models.Author) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	profileKey, emailKey := authorKeys(author)
+	var taken bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM authors WHERE author_id = ? OR profile_key = ? OR email_key = ?)`,
+		author.AuthorId, profileKey, nullIfEmpty(emailKey)).Scan(&taken); err != nil {
+		return err
+	}
+	if taken {
+		return models.ErrDuplicateAuthor
+	}
+
+	now := time.Now()
+	author.CreatedAt = now
+	author.UpdatedAt = now
+	if _, err := tx.ExecContext(ctx, `INSERT INTO authors (author_id, name, email, profile_name, profile_key, email_key, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		author.AuthorId, author.Name, author.Email, author.ProfileName, profileKey, nullIfEmpty(emailKey),
+		formatSQLTime(author.CreatedAt), formatSQLTime(author.UpdatedAt)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLBlogStorage) GetAuthor(ctx context.Context, authorId string) (*models.Author, error) {
+	authors, err := queryAuthors(ctx, s.db, `WHERE author_id = ?`, authorId)
+	if err != nil {
+		return nil, err
+	}
+	if len(authors) == 0 {
+		return nil, models.ErrAuthorNotFound
+	}
+	return authors[0], nil
+}
+
+func (s *SQLBlogStorage) UpdateAuthor(ctx context.Context, req *models.UpdateAuthorRequest) (*models.Author, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	authors, err := queryAuthors(ctx, tx, `WHERE author_id = ?`, req.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	if len(authors) == 0 {
+		return nil, models.ErrAuthorNotFound
+	}
+	author := authors[0]
+	oldName := author.Name
+	applyAuthorUpdate(author, req)
+
+	profileKey, emailKey := authorKeys(author)
+	var taken bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM authors WHERE author_id != ? AND (profile_key = ? OR email_key = ?))`,
+		author.AuthorId, profileKey, nullIfEmpty(emailKey)).Scan(&taken); err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, models.ErrDuplicateAuthor
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE authors SET name = ?, email = ?, profile_name = ?, profile_key = ?, email_key = ?, updated_at = ?
+		WHERE author_id = ?`,
+		author.Name, author.Email, author.ProfileName, profileKey, nullIfEmpty(emailKey), formatSQLTime(author.UpdatedAt),
+		author.AuthorId); err != nil {
+		return nil, err
+	}
+	var renamed []*models.BlogPost
+	if author.Name != oldName {
+		if renamed, err = queryPosts(ctx, tx, `WHERE p.author_id = ?`, author.AuthorId); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE posts SET author = ? WHERE author_id = ?`, author.Name, author.AuthorId); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.commit(ctx, tx, postsById(renamed...)); err != nil {
+		return nil, err
+	}
+	return author, nil
+}
+
+func (s *SQLBlogStorage) DeleteAuthor(ctx context.Context, authorId string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists, hasPosts bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM authors WHERE author_id = ?),
+			EXISTS (SELECT 1 FROM posts WHERE author_id = ?)`, authorId, authorId).Scan(&exists, &hasPosts); err != nil {
+		return err
+	}
+	if !exists {
+		return models.ErrAuthorNotFound
+	}
+	if hasPosts {
+		return models.ErrAuthorHasPosts
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM authors WHERE author_id = ?`, authorId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLBlogStorage) ListAuthors(ctx context.Context, req *models.ListAuthorsRequest) ([]*models.Author, string, error) {
+	q, err := parseNameListRequest(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	authors, err := queryAuthors(ctx, s.db, "")
+	if err != nil {
+		return nil, "", err
+	}
+	page, next := paginateByName(q, authors, authorSortKey)
+	return page, next, nil
+}
+
+func (s *SQLBlogStorage) ListTags(ctx context.Context, req *models.ListTagsRequest) ([]*models.Tag, string, error) {
+	q, err := parseNameListRequest(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	tags, err := queryTags(ctx, s.db, "")
+	if err != nil {
+		return nil, "", err
+	}
+	page, next := paginateByName(q, tags, tagSortKey)
+	return page, next, nil
+}
+
+func (s *SQLBlogStorage) GetTag(ctx context.Context, tagId string) (*models.Tag, error) {
+	tags, err := queryTags(ctx, s.db, `WHERE t.tag_id = ?`, tagId)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, models.ErrTagNotFound
+	}
+	return tags[0], nil
+}
+
+func (s *SQLBlogStorage) RenameTag(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tags, err := queryTags(ctx, tx, `WHERE t.tag_id = ?`, req.TagId)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, models.ErrTagNotFound
+	}
+	tag := tags[0]
+
+	now := time.Now()
+	tag.UpdatedAt = now
+	if req.Description != nil {
+		tag.Description = *req.Description
+	}
+	var retagged []*models.BlogPost
+	if req.Name != "" && req.Name != tag.Name {
+		var taken bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tags WHERE name = ?)`, req.Name).Scan(&taken); err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, models.ErrDuplicateTag
+		}
+		slug, err := uniqueSlug(req.Name, func(slug string) (bool, error) {
+			var taken bool
+			err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tags WHERE slug = ? AND tag_id != ?)`, slug, tag.TagId).Scan(&taken)
+			return taken, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if retagged, err = retagPosts(ctx, tx, tag.Name, req.Name, now); err != nil {
+			return nil, err
+		}
+		tag.Name, tag.Slug = req.Name, slug
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tags SET name = ?, slug = ?, description = ?, updated_at = ? WHERE tag_id = ?`,
+		tag.Name, tag.Slug, tag.Description, formatSQLTime(tag.UpdatedAt), tag.TagId); err != nil {
+		return nil, err
+	}
+	// Read the tag back for its usage count after the rewrite
+	renamedTags, err := queryTags(ctx, tx, `WHERE t.tag_id = ?`, tag.TagId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, tx, postsById(retagged...)); err != nil {
+		return nil, err
+	}
+	return renamedTags[0], nil
+}
+
+func (s *SQLBlogStorage) MergeTags(ctx context.Context, req *models.MergeTagsRequest) (*models.Tag, error) {
+	if req.SourceTagId == req.TargetTagId {
+		return nil, models.ErrMergeSameTag
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tags, err := queryTags(ctx, tx, `WHERE t.tag_id IN (?, ?)`, req.SourceTagId, req.TargetTagId)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != 2 {
+		return nil, models.ErrTagNotFound
+	}
+	sourceTag, targetTag := tags[0], tags[1]
+	if sourceTag.TagId != req.SourceTagId {
+		sourceTag, targetTag = targetTag, sourceTag
+	}
+
+	retagged, err := retagPosts(ctx, tx, sourceTag.Name, targetTag.Name, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE tag_id = ?`, sourceTag.TagId); err != nil {
+		return nil, err
+	}
+	// Read the target back for its usage count after the merge
+	mergedTags, err := queryTags(ctx, tx, `WHERE t.tag_id = ?`, targetTag.TagId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, tx, postsById(retagged...)); err != nil {
+		return nil, err
+	}
+	return mergedTags[0], nil
+}
+
+func (s *SQLBlogStorage) SuggestTags(ctx context.Context, req *models.SuggestTagsRequest) ([]*models.TagSuggestion, error) {
+	limit, err := suggestLimit(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []*models.TagSuggestion
+	if !req.Draft() {
+		// The unique index on the name serves the range scan
+		where, args := "", []any(nil)
+		if req.Prefix != "" {
+			where, args = `WHERE t.name >= ? AND t.name < ?`, []any{req.Prefix, req.Prefix + string(utf8.MaxRune)}
+		}
+		tags, err := queryTags(ctx, s.db, where, args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			suggestions = append(suggestions, &models.TagSuggestion{Tag: tag, Score: float64(tag.UsageCount)})
+		}
+		return rankSuggestions(suggestions, limit), nil
+	}
+
+	s.searchMu.RLock()
+	similar := s.fullText.Similar(draftDocument(req), search.DefaultBoosts, similarPosts)
+	s.searchMu.RUnlock()
+
+	postIds := make([]string, len(similar))
+	for i, hit := range similar {
+		postIds[i] = hit.Id
+	}
+	similarTags, err := queryPostTags(ctx, s.db, postIds)
+	if err != nil {
+		return nil, err
+	}
+	tagged, err := queryTaggedPosts(ctx, s.db, req.Tags, false)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := draftTagScores(req.Tags, similar, similarTags, tagged)
+	var names []any
+	for name := range scores {
+		if strings.HasPrefix(name, req.Prefix) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	tags, err := queryTags(ctx, s.db, `WHERE t.name IN (`+placeholders(len(names))+`)`, names...)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		suggestions = append(suggestions, &models.TagSuggestion{Tag: tag, Score: scores[tag.Name]})
+	}
+	return rankSuggestions(suggestions, limit), nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// queryPostTags returns the tags of the posts, in order, by post ID.
+func queryPostTags(ctx context.Context, q queryer, postIds []string) (map[string][]string, error) {
+	tags := make(map[string][]string, len(postIds))
+	if len(postIds) == 0 {
+		return tags, nil
+	}
+	args := make([]any, len(postIds))
+	for i, id := range postIds {
+		args[i] = id
+	}
+	rows, err := q.QueryContext(ctx, `SELECT post_id, tag FROM post_tags
+		WHERE post_id IN (`+placeholders(len(args))+`) ORDER BY post_id, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId, tag string
+		if err := rows.Scan(&postId, &tag); err != nil {
+			return nil, err
+		}
+		tags[postId] = append(tags[postId], tag)
+	}
+	return tags, rows.Err()
+}
+
+// queryTaggedPosts returns, for each of the tags, the tags of the posts
+// outside the trash that carry it, by post ID. With published set, only
+// published posts are included.
+func queryTaggedPosts(ctx context.Context, q queryer, tags []string, published bool) (map[string]map[string][]string, error) {
+	tagged := make(map[string]map[string][]string, len(tags))
+	if len(tags) == 0 {
+		return tagged, nil
+	}
+	args := make([]any, len(tags))
+	for i, tag := range tags {
+		args[i] = tag
+	}
+	status := ""
+	if published {
+		status = ` AND p.status = 'published'`
+	}
+	rows, err := q.QueryContext(ctx, `SELECT shared.tag, other.post_id, other.tag
+		FROM post_tags shared
+		JOIN posts p ON p.post_id = shared.post_id
+		JOIN post_tags other ON other.post_id = shared.post_id
+		WHERE shared.tag IN (`+placeholders(len(args))+`) AND p.deleted_at IS NULL`+status+`
+		ORDER BY other.post_id, other.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shared, postId, tag string
+		if err := rows.Scan(&shared, &postId, &tag); err != nil {
+			return nil, err
+		}
+		if tagged[shared] == nil {
+			tagged[shared] = make(map[string][]string)
+		}
+		tagged[shared][postId] = append(tagged[shared][postId], tag)
+	}
+	return tagged, rows.Err()
+}
+
+// queryPosts loads the posts selected by where, together with their tags.
+func queryPosts(ctx context.Context, q queryer, where string, args ...any) ([]*models.BlogPost, error) {
+	rows, err := q.QueryContext(ctx, `SELECT p.post_id, p.title, p.content, p.author, COALESCE(p.author_id, ''), p.publication_date, p.updated_at, p.version, p.status, p.deleted_at,
+			COALESCE(t.tag, ''), t.position IS NOT NULL
+		FROM posts p
+		LEFT JOIN post_tags t ON t.post_id = p.post_id
+		`+where+`
+		ORDER BY p.post_id, t.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*models.BlogPost
+	var current *models.BlogPost
+	for rows.Next() {
+		var (
+			post                       models.BlogPost
+			publicationDate, updatedAt string
+			deletedAt                  sql.NullString
+			tag                        string
+			hasTag                     bool
+		)
+		if err := rows.Scan(&post.PostId, &post.Title, &post.Content, &post.Author, &post.AuthorId,
+			&publicationDate, &updatedAt, &post.Version, &post.Status, &deletedAt, &tag, &hasTag); err != nil {
+			return nil, err
+		}
+		if current == nil || current.PostId != post.PostId {
+			if post.PublicationDate, err = parseSQLTime(publicationDate); err != nil {
+				return nil, err
+			}
+			if post.UpdatedAt, err = parseSQLTime(updatedAt); err != nil {
+				return nil, err
+			}
+			if deletedAt.Valid {
+				if post.DeletedAt, err = parseSQLTime(deletedAt.String); err != nil {
+					return nil, err
+				}
+			}
+			current = &post
+			posts = append(posts, current)
+		}
+		if hasTag {
+			current.Tags = append(current.Tags, tag)
+		}
+	}
+	return posts, rows.Err()
+}
+
+// placeholders returns n comma-separated query placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func insertTags(ctx context.Context, q queryer, postId string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(tags))
+	args := make([]any, 0, 3*len(tags))
+	for i, tag := range tags {
+		placeholders[i] = "(?, ?, ?)"
+		args = append(args, postId, i, tag)
+	}
+	_, err := q.ExecContext(ctx, `INSERT INTO post_tags (post_id, position, tag) VALUES `+strings.Join(placeholders, ", "), args...)
+	return err
+}
+
+// checkPostExists fails with models.ErrPostNotFound unless a post exists and
+// is not in the trash.
+func checkPostExists(ctx context.Context, tx *sql.Tx, postId string) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM posts WHERE post_id = ? AND deleted_at IS NULL)`, postId).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return models.ErrPostNotFound
+	}
+	return nil
+}
+
+// queryRevisions loads the revisions selected by where.
+func queryRevisions(ctx context.Context, q queryer, where string, args ...any) ([]*models.PostRevision, error) {
+	rows, err := q.QueryContext(ctx, `SELECT post_id, revision, title, content, tags, editor, created_at
+		FROM post_revisions `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*models.PostRevision
+	for rows.Next() {
+		var (
+			r               models.PostRevision
+			tags, createdAt string
+		)
+		if err := rows.Scan(&r.PostId, &r.Revision, &r.Title, &r.Content, &tags, &r.Editor, &createdAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(tags), &r.Tags); err != nil {
+			return nil, err
+		}
+		if len(r.Tags) == 0 {
+			r.Tags = nil
+		}
+		if r.CreatedAt, err = parseSQLTime(createdAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, &r)
+	}
+	return revisions, rows.Err()
+}
+
+func insertRevision(ctx context.Context, q queryer, r *models.PostRevision) error {
+	tags, err := json.Marshal(r.Tags)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx, `INSERT INTO post_revisions (post_id, revision, title, content, tags, editor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		r.PostId, r.Revision, r.Title, r.Content, string(tags), r.Editor, formatSQLTime(r.CreatedAt))
+	return err
+}
+
+// lockPost reads the version and trash state of a post, whether or not it is
+// in the trash. Transactions take the write lock when they begin, so the
+// state cannot change before the transaction ends.
+func lockPost(ctx context.Context, tx *sql.Tx, postId string) (*models.BlogPost, error) {
+	var (
+		post      = models.BlogPost{PostId: postId}
+		deletedAt sql.NullString
+	)
+	err := tx.QueryRowContext(ctx, `SELECT version, deleted_at FROM posts WHERE post_id = ?`, postId).Scan(&post.Version, &deletedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if deletedAt.Valid {
+		if post.DeletedAt, err = parseSQLTime(deletedAt.String); err != nil {
+			return nil, err
+		}
+	}
+	return &post, nil
+}
+
+// queryAuthors loads the authors selected by where.
+func queryAuthors(ctx context.Context, q queryer, where string, args ...any) ([]*models.Author, error) {
+	rows, err := q.QueryContext(ctx, `SELECT author_id, name, email, profile_name, created_at, updated_at
+		FROM authors `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authors []*models.Author
+	for rows.Next() {
+		var (
+			a                    models.Author
+			createdAt, updatedAt string
+		)
+		if err := rows.Scan(&a.AuthorId, &a.Name, &a.Email, &a.ProfileName, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		if a.CreatedAt, err = parseSQLTime(createdAt); err != nil {
+			return nil, err
+		}
+		if a.UpdatedAt, err = parseSQLTime(updatedAt); err != nil {
+			return nil, err
+		}
+		authors = append(authors, &a)
+	}
+	return authors, rows.Err()
+}
+
+// nullIfEmpty stores empty strings as NULL, for columns where NULL means
+// unset.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// queryTags loads the tags selected by where, together with their usage
+// counts.
+func queryTags(ctx context.Context, q queryer, where string, args ...any) ([]*models.Tag, error) {
+	rows, err := q.QueryContext(ctx, `SELECT t.tag_id, t.name, t.slug, t.description, t.created_at, t.updated_at,
+			(SELECT COUNT(DISTINCT pt.post_id)
+				FROM post_tags pt JOIN posts p ON p.post_id = pt.post_id
+				WHERE pt.tag = t.name AND p.deleted_at IS NULL)
+		FROM tags t `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*models.Tag
+	for rows.Next() {
+		var (
+			t                    models.Tag
+			createdAt, updatedAt string
+		)
+		if err := rows.Scan(&t.TagId, &t.Name, &t.Slug, &t.Description, &createdAt, &updatedAt, &t.UsageCount); err != nil {
+			return nil, err
+		}
+		if t.CreatedAt, err = parseSQLTime(createdAt); err != nil {
+			return nil, err
+		}
+		if t.UpdatedAt, err = parseSQLTime(updatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &t)
+	}
+	return tags, rows.Err()
+}
+
+// registerTags adds the given tags to the registry, skipping those already
+// registered.
+func registerTags(ctx context.Context, tx *sql.Tx, tags []string, now time.Time) error {
+	for _, name := range tags {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tags WHERE name = ?)`, name).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		slug, err := uniqueSlug(name, func(slug string) (bool, error) {
+			var taken bool
+			err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tags WHERE slug = ?)`, slug).Scan(&taken)
+			return taken, err
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tags (tag_id, name, slug, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+			uuid.New().String(), name, slug, formatSQLTime(now), formatSQLTime(now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillTags registers the tags of posts written before the tag registry.
+func backfillTags(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT DISTINCT tag FROM post_tags WHERE tag NOT IN (SELECT name FROM tags) ORDER BY tag`)
+	if err != nil {
+		return err
+	}
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			rows.Close()
+			return err
+		}
+		tags = append(tags, tag)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if err := registerTags(ctx, tx, tags, time.Now()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// retagPosts replaces one tag with another on every post that carries it,
+// increments the version of those posts, records a revision of each by the
+// system and returns them as they were before.
+func retagPosts(ctx context.Context, tx *sql.Tx, from, to string, now time.Time) ([]*models.BlogPost, error) {
+	posts, err := queryPosts(ctx, tx, `WHERE p.post_id IN (SELECT post_id FROM post_tags WHERE tag = ?)`, from)
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		if _, err := tx.ExecContext(ctx, `UPDATE posts SET version = version + 1, updated_at = ? WHERE post_id = ?`,
+			formatSQLTime(now), post.PostId); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM post_tags WHERE post_id = ?`, post.PostId); err != nil {
+			return nil, err
+		}
+		retagged := post.Clone()
+		retagged.Tags = replaceTag(post.Tags, from, to)
+		retagged.UpdatedAt = now
+		retagged.Version++
+		if err := insertTags(ctx, tx, post.PostId, retagged.Tags); err != nil {
+			return nil, err
+		}
+		if err := insertRevision(ctx, tx, models.NewPostRevision(retagged, models.SystemEditor)); err != nil {
+			return nil, err
+		}
+	}
+	return posts, nil
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/query"
+)
+
+func openSQLStorage(t *testing.T, path string) *SQLBlogStorage {
+	t.Helper()
+	s, err := NewSQLBlogStorage(path)
+	if err != nil {
+		t.Fatalf("NewSQLBlogStorage failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSQLBlogStorage_SurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "blog.db")
+
+	s := openSQLStorage(t, path)
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p1", Title: "Title", Tags: []string{"go", "sql"}}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Reopening runs the migrations again, which must be a no-op
+	reopened := openSQLStorage(t, path)
+	got, err := reopened.GetPost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.Title != "Title" || len(got.Tags) != 2 || got.Tags[0] != "go" || got.Tags[1] != "sql" {
+		t.Errorf("unexpected post after reopen: %+v", got)
+	}
+	// The search index is rebuilt from the database
+	if results, _, err := reopened.SearchPosts(ctx, &models.SearchPostsRequest{Query: "title sql"}); err != nil || len(results) != 1 {
+		t.Errorf("expected to find p1 after reopen, got %v, %v", results, err)
+	}
+
+	var version int
+	if err := reopened.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		t.Fatalf("reading schema version failed: %v", err)
+	}
+	if version != len(sqlMigrations) {
+		t.Errorf("expected schema version %d, got %d", len(sqlMigrations), version)
+	}
+}
+
+func TestSQLBlogStorage_PostTagsTable(t *testing.T) {
+	ctx := context.Background()
+	s := openSQLStorage(t, filepath.Join(t.TempDir(), "blog.db"))
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p1", Tags: []string{"go", "sql"}}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Tags: []string{"rust"}}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p2", Tags: []string{"rust"}}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM post_tags WHERE tag = 'rust'`).Scan(&count); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 posts tagged rust, got %d", count)
+	}
+
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM post_tags WHERE post_id = 'p1'`).Scan(&count); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected tags of deleted post to be removed, got %d", count)
+	}
+}
+
+func TestSQLBlogStorage_RevisionsBackfilled(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "blog.db")
+
+	// Build a database at schema version 2, from before revision history
+	s := openSQLStorage(t, path)
+	for _, stmt := range []string{
+		`DROP TABLE idempotency_keys`,
+		`DROP TABLE tags`,
+		`DROP INDEX posts_by_author_id`,
+		`ALTER TABLE posts DROP COLUMN author_id`,
+		`DROP TABLE authors`,
+		`DROP INDEX posts_by_scheduled_date`,
+		`ALTER TABLE posts DROP COLUMN status`,
+		`DROP INDEX posts_by_deleted_at`,
+		`ALTER TABLE posts DROP COLUMN deleted_at`,
+		`DROP TABLE post_revisions`,
+		`DELETE FROM schema_migrations WHERE version > 2`,
+		`INSERT INTO posts (post_id, title, content, author, publication_date, updated_at, version)
+			VALUES ('p1', 'old', 'content', 'author', '2025-01-01T00:00:00.000000000Z', '2025-01-02T00:00:00.000000000Z', 4)`,
+		`INSERT INTO post_tags (post_id, position, tag) VALUES ('p1', 0, 'go'), ('p1', 1, 'sql')`,
+	} {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened := openSQLStorage(t, path)
+	revisions, err := reopened.ListRevisions(ctx, "p1")
+	if err != nil {
+		t.Fatalf("ListRevisions failed: %v", err)
+	}
+	if len(revisions) != 1 {
+		t.Fatalf("expected one backfilled revision, got %d", len(revisions))
+	}
+	r := revisions[0]
+	if r.Revision != 4 || r.Title != "old" || len(r.Tags) != 2 || r.Tags[0] != "go" || r.Tags[1] != "sql" {
+		t.Errorf("unexpected backfilled revision: %+v", r)
+	}
+	if post, err := reopened.GetPost(ctx, "p1"); err != nil || post.Status != models.StatusPublished {
+		t.Errorf("expected the existing post to be published, got %+v, %v", post, err)
+	}
+	tags, _, err := reopened.ListTags(ctx, &models.ListTagsRequest{})
+	if err != nil || len(tags) != 2 || tags[0].Slug != "go" || tags[1].UsageCount != 1 {
+		t.Errorf("expected the existing tags to be registered, got %+v, %v", tags, err)
+	}
+}
+
+func TestSQLFilter(t *testing.T) {
+	tests := []struct {
+		filter    string
+		condition string
+		exact     bool
+	}{
+		{"", "", true},
+		{`author = "x" AND NOT status = "draft"`, "(p.author = ? AND NOT p.status = ?)", true},
+		{`publication_date > "2025-01-01" OR author_id != "a1"`, "(p.publication_date > ? OR COALESCE(p.author_id, '') != ?)", true},
+		// The parts without an exact translation only narrow the selection
+		{`tags:"go" AND title < "m"`, "p.title < ?", false},
+		{`tags:"go" OR title < "m"`, "", false},
+		{`NOT title:"go"`, "", false},
+	}
+	for _, tt := range tests {
+		expr, err := query.Parse(tt.filter)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.filter, err)
+		}
+		condition, _, exact := sqlFilter(expr)
+		if condition != tt.condition || exact != tt.exact {
+			t.Errorf("sqlFilter(%q) = %q, %v; expected %q, %v", tt.filter, condition, exact, tt.condition, tt.exact)
+		}
+	}
+}
+
+func TestSQLBlogStorage_ListPostsFilteredInGo(t *testing.T) {
+	ctx := context.Background()
+	s := openSQLStorage(t, filepath.Join(t.TempDir(), "blog.db"))
+
+	// Every 100th post matches a filter that is evaluated in Go, so pages
+	// are filled from several batches of listScanBatch posts
+	start := time.Now().Add(-time.Hour)
+	posts := make([]*models.BlogPost, 3*listScanBatch)
+	var want []string
+	for i := range posts {
+		title := "other"
+		if i%100 == 0 {
+			title = "Match"
+			want = append(want, fmt.Sprintf("p%04d", i))
+		}
+		posts[i] = &models.BlogPost{PostId: fmt.Sprintf("p%04d", i), Title: title, PublicationDate: start.Add(time.Duration(i) * time.Second)}
+	}
+	if err := s.CreatePosts(ctx, posts); err != nil {
+		t.Fatalf("CreatePosts failed: %v", err)
+	}
+
+	var got []string
+	req := &models.ListBlogPostsRequest{Filter: `title:"match"`, OrderBy: "publication_date asc", PageSize: 4}
+	for {
+		page, next, err := s.ListPosts(ctx, req)
+		if err != nil {
+			t.Fatalf("ListPosts failed: %v", err)
+		}
+		for _, post := range page {
+			got = append(got, post.PostId)
+		}
+		if next == "" {
+			break
+		}
+		req.PageToken = next
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,2132 @@
+// Package storagetest provides a conformance suite for storage.Storage
+// implementations. Every backend runs it from its own tests:
+//
+//	func TestMyStorage(t *testing.T) {
+//		storagetest.RunConformance(t, func(t *testing.T) storage.Storage {
+//			return newMyStorage(t)
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/storage"
+)
+
+// Factory returns a new, empty storage for a single test. It should register
+// any cleanup with t.Cleanup.
+type Factory func(t *testing.T) storage.Storage
+
+// RunConformance runs the full behavioral contract of storage.Storage
+// against storages created by newStorage.
+func RunConformance(t *testing.T, newStorage Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s storage.Storage)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateKeepsPublicationDate", testCreateKeepsPublicationDate},
+		{"DuplicateID", testDuplicateID},
+		{"CreatePosts", testCreatePosts},
+		{"CreatePostOnce", testCreatePostOnce},
+		{"NotFound", testNotFound},
+		{"PartialUpdate", testPartialUpdate},
+		{"Delete", testDelete},
+		{"ListPagination", testListPagination},
+		{"ListStableUnderWrites", testListStableUnderWrites},
+		{"ListFilterAndOrder", testListFilterAndOrder},
+		{"ListInvalidRequests", testListInvalidRequests},
+		{"ConcurrentCreates", testConcurrentCreates},
+		{"ConcurrentDuplicateCreates", testConcurrentDuplicateCreates},
+		{"ConcurrentUpdates", testConcurrentUpdates},
+		{"UpdateMask", testUpdateMask},
+		{"Versioning", testVersioning},
+		{"Revisions", testRevisions},
+		{"Trash", testTrash},
+		{"BatchPosts", testBatchPosts},
+		{"PurgeTrashedBefore", testPurgeTrashedBefore},
+		{"CreateStatus", testCreateStatus},
+		{"StatusTransitions", testStatusTransitions},
+		{"PublishScheduled", testPublishScheduled},
+		{"Authors", testAuthors},
+		{"PostAuthors", testPostAuthors},
+		{"ListAuthors", testListAuthors},
+		{"Tags", testTags},
+		{"RenameTag", testRenameTag},
+		{"MergeTags", testMergeTags},
+		{"ListPostsByAuthorAndTag", testListPostsByAuthorAndTag},
+		{"SearchPosts", testSearchPosts},
+		{"SuggestTags", testSuggestTags},
+		{"RelatedPosts", testRelatedPosts},
+		{"WatchPosts", testWatchPosts},
+		{"ExportPosts", testExportPosts},
+		{"ConcurrentConditionalUpdates", testConcurrentConditionalUpdates},
+		{"ContextCanceled", testContextCanceled},
+		{"NoAliasing", testNoAliasing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStorage(t))
+		})
+	}
+}
+
+func mustCreate(t *testing.T, s storage.BlogStorage, post *models.BlogPost) {
+	t.Helper()
+	if err := s.CreatePost(context.Background(), post); err != nil {
+		t.Fatalf("CreatePost(%s) failed: %v", post.PostId, err)
+	}
+}
+
+// listAll pages through every post matching req.
+func listAll(t *testing.T, s storage.BlogStorage, req models.ListBlogPostsRequest) []string {
+	t.Helper()
+	var ids []string
+	for {
+		page, next, err := s.ListPosts(context.Background(), &req)
+		if err != nil {
+			t.Fatalf("ListPosts failed: %v", err)
+		}
+		for _, post := range page {
+			ids = append(ids, post.PostId)
+		}
+		if next == "" {
+			return ids
+		}
+		req.PageToken = next
+	}
+}
+
+func testCreateAndGet(t *testing.T, s storage.Storage) {
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "Title", Content: "Content", Author: "Author", Tags: []string{"go", "grpc"}})
+
+	got, err := s.GetPost(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.PostId != "p1" || got.Title != "Title" || got.Content != "Content" || got.Author != "Author" {
+		t.Errorf("unexpected post: %+v", got)
+	}
+	if fmt.Sprint(got.Tags) != "[go grpc]" {
+		t.Errorf("expected tags in order, got %v", got.Tags)
+	}
+	if got.PublicationDate.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("expected publication date and updated at to be set: %+v", got)
+	}
+}
+
+func testCreateKeepsPublicationDate(t *testing.T, s storage.Storage) {
+	published := time.Date(2030, 6, 1, 9, 30, 0, 0, time.UTC)
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", PublicationDate: published})
+
+	got, err := s.GetPost(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if !got.PublicationDate.Equal(published) {
+		t.Errorf("expected publication date %v, got %v", published, got.PublicationDate)
+	}
+}
+
+func testDuplicateID(t *testing.T, s storage.Storage) {
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "First"})
+
+	err := s.CreatePost(context.Background(), &models.BlogPost{PostId: "p1", Title: "Second"})
+	if !errors.Is(err, models.ErrDuplicatePost) {
+		t.Errorf("expected ErrDuplicatePost, got: %v", err)
+	}
+	got, _ := s.GetPost(context.Background(), "p1")
+	if got == nil || got.Title != "First" {
+		t.Errorf("duplicate create must not overwrite the original: %+v", got)
+	}
+}
+
+func testCreatePosts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a1", Name: "Aman Pandae", ProfileName: "aman"})
+	mustCreate(t, s, &models.BlogPost{PostId: "existing"})
+
+	posts := []*models.BlogPost{
+		{PostId: "p1", Title: "One", AuthorId: "a1", Tags: []string{"go", "import"}},
+		{PostId: "p2", Title: "Two", Tags: []string{"import"}},
+		{PostId: "p3", Title: "Three", PublicationDate: time.Now().Add(time.Hour)},
+	}
+	if err := s.CreatePosts(ctx, posts); err != nil {
+		t.Fatalf("CreatePosts failed: %v", err)
+	}
+	for _, post := range posts {
+		got, err := s.GetPost(ctx, post.PostId)
+		if err != nil || got.Title != post.Title || got.Version != 1 {
+			t.Errorf("expected %s to be created at version 1, got %+v, %v", post.PostId, got, err)
+		}
+	}
+	if got, err := s.GetPost(ctx, "p1"); err != nil || got.Author != "Aman Pandae" {
+		t.Errorf("expected p1 to carry the author's name, got %+v, %v", got, err)
+	}
+	if got, err := s.GetPost(ctx, "p3"); err != nil || got.Status != models.StatusScheduled {
+		t.Errorf("expected p3 to be scheduled, got %+v, %v", got, err)
+	}
+	if tag := tagsByName(t, s)["import"]; tag == nil || tag.UsageCount != 2 {
+		t.Errorf("expected a single import tag used twice, got %+v", tag)
+	}
+	if revisions, err := s.ListRevisions(ctx, "p2"); err != nil || len(revisions) != 1 {
+		t.Errorf("expected one revision of p2, got %d, %v", len(revisions), err)
+	}
+
+	// A batch with a post that cannot be created leaves everything as it was
+	for _, tt := range []struct {
+		name  string
+		posts []*models.BlogPost
+		index int
+		want  error
+	}{
+		{"existing", []*models.BlogPost{{PostId: "new1", Tags: []string{"orphan"}}, {PostId: "existing"}}, 1, models.ErrDuplicatePost},
+		{"repeated", []*models.BlogPost{{PostId: "new1"}, {PostId: "new2"}, {PostId: "new1"}}, 2, models.ErrDuplicatePost},
+		{"author", []*models.BlogPost{{PostId: "new1", AuthorId: "missing"}}, 0, models.ErrAuthorNotFound},
+		{"status", []*models.BlogPost{{PostId: "new1"}, {PostId: "new2", Status: models.StatusScheduled}}, 1, models.ErrPublishInPast},
+	} {
+		err := s.CreatePosts(ctx, tt.posts)
+		var itemErr *models.ItemError
+		if !errors.As(err, &itemErr) || itemErr.Index != tt.index || !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v for item %d, got: %v", tt.name, tt.want, tt.index, err)
+		}
+		for _, id := range []string{"new1", "new2"} {
+			if _, err := s.GetPost(ctx, id); !errors.Is(err, models.ErrPostNotFound) {
+				t.Errorf("%s: expected %s not to be created, got: %v", tt.name, id, err)
+			}
+		}
+	}
+	if _, ok := tagsByName(t, s)["orphan"]; ok {
+		t.Errorf("expected the tag of a failed batch not to be registered")
+	}
+
+	if err := s.CreatePosts(ctx, nil); err != nil {
+		t.Errorf("expected an empty batch to succeed, got: %v", err)
+	}
+}
+
+func testCreatePostOnce(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	later := time.Now().Add(time.Hour)
+	createOnce := func(postId, requestId, fingerprint string, expiresAt time.Time) (*models.BlogPost, bool, error) {
+		key := &models.IdempotencyKey{RequestId: requestId, Fingerprint: fingerprint, ExpiresAt: expiresAt}
+		return s.CreatePostOnce(ctx, &models.BlogPost{PostId: postId, Title: "Title " + postId, Tags: []string{"go"}}, key)
+	}
+
+	post, created, err := createOnce("p1", "r1", "f1", later)
+	if err != nil || !created || post.PostId != "p1" || post.Version != 1 {
+		t.Fatalf("CreatePostOnce: expected p1 to be created, got %+v, %v, %v", post, created, err)
+	}
+	if _, err := s.GetPost(ctx, "p1"); err != nil {
+		t.Errorf("expected p1 to be stored, got: %v", err)
+	}
+
+	// A retry returns the post as it is now and creates nothing
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "Edited"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	post, created, err = createOnce("p2", "r1", "f1", later)
+	if err != nil || created || post.PostId != "p1" || post.Title != "Edited" {
+		t.Errorf("CreatePostOnce(retry): expected the edited p1, got %+v, %v, %v", post, created, err)
+	}
+	if _, err := s.GetPost(ctx, "p2"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected the retry not to create p2, got: %v", err)
+	}
+	if _, _, err := createOnce("p2", "r1", "other", later); !errors.Is(err, models.ErrRequestIDReused) {
+		t.Errorf("CreatePostOnce(other payload): expected ErrRequestIDReused, got: %v", err)
+	}
+
+	// A failed create leaves the request ID unused
+	key := &models.IdempotencyKey{RequestId: "r2", Fingerprint: "f2", ExpiresAt: later}
+	if _, _, err := s.CreatePostOnce(ctx, &models.BlogPost{PostId: "p2", AuthorId: "missing"}, key); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("CreatePostOnce: expected ErrAuthorNotFound, got: %v", err)
+	}
+	if _, _, err := createOnce("p1", "r2", "f2", later); !errors.Is(err, models.ErrDuplicatePost) {
+		t.Errorf("CreatePostOnce: expected ErrDuplicatePost, got: %v", err)
+	}
+	if post, created, err := createOnce("p2", "r2", "f2", later); err != nil || !created || post.PostId != "p2" {
+		t.Errorf("CreatePostOnce: expected p2 to be created after the failures, got %+v, %v, %v", post, created, err)
+	}
+
+	// Keys outlive their post, so a retry after a delete does not create it again
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p2"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if _, _, err := createOnce("p3", "r2", "f2", later); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("CreatePostOnce(deleted): expected ErrPostNotFound, got: %v", err)
+	}
+
+	// Expired keys are ignored and replaced, and forgotten by
+	// ExpireIdempotencyKeys
+	past := time.Now().Add(-time.Minute)
+	if _, created, err := createOnce("p3", "r3", "f3", past); err != nil || !created {
+		t.Fatalf("CreatePostOnce: expected p3 to be created, got %v, %v", created, err)
+	}
+	if post, created, err := createOnce("p4", "r3", "other", later); err != nil || !created || post.PostId != "p4" {
+		t.Errorf("CreatePostOnce(expired): expected p4 to be created, got %+v, %v, %v", post, created, err)
+	}
+	if _, created, err := createOnce("p5", "r5", "f5", past); err != nil || !created {
+		t.Fatalf("CreatePostOnce: expected p5 to be created, got %v, %v", created, err)
+	}
+	if n, err := s.ExpireIdempotencyKeys(ctx, time.Now()); err != nil || n != 1 {
+		t.Errorf("ExpireIdempotencyKeys: expected 1 expired key, got %d, %v", n, err)
+	}
+	if post, _, err := createOnce("p6", "r3", "other", later); err != nil || post.PostId != "p4" {
+		t.Errorf("expected the key replacing an expired one to be kept, got %+v, %v", post, err)
+	}
+	if n, err := s.ExpireIdempotencyKeys(ctx, later.Add(time.Minute)); err != nil || n != 3 {
+		t.Errorf("ExpireIdempotencyKeys: expected the 3 remaining keys to expire, got %d, %v", n, err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := s.CreatePostOnce(canceled, &models.BlogPost{PostId: "p7"}, &models.IdempotencyKey{RequestId: "r7", ExpiresAt: later}); err == nil {
+		t.Errorf("CreatePostOnce: expected an error for a canceled context")
+	}
+	if _, err := s.ExpireIdempotencyKeys(canceled, time.Now()); err == nil {
+		t.Errorf("ExpireIdempotencyKeys: expected an error for a canceled context")
+	}
+}
+
+func testNotFound(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	if _, err := s.GetPost(ctx, "missing"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("GetPost: expected ErrPostNotFound, got: %v", err)
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "missing", Title: "x"}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("UpdatePost: expected ErrPostNotFound, got: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "missing"}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("DeletePost: expected ErrPostNotFound, got: %v", err)
+	}
+}
+
+func testPartialUpdate(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "Title", Content: "Content", Author: "Author", Tags: []string{"go"}})
+	before, _ := s.GetPost(ctx, "p1")
+
+	updated, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "New Title"})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if updated.Title != "New Title" || updated.Content != "Content" || updated.Author != "Author" || fmt.Sprint(updated.Tags) != "[go]" {
+		t.Errorf("only the title should change: %+v", updated)
+	}
+	if !updated.PublicationDate.Equal(before.PublicationDate) {
+		t.Errorf("publication date must not change on update")
+	}
+	if updated.UpdatedAt.Before(before.UpdatedAt) {
+		t.Errorf("updated at must not go backwards")
+	}
+
+	updated, err = s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Tags: []string{"rust", "wasm"}})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if updated.Title != "New Title" || fmt.Sprint(updated.Tags) != "[rust wasm]" {
+		t.Errorf("only the tags should change: %+v", updated)
+	}
+
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.Title != "New Title" || got.Content != "Content" || fmt.Sprint(got.Tags) != "[rust wasm]" {
+		t.Errorf("updates not persisted: %+v", got)
+	}
+}
+
+func testDelete(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1"})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2"})
+
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if _, err := s.GetPost(ctx, "p1"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected deleted post to be gone, got: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected second delete to fail with ErrPostNotFound, got: %v", err)
+	}
+	if _, err := s.GetPost(ctx, "p2"); err != nil {
+		t.Errorf("deleting one post must not affect others: %v", err)
+	}
+}
+
+func testListPagination(t *testing.T, s storage.Storage) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		// Pairs of posts share a publication date to exercise the tiebreak
+		mustCreate(t, s, &models.BlogPost{PostId: fmt.Sprintf("p%d", i), PublicationDate: base.Add(time.Duration(i/2) * time.Hour)})
+	}
+
+	ids := listAll(t, s, models.ListBlogPostsRequest{PageSize: 3})
+	want := []string{"p6", "p4", "p5", "p2", "p3", "p0", "p1"}
+	if fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+
+	page, next, err := s.ListPosts(context.Background(), &models.ListBlogPostsRequest{})
+	if err != nil || len(page) != 7 || next != "" {
+		t.Errorf("expected a single default-sized page, got %d posts, next %q, err %v", len(page), next, err)
+	}
+}
+
+func testListStableUnderWrites(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		mustCreate(t, s, &models.BlogPost{PostId: fmt.Sprintf("p%d", i), PublicationDate: base.Add(time.Duration(i) * time.Hour)})
+	}
+	page, next, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{PageSize: 3})
+	if err != nil || len(page) != 3 {
+		t.Fatalf("ListPosts failed: %v, %d posts", err, len(page))
+	}
+
+	// A newer post and a deleted post from the first page must not shift
+	// the second page.
+	mustCreate(t, s, &models.BlogPost{PostId: "new", PublicationDate: base.Add(24 * time.Hour)})
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: page[0].PostId}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+
+	page, _, err = s.ListPosts(ctx, &models.ListBlogPostsRequest{PageSize: 3, PageToken: next})
+	if err != nil {
+		t.Fatalf("ListPosts failed: %v", err)
+	}
+	if len(page) != 3 || page[0].PostId != "p2" || page[1].PostId != "p1" || page[2].PostId != "p0" {
+		t.Errorf("unexpected second page: %v", page)
+	}
+}
+
+func testListFilterAndOrder(t *testing.T, s storage.Storage) {
+	for _, post := range []*models.BlogPost{
+		{PostId: "a", Title: "Charlie", Author: "x", Tags: []string{"go"}},
+		{PostId: "b", Title: "Alpha", Author: "x", Tags: []string{"go", "grpc"}},
+		{PostId: "c", Title: "Bravo", Author: "y", Tags: []string{"go"}},
+		{PostId: "d", Title: "Delta", Author: "x", Tags: []string{"rust"}},
+	} {
+		mustCreate(t, s, post)
+	}
+
+	ids := listAll(t, s, models.ListBlogPostsRequest{Filter: `author = "x" AND tags:"go"`, OrderBy: "title asc", PageSize: 1})
+	if fmt.Sprint(ids) != "[b a]" {
+		t.Errorf("expected [b a], got %v", ids)
+	}
+	ids = listAll(t, s, models.ListBlogPostsRequest{Filter: `NOT author = "x"`})
+	if fmt.Sprint(ids) != "[c]" {
+		t.Errorf("expected [c], got %v", ids)
+	}
+	ids = listAll(t, s, models.ListBlogPostsRequest{OrderBy: "title desc"})
+	if fmt.Sprint(ids) != "[d a c b]" {
+		t.Errorf("expected [d a c b], got %v", ids)
+	}
+}
+
+func testListInvalidRequests(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1"})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2"})
+
+	if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{PageToken: "not-a-token"}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken, got: %v", err)
+	}
+	if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{PageSize: -1}); !errors.Is(err, models.ErrInvalidPageSize) {
+		t.Errorf("expected ErrInvalidPageSize, got: %v", err)
+	}
+	if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{Filter: "title ="}); err == nil {
+		t.Errorf("expected error for invalid filter")
+	}
+	if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{OrderBy: "tags"}); err == nil {
+		t.Errorf("expected error for invalid order_by")
+	}
+
+	// A token is bound to the query it was issued for
+	_, next, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{PageSize: 1})
+	if err != nil || next == "" {
+		t.Fatalf("expected a next page token, got %q, %v", next, err)
+	}
+	if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{PageSize: 1, PageToken: next, OrderBy: "title asc"}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken when reusing a token with another order, got: %v", err)
+	}
+}
+
+func testConcurrentCreates(t *testing.T, s storage.Storage) {
+	const writers, perWriter = 8, 10
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				post := &models.BlogPost{PostId: fmt.Sprintf("w%d-%d", w, i), Title: "t", Tags: []string{"go"}}
+				if err := s.CreatePost(context.Background(), post); err != nil {
+					t.Errorf("CreatePost(%s) failed: %v", post.PostId, err)
+				}
+			}
+		}(w)
+	}
+	// Readers run alongside the writers
+	for r := 0; r < 2; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, _, err := s.ListPosts(context.Background(), &models.ListBlogPostsRequest{}); err != nil {
+					t.Errorf("ListPosts failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ids := listAll(t, s, models.ListBlogPostsRequest{PageSize: 7}); len(ids) != writers*perWriter {
+		t.Errorf("expected %d posts, got %d", writers*perWriter, len(ids))
+	}
+}
+
+func testConcurrentDuplicateCreates(t *testing.T, s storage.Storage) {
+	const writers = 8
+	var created, duplicates atomic.Int32
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			err := s.CreatePost(context.Background(), &models.BlogPost{PostId: "same", Title: fmt.Sprint(w)})
+			switch {
+			case err == nil:
+				created.Add(1)
+			case errors.Is(err, models.ErrDuplicatePost):
+				duplicates.Add(1)
+			default:
+				t.Errorf("CreatePost failed: %v", err)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if created.Load() != 1 || duplicates.Load() != writers-1 {
+		t.Errorf("expected exactly one create to win, got %d created and %d duplicates", created.Load(), duplicates.Load())
+	}
+}
+
+func testConcurrentUpdates(t *testing.T, s storage.Storage) {
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "start", Content: "content"})
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			req := &models.UpdateBlogPostRequest{PostId: "p1", Title: fmt.Sprintf("title-%d", w), Tags: []string{fmt.Sprintf("tag-%d", w)}}
+			if _, err := s.UpdatePost(context.Background(), req); err != nil {
+				t.Errorf("UpdatePost failed: %v", err)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	got, err := s.GetPost(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	// Each update is atomic, so title and tags must come from the same writer
+	var w int
+	if _, err := fmt.Sscanf(got.Title, "title-%d", &w); err != nil || fmt.Sprint(got.Tags) != fmt.Sprintf("[tag-%d]", w) {
+		t.Errorf("torn update: title %q with tags %v", got.Title, got.Tags)
+	}
+	if got.Content != "content" {
+		t.Errorf("content must be untouched, got %q", got.Content)
+	}
+}
+
+func testUpdateMask(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "Title", Content: "Content", Tags: []string{"go"}})
+
+	// Only masked fields change, and an empty tag list clears the tags
+	updated, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "ignored", UpdateMask: []string{"tags"}})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if updated.Title != "Title" || len(updated.Tags) != 0 {
+		t.Errorf("expected only tags to be cleared, got %+v", updated)
+	}
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if len(got.Tags) != 0 {
+		t.Errorf("expected stored tags to be cleared, got %v", got.Tags)
+	}
+
+	updated, err = s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Content: "New", Tags: []string{"a", "b"}, UpdateMask: []string{"content", "tags"}})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if updated.Title != "Title" || updated.Content != "New" || fmt.Sprint(updated.Tags) != "[a b]" {
+		t.Errorf("unexpected post after masked update: %+v", updated)
+	}
+
+	tests := []struct {
+		mask  []string
+		title string
+		want  error
+	}{
+		{[]string{"author"}, "", models.ErrImmutableField},
+		{[]string{"publication_date"}, "", models.ErrImmutableField},
+		{[]string{"tags", "nope"}, "", models.ErrInvalidUpdateMask},
+		{[]string{"title"}, "", models.ErrEmptyTitle},
+	}
+	for _, tt := range tests {
+		req := &models.UpdateBlogPostRequest{PostId: "p1", Title: tt.title, UpdateMask: tt.mask}
+		if _, err := s.UpdatePost(ctx, req); !errors.Is(err, tt.want) {
+			t.Errorf("mask %v: expected %v, got: %v", tt.mask, tt.want, err)
+		}
+	}
+	if got, err = s.GetPost(ctx, "p1"); err != nil || got.Version != 3 {
+		t.Errorf("rejected updates must not change the post, got %+v, %v", got, err)
+	}
+}
+
+func testVersioning(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	post := &models.BlogPost{PostId: "p1", Title: "Title"}
+	mustCreate(t, s, post)
+	if post.Version != 1 {
+		t.Errorf("expected a new post at version 1, got %d", post.Version)
+	}
+
+	updated, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "v2", ExpectedVersion: 1})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("expected version 2 after update, got %d", updated.Version)
+	}
+	// No expected version skips the check but still bumps the version
+	if updated, err = s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "v3"}); err != nil || updated.Version != 3 {
+		t.Fatalf("expected version 3 after unconditional update, got %+v, %v", updated, err)
+	}
+
+	// A stale version must fail and leave the post untouched
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "stale", ExpectedVersion: 2}); !errors.Is(err, models.ErrVersionMismatch) {
+		t.Errorf("UpdatePost: expected ErrVersionMismatch, got: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1", ExpectedVersion: 2}); !errors.Is(err, models.ErrVersionMismatch) {
+		t.Errorf("DeletePost: expected ErrVersionMismatch, got: %v", err)
+	}
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.Title != "v3" || got.Version != 3 {
+		t.Errorf("rejected writes must not change the post, got %+v", got)
+	}
+
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1", ExpectedVersion: 3}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	// Not found takes precedence over a version mismatch
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1", ExpectedVersion: 3}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("DeletePost: expected ErrPostNotFound, got: %v", err)
+	}
+}
+
+func testRevisions(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "v1", Content: "Content", Author: "Author", Tags: []string{"go"}})
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "v2", Editor: "Alice"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", UpdateMask: []string{"tags"}, Editor: "Bob"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	// Rejected updates leave no trace in the history
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "stale", ExpectedVersion: 1}); !errors.Is(err, models.ErrVersionMismatch) {
+		t.Fatalf("UpdatePost: expected ErrVersionMismatch, got: %v", err)
+	}
+
+	revisions, err := s.ListRevisions(ctx, "p1")
+	if err != nil {
+		t.Fatalf("ListRevisions failed: %v", err)
+	}
+	var got []string
+	for _, r := range revisions {
+		got = append(got, fmt.Sprintf("%d:%s:%s:%v", r.Revision, r.Title, r.Editor, r.Tags))
+	}
+	if want := "[3:v2:Bob:[] 2:v2:Alice:[go] 1:v1:Author:[go]]"; fmt.Sprint(got) != want {
+		t.Errorf("expected revisions %s, got %v", want, got)
+	}
+	if revisions[2].Content != "Content" || revisions[2].CreatedAt.IsZero() {
+		t.Errorf("incomplete first revision: %+v", revisions[2])
+	}
+
+	first, err := s.GetRevision(ctx, "p1", 1)
+	if err != nil {
+		t.Fatalf("GetRevision failed: %v", err)
+	}
+	if first.Title != "v1" || fmt.Sprint(first.Tags) != "[go]" {
+		t.Errorf("unexpected revision 1: %+v", first)
+	}
+	// Revisions are copies too
+	first.Tags[0] = "changed"
+	if again, err := s.GetRevision(ctx, "p1", 1); err != nil || again.Tags[0] != "go" {
+		t.Errorf("revision shares memory with its caller: %+v, %v", again, err)
+	}
+
+	if _, err := s.GetRevision(ctx, "p1", 4); !errors.Is(err, models.ErrRevisionNotFound) {
+		t.Errorf("GetRevision: expected ErrRevisionNotFound, got: %v", err)
+	}
+	if _, err := s.ListRevisions(ctx, "missing"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("ListRevisions: expected ErrPostNotFound, got: %v", err)
+	}
+
+	// The history goes away with the post, and a new post with the same ID
+	// starts over
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if _, err := s.GetRevision(ctx, "p1", 1); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("GetRevision: expected ErrPostNotFound after delete, got: %v", err)
+	}
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "new", Author: "Author"})
+	if revisions, err := s.ListRevisions(ctx, "p1"); err != nil || len(revisions) != 1 {
+		t.Errorf("expected a fresh history, got %d revisions, %v", len(revisions), err)
+	}
+}
+
+func testTrash(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "Title"})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", Title: "Title"})
+
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1", ExpectedVersion: 2}); !errors.Is(err, models.ErrVersionMismatch) {
+		t.Errorf("TrashPost: expected ErrVersionMismatch, got: %v", err)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1", ExpectedVersion: 1}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	// Trashed posts are hidden from everything but the trash methods
+	if _, err := s.GetPost(ctx, "p1"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("GetPost: expected ErrPostNotFound, got: %v", err)
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "x"}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("UpdatePost: expected ErrPostNotFound, got: %v", err)
+	}
+	if _, err := s.ListRevisions(ctx, "p1"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("ListRevisions: expected ErrPostNotFound, got: %v", err)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("TrashPost: expected ErrPostNotFound for a trashed post, got: %v", err)
+	}
+	if got := listAll(t, s, models.ListBlogPostsRequest{}); fmt.Sprint(got) != "[p2]" {
+		t.Errorf("expected only p2 in the listing, got %v", got)
+	}
+	page, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{ShowDeleted: true, Filter: `post_id = "p1"`})
+	if err != nil || len(page) != 1 || !page[0].Trashed() {
+		t.Errorf("expected trashed p1 with show_deleted, got %+v, %v", page, err)
+	}
+	// The ID stays taken while the post is in the trash
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p1"}); !errors.Is(err, models.ErrDuplicatePost) {
+		t.Errorf("CreatePost: expected ErrDuplicatePost, got: %v", err)
+	}
+
+	restored, err := s.RestorePost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if restored.Trashed() || restored.Title != "Title" || restored.Version != 1 {
+		t.Errorf("unexpected restored post: %+v", restored)
+	}
+	if _, err := s.GetPost(ctx, "p1"); err != nil {
+		t.Errorf("GetPost after restore failed: %v", err)
+	}
+	if _, err := s.RestorePost(ctx, "p1"); !errors.Is(err, models.ErrPostNotTrashed) {
+		t.Errorf("RestorePost: expected ErrPostNotTrashed, got: %v", err)
+	}
+	if err := s.PurgePost(ctx, "p1"); !errors.Is(err, models.ErrPostNotTrashed) {
+		t.Errorf("PurgePost: expected ErrPostNotTrashed for a live post, got: %v", err)
+	}
+
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if err := s.PurgePost(ctx, "p1"); err != nil {
+		t.Fatalf("PurgePost failed: %v", err)
+	}
+	if _, err := s.RestorePost(ctx, "p1"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("RestorePost: expected ErrPostNotFound after purge, got: %v", err)
+	}
+	if err := s.PurgePost(ctx, "p1"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("PurgePost: expected ErrPostNotFound after purge, got: %v", err)
+	}
+
+	// DeletePost skips the trash, and also removes posts already in it
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p2"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p2"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if _, err := s.RestorePost(ctx, "p2"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("RestorePost: expected ErrPostNotFound after delete, got: %v", err)
+	}
+}
+
+func testBatchPosts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	for _, id := range []string{"p1", "p2", "p3", "p4", "marker"} {
+		mustCreate(t, s, &models.BlogPost{PostId: id, Title: "Title " + id, Tags: []string{"go"}})
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p4"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	// Results follow the request, with nil for missing and trashed posts
+	posts, err := s.GetPosts(ctx, []string{"p3", "missing", "p1", "p4", "p3"})
+	if err != nil || len(posts) != 5 {
+		t.Fatalf("GetPosts: expected 5 results, got %d, %v", len(posts), err)
+	}
+	for i, want := range []string{"p3", "", "p1", "", "p3"} {
+		got := ""
+		if posts[i] != nil {
+			got = posts[i].PostId
+		}
+		if got != want {
+			t.Errorf("GetPosts: expected %q at %d, got %q", want, i, got)
+		}
+	}
+	if posts[0].Title != "Title p3" || len(posts[0].Tags) != 1 {
+		t.Errorf("GetPosts: expected the full post, got %+v", posts[0])
+	}
+	posts[0].Title = "changed"
+	if posts[4].Title != "Title p3" {
+		t.Errorf("GetPosts: expected repeated IDs not to share a post")
+	}
+
+	// One error per ID: trashing skips trashed posts and repeats, deleting
+	// permanently also removes trashed posts
+	start := latestSequence(t, s, "marker")
+	errs, err := s.DeletePosts(ctx, &models.BatchDeleteRequest{PostIds: []string{"p1", "missing", "p4", "p1", "p2"}})
+	if err != nil {
+		t.Fatalf("DeletePosts failed: %v", err)
+	}
+	for i, want := range []error{nil, models.ErrPostNotFound, models.ErrPostNotFound, models.ErrPostNotFound, nil} {
+		if !errors.Is(errs[i], want) {
+			t.Errorf("DeletePosts: expected %v at %d, got: %v", want, i, errs[i])
+		}
+	}
+	if ids := listAll(t, s, models.ListBlogPostsRequest{ShowDeleted: true}); len(ids) != 5 {
+		t.Errorf("expected trashed posts to be kept, got %v", ids)
+	}
+	errs, err = s.DeletePosts(ctx, &models.BatchDeleteRequest{PostIds: []string{"p4", "p3"}, Permanent: true})
+	if err != nil || errs[0] != nil || errs[1] != nil {
+		t.Fatalf("DeletePosts(permanent): expected both deleted, got %v, %v", errs, err)
+	}
+	for _, id := range []string{"p3", "p4"} {
+		if _, err := s.RestorePost(ctx, id); !errors.Is(err, models.ErrPostNotFound) {
+			t.Errorf("expected %s to be gone, got: %v", id, err)
+		}
+	}
+
+	// Purging the trashed p4 is not an event
+	if got, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: start}, 3); err != nil ||
+		eventString(got) != "deleted:p1 deleted:p2 deleted:p3" || got[0].Post.Title != "Title p1" {
+		t.Errorf("expected the deletes as events, got %q, %v", eventString(got), err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := s.GetPosts(canceled, []string{"marker"}); err == nil {
+		t.Errorf("GetPosts: expected an error for a canceled context")
+	}
+	if _, err := s.DeletePosts(canceled, &models.BatchDeleteRequest{PostIds: []string{"marker"}}); err == nil {
+		t.Errorf("DeletePosts: expected an error for a canceled context")
+	}
+	if _, err := s.GetPost(ctx, "marker"); err != nil {
+		t.Errorf("expected a canceled DeletePosts to leave the post, got: %v", err)
+	}
+}
+
+func testPurgeTrashedBefore(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	for _, id := range []string{"p1", "p2", "p3"} {
+		mustCreate(t, s, &models.BlogPost{PostId: id})
+	}
+	for _, id := range []string{"p1", "p2"} {
+		if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: id}); err != nil {
+			t.Fatalf("TrashPost failed: %v", err)
+		}
+	}
+
+	if n, err := s.PurgeTrashedBefore(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("expected nothing to expire yet, got %d, %v", n, err)
+	}
+	if n, err := s.PurgeTrashedBefore(ctx, time.Now().Add(time.Hour)); err != nil || n != 2 {
+		t.Errorf("expected 2 purged posts, got %d, %v", n, err)
+	}
+	if got := listAll(t, s, models.ListBlogPostsRequest{ShowDeleted: true}); fmt.Sprint(got) != "[p3]" {
+		t.Errorf("expected only the live post to remain, got %v", got)
+	}
+}
+
+func testCreateStatus(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+	mustCreate(t, s, &models.BlogPost{PostId: "now"})
+	mustCreate(t, s, &models.BlogPost{PostId: "later", PublicationDate: future})
+	mustCreate(t, s, &models.BlogPost{PostId: "draft", Status: models.StatusDraft})
+
+	for id, want := range map[string]models.PostStatus{
+		"now":   models.StatusPublished,
+		"later": models.StatusScheduled,
+		"draft": models.StatusDraft,
+	} {
+		if got, err := s.GetPost(ctx, id); err != nil || got.Status != want {
+			t.Errorf("expected %s to be %s, got %+v, %v", id, want, got, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		post *models.BlogPost
+		want error
+	}{
+		{&models.BlogPost{PostId: "e1", Status: models.StatusScheduled}, models.ErrPublishInPast},
+		{&models.BlogPost{PostId: "e2", Status: models.StatusPublished, PublicationDate: future}, models.ErrPublishInFuture},
+		{&models.BlogPost{PostId: "e3", Status: models.StatusArchived}, models.ErrInvalidStatus},
+		{&models.BlogPost{PostId: "e4", Status: "bogus"}, models.ErrInvalidStatus},
+	} {
+		if err := s.CreatePost(ctx, tt.post); !errors.Is(err, tt.want) {
+			t.Errorf("CreatePost(%s): expected %v, got: %v", tt.post.Status, tt.want, err)
+		}
+	}
+
+	// Only published posts are listed unless the filter asks for a status
+	if got := listAll(t, s, models.ListBlogPostsRequest{}); fmt.Sprint(got) != "[now]" {
+		t.Errorf("expected only the published post to be listed, got %v", got)
+	}
+	if got := listAll(t, s, models.ListBlogPostsRequest{Filter: `status != "published"`, OrderBy: "post_id"}); fmt.Sprint(got) != "[draft later]" {
+		t.Errorf("expected the unpublished posts, got %v", got)
+	}
+}
+
+func testStatusTransitions(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	published := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Status: models.StatusDraft, PublicationDate: published})
+
+	setStatus := func(status models.PostStatus, publishAt time.Time) (*models.BlogPost, error) {
+		return s.SetPostStatus(ctx, &models.SetPostStatusRequest{PostId: "p1", Status: status, PublishAt: publishAt, Editor: "alice"})
+	}
+
+	for _, status := range []models.PostStatus{models.StatusDraft, models.StatusArchived} {
+		if _, err := setStatus(status, time.Time{}); !errors.Is(err, models.ErrInvalidTransition) {
+			t.Errorf("draft -> %s: expected ErrInvalidTransition, got: %v", status, err)
+		}
+	}
+	if _, err := setStatus(models.StatusScheduled, time.Time{}); !errors.Is(err, models.ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus for a scheduled target, got: %v", err)
+	}
+
+	// Publishing with a future date schedules, and again reschedules
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	post, err := setStatus(models.StatusPublished, future.Add(time.Hour))
+	if err != nil || post.Status != models.StatusScheduled {
+		t.Fatalf("expected a scheduled post, got %+v, %v", post, err)
+	}
+	post, err = setStatus(models.StatusPublished, future)
+	if err != nil || post.Status != models.StatusScheduled || !post.PublicationDate.Equal(future) || post.Version != 3 {
+		t.Fatalf("expected a rescheduled post at version 3, got %+v, %v", post, err)
+	}
+
+	// Publishing without a date publishes now
+	before := time.Now()
+	post, err = setStatus(models.StatusPublished, time.Time{})
+	if err != nil || post.Status != models.StatusPublished || post.PublicationDate.Before(before) {
+		t.Fatalf("expected a post published now, got %+v, %v", post, err)
+	}
+	publishedAt := post.PublicationDate
+	if _, err := setStatus(models.StatusPublished, time.Time{}); !errors.Is(err, models.ErrInvalidTransition) {
+		t.Errorf("published -> published: expected ErrInvalidTransition, got: %v", err)
+	}
+
+	// Archiving and republishing keeps the publication date
+	if post, err = setStatus(models.StatusArchived, time.Time{}); err != nil || post.Status != models.StatusArchived {
+		t.Fatalf("expected an archived post, got %+v, %v", post, err)
+	}
+	if _, err := setStatus(models.StatusArchived, time.Time{}); !errors.Is(err, models.ErrInvalidTransition) {
+		t.Errorf("archived -> archived: expected ErrInvalidTransition, got: %v", err)
+	}
+	post, err = setStatus(models.StatusPublished, time.Time{})
+	if err != nil || post.Status != models.StatusPublished || !post.PublicationDate.Equal(publishedAt) {
+		t.Fatalf("expected republished post to keep its date %v, got %+v, %v", publishedAt, post, err)
+	}
+
+	if post, err = setStatus(models.StatusDraft, time.Time{}); err != nil || post.Status != models.StatusDraft {
+		t.Fatalf("expected a draft, got %+v, %v", post, err)
+	}
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil || got.Status != models.StatusDraft || got.Version != post.Version {
+		t.Errorf("status change was not stored: %+v, %v", got, err)
+	}
+	assertRevisionPerVersion(t, s, got)
+	if rev, err := s.GetRevision(ctx, "p1", got.Version); err != nil || rev.Editor != "alice" {
+		t.Errorf("expected the status change to be recorded by its editor, got %+v, %v", rev, err)
+	}
+
+	_, err = s.SetPostStatus(ctx, &models.SetPostStatusRequest{PostId: "p1", Status: models.StatusPublished, ExpectedVersion: 1})
+	if !errors.Is(err, models.ErrVersionMismatch) {
+		t.Errorf("expected ErrVersionMismatch, got: %v", err)
+	}
+	_, err = s.SetPostStatus(ctx, &models.SetPostStatusRequest{PostId: "missing", Status: models.StatusPublished})
+	if !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound, got: %v", err)
+	}
+}
+
+func testPublishScheduled(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	now := time.Now()
+	mustCreate(t, s, &models.BlogPost{PostId: "soon", PublicationDate: now.Add(time.Hour)})
+	mustCreate(t, s, &models.BlogPost{PostId: "later", PublicationDate: now.Add(2 * time.Hour)})
+	mustCreate(t, s, &models.BlogPost{PostId: "trashed", PublicationDate: now.Add(time.Hour)})
+	mustCreate(t, s, &models.BlogPost{PostId: "draft", Status: models.StatusDraft, PublicationDate: now.Add(time.Hour)})
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "trashed"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	if n, err := s.PublishScheduled(ctx, now); err != nil || n != 0 {
+		t.Errorf("expected nothing due yet, got %d, %v", n, err)
+	}
+	if n, err := s.PublishScheduled(ctx, now.Add(time.Hour)); err != nil || n != 1 {
+		t.Errorf("expected 1 published post, got %d, %v", n, err)
+	}
+	got, err := s.GetPost(ctx, "soon")
+	if err != nil || got.Status != models.StatusPublished || got.Version != 2 {
+		t.Errorf("expected soon to be published at version 2, got %+v, %v", got, err)
+	}
+	assertRevisionPerVersion(t, s, got)
+	if rev, err := s.GetRevision(ctx, "soon", 2); err != nil || rev.Editor != models.SystemEditor {
+		t.Errorf("expected publishing to be recorded by the system, got %+v, %v", rev, err)
+	}
+	if got := listAll(t, s, models.ListBlogPostsRequest{}); fmt.Sprint(got) != "[soon]" {
+		t.Errorf("expected only soon to be listed, got %v", got)
+	}
+	// Posts in the trash wait until they are restored
+	if _, err := s.RestorePost(ctx, "trashed"); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if n, err := s.PublishScheduled(ctx, now.Add(time.Hour)); err != nil || n != 1 {
+		t.Errorf("expected the restored post to be published, got %d, %v", n, err)
+	}
+}
+
+// assertRevisionPerVersion checks that post has one revision for each of its
+// versions.
+func assertRevisionPerVersion(t *testing.T, s storage.Storage, post *models.BlogPost) {
+	t.Helper()
+	revisions, err := s.ListRevisions(context.Background(), post.PostId)
+	if err != nil {
+		t.Fatalf("ListRevisions(%s) failed: %v", post.PostId, err)
+	}
+	if int64(len(revisions)) != post.Version {
+		t.Fatalf("expected %d revisions of %s, got %d", post.Version, post.PostId, len(revisions))
+	}
+	for i, rev := range revisions {
+		if want := post.Version - int64(i); rev.Revision != want {
+			t.Errorf("expected revision %d of %s, got %d", want, post.PostId, rev.Revision)
+		}
+	}
+}
+
+func mustCreateAuthor(t *testing.T, s storage.AuthorStorage, author *models.Author) {
+	t.Helper()
+	if err := s.CreateAuthor(context.Background(), author); err != nil {
+		t.Fatalf("CreateAuthor(%s) failed: %v", author.AuthorId, err)
+	}
+}
+
+func testAuthors(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a1", Name: "Aman Pandae", ProfileName: "aman", Email: "aman@example.com"})
+
+	got, err := s.GetAuthor(ctx, "a1")
+	if err != nil {
+		t.Fatalf("GetAuthor failed: %v", err)
+	}
+	if got.Name != "Aman Pandae" || got.Email != "aman@example.com" || got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("unexpected author: %+v", got)
+	}
+	if _, err := s.GetAuthor(ctx, "missing"); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("GetAuthor: expected ErrAuthorNotFound, got: %v", err)
+	}
+
+	// Profile names and emails identify authors regardless of case
+	for _, author := range []*models.Author{
+		{AuthorId: "a1", Name: "Other", ProfileName: "other"},
+		{AuthorId: "a2", Name: "aman pandae", ProfileName: " AMAN "},
+		{AuthorId: "a3", Name: "Someone", ProfileName: "someone", Email: "Aman@Example.com"},
+	} {
+		if err := s.CreateAuthor(ctx, author); !errors.Is(err, models.ErrDuplicateAuthor) {
+			t.Errorf("CreateAuthor(%+v): expected ErrDuplicateAuthor, got: %v", author, err)
+		}
+	}
+	// Authors without an email do not clash with each other
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a2", Name: "Second", ProfileName: "second"})
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a3", Name: "Third", ProfileName: "third"})
+
+	updated, err := s.UpdateAuthor(ctx, &models.UpdateAuthorRequest{AuthorId: "a2", Email: "second@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateAuthor failed: %v", err)
+	}
+	if updated.Name != "Second" || updated.Email != "second@example.com" || updated.CreatedAt.IsZero() {
+		t.Errorf("unexpected updated author: %+v", updated)
+	}
+	if _, err := s.UpdateAuthor(ctx, &models.UpdateAuthorRequest{AuthorId: "a3", ProfileName: "Second"}); !errors.Is(err, models.ErrDuplicateAuthor) {
+		t.Errorf("UpdateAuthor: expected ErrDuplicateAuthor, got: %v", err)
+	}
+	if _, err := s.UpdateAuthor(ctx, &models.UpdateAuthorRequest{AuthorId: "missing", Name: "x"}); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("UpdateAuthor: expected ErrAuthorNotFound, got: %v", err)
+	}
+
+	if err := s.DeleteAuthor(ctx, "a3"); err != nil {
+		t.Fatalf("DeleteAuthor failed: %v", err)
+	}
+	if _, err := s.GetAuthor(ctx, "a3"); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("GetAuthor after delete: expected ErrAuthorNotFound, got: %v", err)
+	}
+	if err := s.DeleteAuthor(ctx, "a3"); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("DeleteAuthor: expected ErrAuthorNotFound, got: %v", err)
+	}
+}
+
+func testPostAuthors(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a1", Name: "Aman Pandae", ProfileName: "aman"})
+
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p0", AuthorId: "missing"}); !errors.Is(err, models.ErrAuthorNotFound) {
+		t.Errorf("CreatePost: expected ErrAuthorNotFound, got: %v", err)
+	}
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", AuthorId: "a1", Author: "ignored"})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", Author: "Guest"})
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil || got.AuthorId != "a1" || got.Author != "Aman Pandae" {
+		t.Fatalf("expected the post to carry the author's name, got %+v, %v", got, err)
+	}
+
+	// Renaming the author renames it on its posts, without a new version
+	if _, err := s.UpdateAuthor(ctx, &models.UpdateAuthorRequest{AuthorId: "a1", Name: "Aman P."}); err != nil {
+		t.Fatalf("UpdateAuthor failed: %v", err)
+	}
+	got, err = s.GetPost(ctx, "p1")
+	if err != nil || got.Author != "Aman P." || got.Version != 1 {
+		t.Errorf("expected the renamed author on the post, got %+v, %v", got, err)
+	}
+	if got, err := s.GetPost(ctx, "p2"); err != nil || got.Author != "Guest" || got.AuthorId != "" {
+		t.Errorf("expected the other post to be untouched, got %+v, %v", got, err)
+	}
+	if got := listAll(t, s, models.ListBlogPostsRequest{Filter: `author_id = "a1"`}); fmt.Sprint(got) != "[p1]" {
+		t.Errorf("expected to find p1 by author ID, got %v", got)
+	}
+
+	// Authors cannot be deleted while they have posts, even in the trash
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if err := s.DeleteAuthor(ctx, "a1"); !errors.Is(err, models.ErrAuthorHasPosts) {
+		t.Errorf("DeleteAuthor: expected ErrAuthorHasPosts, got: %v", err)
+	}
+	if err := s.PurgePost(ctx, "p1"); err != nil {
+		t.Fatalf("PurgePost failed: %v", err)
+	}
+	if err := s.DeleteAuthor(ctx, "a1"); err != nil {
+		t.Errorf("DeleteAuthor failed: %v", err)
+	}
+}
+
+func testListAuthors(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	for i, name := range []string{"carol", "Bob", "alice", "bob", "Dave"} {
+		mustCreateAuthor(t, s, &models.Author{AuthorId: fmt.Sprintf("a%d", i), Name: name, ProfileName: fmt.Sprintf("p%d", i)})
+	}
+
+	var names []string
+	req := &models.ListAuthorsRequest{PageSize: 2}
+	for pages := 0; ; pages++ {
+		page, next, err := s.ListAuthors(ctx, req)
+		if err != nil {
+			t.Fatalf("ListAuthors failed: %v", err)
+		}
+		if len(page) > 2 {
+			t.Fatalf("page of %d authors exceeds the page size", len(page))
+		}
+		for _, author := range page {
+			names = append(names, author.Name)
+		}
+		if next == "" {
+			if pages != 2 {
+				t.Errorf("expected 3 pages, got %d", pages+1)
+			}
+			break
+		}
+		req.PageToken = next
+	}
+	if fmt.Sprint(names) != "[alice Bob bob carol Dave]" {
+		t.Errorf("expected authors sorted by name, got %v", names)
+	}
+
+	if _, _, err := s.ListAuthors(ctx, &models.ListAuthorsRequest{PageToken: "garbage"}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken, got: %v", err)
+	}
+	if _, _, err := s.ListAuthors(ctx, &models.ListAuthorsRequest{PageSize: -1}); !errors.Is(err, models.ErrInvalidPageSize) {
+		t.Errorf("expected ErrInvalidPageSize, got: %v", err)
+	}
+}
+
+// tagsByName lists every registered tag, keyed by name.
+func tagsByName(t *testing.T, s storage.TagStorage) map[string]*models.Tag {
+	t.Helper()
+	tags := make(map[string]*models.Tag)
+	req := &models.ListTagsRequest{}
+	for {
+		page, next, err := s.ListTags(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListTags failed: %v", err)
+		}
+		for _, tag := range page {
+			tags[tag.Name] = tag
+		}
+		if next == "" {
+			return tags
+		}
+		req.PageToken = next
+	}
+}
+
+func testTags(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Tags: []string{"go", "Go!"}})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", Tags: []string{"go"}})
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p2", Tags: []string{"go", "gRPC services"}}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+
+	// Tags are registered as posts use them, with unique slugs
+	tags := tagsByName(t, s)
+	if len(tags) != 3 {
+		t.Fatalf("expected 3 tags, got %v", tags)
+	}
+	slugs := fmt.Sprint(tags["Go!"].Slug, " ", tags["go"].Slug, " ", tags["gRPC services"].Slug)
+	if slugs != "go-2 go grpc-services" && slugs != "go go-2 grpc-services" {
+		t.Errorf("unexpected slugs: %s", slugs)
+	}
+	if tags["go"].UsageCount != 2 || tags["Go!"].UsageCount != 1 || tags["go"].TagId == "" || tags["go"].CreatedAt.IsZero() {
+		t.Errorf("unexpected tag: %+v", tags["go"])
+	}
+
+	got, err := s.GetTag(ctx, tags["go"].TagId)
+	if err != nil || got.Name != "go" || got.UsageCount != 2 {
+		t.Errorf("GetTag: unexpected tag %+v, %v", got, err)
+	}
+	if _, err := s.GetTag(ctx, "missing"); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("GetTag: expected ErrTagNotFound, got: %v", err)
+	}
+
+	// Posts in the trash do not count, and unused tags stay registered
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	tags = tagsByName(t, s)
+	if tags["go"].UsageCount != 1 || tags["Go!"] == nil || tags["Go!"].UsageCount != 0 {
+		t.Errorf("unexpected usage counts after trashing: go=%+v Go!=%+v", tags["go"], tags["Go!"])
+	}
+
+	page, next, err := s.ListTags(ctx, &models.ListTagsRequest{PageSize: 2})
+	if err != nil || len(page) != 2 || next == "" || page[0].Name != "go" || page[1].Name != "Go!" {
+		t.Errorf("expected the first page sorted by name, got %v, %q, %v", page, next, err)
+	}
+	if _, _, err := s.ListTags(ctx, &models.ListTagsRequest{PageToken: "garbage"}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken, got: %v", err)
+	}
+}
+
+func testRenameTag(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Tags: []string{"golang", "grpc"}})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", Tags: []string{"rust"}})
+	mustCreate(t, s, &models.BlogPost{PostId: "p3", Tags: []string{"golang"}})
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p3"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	tags := tagsByName(t, s)
+
+	description := "The Go programming language"
+	renamed, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: tags["golang"].TagId, Name: "Go", Description: &description})
+	if err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+	if renamed.Name != "Go" || renamed.Slug != "go" || renamed.Description != description || renamed.UsageCount != 1 || renamed.TagId != tags["golang"].TagId {
+		t.Errorf("unexpected renamed tag: %+v", renamed)
+	}
+
+	// Every post carrying the tag is rewritten, including those in the trash
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil || fmt.Sprint(got.Tags) != "[Go grpc]" || got.Version != 2 {
+		t.Errorf("expected p1 to carry the new name at version 2, got %+v, %v", got, err)
+	}
+	assertRevisionPerVersion(t, s, got)
+	if rev, err := s.GetRevision(ctx, "p1", 2); err != nil || fmt.Sprint(rev.Tags) != "[Go grpc]" || rev.Editor != models.SystemEditor {
+		t.Errorf("expected the rename to be recorded by the system, got %+v, %v", rev, err)
+	}
+	if got, err := s.GetPost(ctx, "p2"); err != nil || got.Version != 1 {
+		t.Errorf("expected p2 to be untouched, got %+v, %v", got, err)
+	}
+	if _, err := s.RestorePost(ctx, "p3"); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if got, err := s.GetPost(ctx, "p3"); err != nil || fmt.Sprint(got.Tags) != "[Go]" {
+		t.Errorf("expected the trashed post to be rewritten, got %+v, %v", got, err)
+	}
+	if got := listAll(t, s, models.ListBlogPostsRequest{Filter: `tags:"golang"`}); len(got) != 0 {
+		t.Errorf("expected no post with the old name, got %v", got)
+	}
+
+	// Only the description changes when no name is given
+	description = "Go"
+	renamed, err = s.RenameTag(ctx, &models.RenameTagRequest{TagId: renamed.TagId, Description: &description})
+	if err != nil || renamed.Name != "Go" || renamed.Description != "Go" {
+		t.Errorf("unexpected tag after changing the description: %+v, %v", renamed, err)
+	}
+	if got, err := s.GetPost(ctx, "p1"); err != nil || got.Version != 2 {
+		t.Errorf("expected a description change to leave posts alone, got %+v, %v", got, err)
+	}
+
+	if _, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: renamed.TagId, Name: "rust"}); !errors.Is(err, models.ErrDuplicateTag) {
+		t.Errorf("RenameTag: expected ErrDuplicateTag, got: %v", err)
+	}
+	if _, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: "missing", Name: "x"}); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("RenameTag: expected ErrTagNotFound, got: %v", err)
+	}
+}
+
+func testMergeTags(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Tags: []string{"golang", "grpc"}})
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", Tags: []string{"go", "golang"}})
+	mustCreate(t, s, &models.BlogPost{PostId: "p3", Tags: []string{"go"}})
+	tags := tagsByName(t, s)
+
+	merged, err := s.MergeTags(ctx, &models.MergeTagsRequest{SourceTagId: tags["golang"].TagId, TargetTagId: tags["go"].TagId})
+	if err != nil {
+		t.Fatalf("MergeTags failed: %v", err)
+	}
+	if merged.TagId != tags["go"].TagId || merged.UsageCount != 3 {
+		t.Errorf("unexpected merged tag: %+v", merged)
+	}
+	for id, want := range map[string]string{"p1": "[go grpc] 2", "p2": "[go] 2", "p3": "[go] 1"} {
+		got, err := s.GetPost(ctx, id)
+		if err != nil || fmt.Sprint(got.Tags, " ", got.Version) != want {
+			t.Errorf("%s: expected tags and version %s, got %+v, %v", id, want, got, err)
+			continue
+		}
+		assertRevisionPerVersion(t, s, got)
+	}
+	if rev, err := s.GetRevision(ctx, "p2", 2); err != nil || fmt.Sprint(rev.Tags) != "[go]" || rev.Editor != models.SystemEditor {
+		t.Errorf("expected the merge to be recorded by the system, got %+v, %v", rev, err)
+	}
+	if _, err := s.GetTag(ctx, tags["golang"].TagId); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("expected the source tag to be removed, got: %v", err)
+	}
+
+	if _, err := s.MergeTags(ctx, &models.MergeTagsRequest{SourceTagId: tags["go"].TagId, TargetTagId: tags["go"].TagId}); !errors.Is(err, models.ErrMergeSameTag) {
+		t.Errorf("MergeTags: expected ErrMergeSameTag, got: %v", err)
+	}
+	if _, err := s.MergeTags(ctx, &models.MergeTagsRequest{SourceTagId: tags["golang"].TagId, TargetTagId: tags["go"].TagId}); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("MergeTags: expected ErrTagNotFound, got: %v", err)
+	}
+	if _, err := s.MergeTags(ctx, &models.MergeTagsRequest{SourceTagId: tags["grpc"].TagId, TargetTagId: "missing"}); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("MergeTags: expected ErrTagNotFound, got: %v", err)
+	}
+	if got, err := s.GetPost(ctx, "p1"); err != nil || fmt.Sprint(got.Tags) != "[go grpc]" {
+		t.Errorf("expected a failed merge to leave posts alone, got %+v, %v", got, err)
+	}
+}
+
+func testListPostsByAuthorAndTag(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a1", Name: "Aman Pandae", ProfileName: "aman"})
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a2", Name: "Other", ProfileName: "other"})
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, post := range []*models.BlogPost{
+		{PostId: "p1", AuthorId: "a1", Tags: []string{"go"}},
+		{PostId: "p2", AuthorId: "a1", Tags: []string{"go", "grpc"}},
+		{PostId: "p3", AuthorId: "a2", Tags: []string{"go"}},
+		{PostId: "p4", AuthorId: "a1", Tags: []string{"rust"}},
+		{PostId: "p5", AuthorId: "a1", Tags: []string{"go"}, Status: models.StatusDraft},
+	} {
+		post.PublicationDate = base.Add(time.Duration(i) * time.Hour)
+		mustCreate(t, s, post)
+	}
+
+	byAuthor := func(authorId string, size int) []string {
+		t.Helper()
+		req := &models.ListPostsByAuthorRequest{AuthorId: authorId, PageSize: size}
+		var ids []string
+		for {
+			page, next, err := s.ListPostsByAuthor(ctx, req)
+			if err != nil {
+				t.Fatalf("ListPostsByAuthor failed: %v", err)
+			}
+			for _, post := range page {
+				ids = append(ids, post.PostId)
+			}
+			if next == "" {
+				return ids
+			}
+			req.PageToken = next
+		}
+	}
+	byTag := func(tag string, size int) []string {
+		t.Helper()
+		req := &models.ListPostsByTagRequest{Tag: tag, PageSize: size}
+		var ids []string
+		for {
+			page, next, err := s.ListPostsByTag(ctx, req)
+			if err != nil {
+				t.Fatalf("ListPostsByTag failed: %v", err)
+			}
+			for _, post := range page {
+				ids = append(ids, post.PostId)
+			}
+			if next == "" {
+				return ids
+			}
+			req.PageToken = next
+		}
+	}
+
+	// Only published posts are listed, newest first
+	if got := byAuthor("a1", 1); fmt.Sprint(got) != "[p4 p2 p1]" {
+		t.Errorf("expected [p4 p2 p1] for a1, got %v", got)
+	}
+	if got := byTag("go", 2); fmt.Sprint(got) != "[p3 p2 p1]" {
+		t.Errorf("expected [p3 p2 p1] for go, got %v", got)
+	}
+	if got := byAuthor("missing", 0); len(got) != 0 {
+		t.Errorf("expected no posts for an unknown author, got %v", got)
+	}
+
+	// The lists follow updates, trashing and deletion
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Tags: []string{"rust"}, UpdateMask: []string{"tags"}}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p3"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p4"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if got := byTag("go", 0); fmt.Sprint(got) != "[p2]" {
+		t.Errorf("expected [p2] for go after the changes, got %v", got)
+	}
+	if got := byTag("rust", 0); fmt.Sprint(got) != "[p1]" {
+		t.Errorf("expected [p1] for rust after the changes, got %v", got)
+	}
+	if got := byAuthor("a1", 0); fmt.Sprint(got) != "[p2 p1]" {
+		t.Errorf("expected [p2 p1] for a1 after the changes, got %v", got)
+	}
+	if _, err := s.RestorePost(ctx, "p3"); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if got := byAuthor("a2", 0); fmt.Sprint(got) != "[p3]" {
+		t.Errorf("expected [p3] for a2 after the restore, got %v", got)
+	}
+
+	// A token is bound to the author or tag it was issued for
+	_, next, err := s.ListPostsByAuthor(ctx, &models.ListPostsByAuthorRequest{AuthorId: "a1", PageSize: 1})
+	if err != nil || next == "" {
+		t.Fatalf("expected a next page token, got %q, %v", next, err)
+	}
+	if _, _, err := s.ListPostsByAuthor(ctx, &models.ListPostsByAuthorRequest{AuthorId: "a2", PageToken: next}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken for another author, got: %v", err)
+	}
+	if _, _, err := s.ListPostsByTag(ctx, &models.ListPostsByTagRequest{Tag: "a1", PageToken: next}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken for a tag, got: %v", err)
+	}
+	if _, _, err := s.ListPostsByTag(ctx, &models.ListPostsByTagRequest{Tag: "go", PageSize: -1}); !errors.Is(err, models.ErrInvalidPageSize) {
+		t.Errorf("expected ErrInvalidPageSize, got: %v", err)
+	}
+}
+
+// searchAll pages through every result of a search query.
+func searchAll(t *testing.T, s storage.BlogStorage, query string, size int) []string {
+	t.Helper()
+	req := &models.SearchPostsRequest{Query: query, PageSize: size}
+	var ids []string
+	for {
+		page, next, err := s.SearchPosts(context.Background(), req)
+		if err != nil {
+			t.Fatalf("SearchPosts(%q) failed: %v", query, err)
+		}
+		for _, result := range page {
+			ids = append(ids, result.Post.PostId)
+		}
+		if next == "" {
+			return ids
+		}
+		req.PageToken = next
+	}
+}
+
+func testSearchPosts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	for _, post := range []*models.BlogPost{
+		{PostId: "title", Title: "Streaming with gRPC", Content: "Servers push messages to clients."},
+		{PostId: "content", Title: "Notes", Content: "Server streaming in gRPC keeps a connection open."},
+		{PostId: "tags", Title: "Weekly links", Content: "Assorted reading.", Tags: []string{"grpc"}},
+		{PostId: "draft", Title: "gRPC draft", Content: "Not yet.", Status: models.StatusDraft},
+		{PostId: "rust", Title: "Rust", Content: "Ownership and borrowing."},
+	} {
+		mustCreate(t, s, post)
+	}
+
+	// Title matches rank above tag matches, and those above content matches
+	if got := searchAll(t, s, "grpc", 1); fmt.Sprint(got) != "[title tags content]" {
+		t.Errorf("expected [title tags content] for grpc, got %v", got)
+	}
+	if got := searchAll(t, s, `"server streaming"`, 0); fmt.Sprint(got) != "[content]" {
+		t.Errorf("expected [content] for a phrase, got %v", got)
+	}
+	if got := searchAll(t, s, "stream* grpc", 0); fmt.Sprint(got) != "[title content]" {
+		t.Errorf("expected [title content] for a prefix, got %v", got)
+	}
+	page, _, err := s.SearchPosts(ctx, &models.SearchPostsRequest{Query: "borrowing"})
+	if err != nil || len(page) != 1 || page[0].Post.Title != "Rust" || page[0].Score <= 0 {
+		t.Errorf("expected the rust post with a score, got %+v, %v", page, err)
+	}
+
+	// The index follows every write
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "rust", Content: "Lifetimes and gRPC."}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if _, err := s.SetPostStatus(ctx, &models.SetPostStatusRequest{PostId: "draft", Status: models.StatusPublished}); err != nil {
+		t.Fatalf("SetPostStatus failed: %v", err)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "title"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "content"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if got := searchAll(t, s, "grpc", 0); fmt.Sprint(got) != "[draft tags rust]" {
+		t.Errorf("expected [draft tags rust] after the writes, got %v", got)
+	}
+	if got := searchAll(t, s, "borrowing", 0); len(got) != 0 {
+		t.Errorf("expected the old content to be gone, got %v", got)
+	}
+	tags := tagsByName(t, s)
+	if _, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: tags["grpc"].TagId, Name: "protobuf"}); err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+	if got := searchAll(t, s, "protobuf", 0); fmt.Sprint(got) != "[tags]" {
+		t.Errorf("expected [tags] after renaming the tag, got %v", got)
+	}
+	if _, err := s.RestorePost(ctx, "title"); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if got := searchAll(t, s, "streaming", 0); fmt.Sprint(got) != "[title]" {
+		t.Errorf("expected [title] after the restore, got %v", got)
+	}
+
+	// Queries without words and tokens from other queries are rejected
+	if _, _, err := s.SearchPosts(ctx, &models.SearchPostsRequest{Query: ` "" * `}); !errors.Is(err, models.ErrEmptyQuery) {
+		t.Errorf("expected ErrEmptyQuery, got: %v", err)
+	}
+	_, next, err := s.SearchPosts(ctx, &models.SearchPostsRequest{Query: "grpc", PageSize: 1})
+	if err != nil || next == "" {
+		t.Fatalf("expected a next page token, got %q, %v", next, err)
+	}
+	if _, _, err := s.SearchPosts(ctx, &models.SearchPostsRequest{Query: "rust", PageToken: next}); !errors.Is(err, models.ErrInvalidPageToken) {
+		t.Errorf("expected ErrInvalidPageToken for another query, got: %v", err)
+	}
+	if _, _, err := s.SearchPosts(ctx, &models.SearchPostsRequest{Query: "GRPC", PageSize: 1, PageToken: next}); err != nil {
+		t.Errorf("expected a token to work for the same query in another case, got: %v", err)
+	}
+}
+
+func suggestNames(t *testing.T, s storage.TagStorage, req models.SuggestTagsRequest) []string {
+	t.Helper()
+	suggestions, err := s.SuggestTags(context.Background(), &req)
+	if err != nil {
+		t.Fatalf("SuggestTags(%+v) failed: %v", req, err)
+	}
+	names := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		names[i] = suggestion.Tag.Name
+	}
+	return names
+}
+
+func testSuggestTags(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	for _, post := range []*models.BlogPost{
+		{PostId: "streams", Title: "Streaming with gRPC", Content: "Server streaming keeps a connection open.", Tags: []string{"grpc", "go", "streaming"}},
+		{PostId: "interceptors", Title: "gRPC interceptors", Content: "Middleware for unary calls.", Tags: []string{"grpc", "go"}},
+		{PostId: "generics", Title: "Go generics", Content: "Type parameters.", Tags: []string{"go", "generics"}},
+		{PostId: "rust", Title: "Rust ownership", Content: "Borrowing rules.", Tags: []string{"rust"}},
+		{PostId: "draft", Title: "GraphQL", Content: "Schemas.", Tags: []string{"graphql"}, Status: models.StatusDraft},
+		{PostId: "trashed", Title: "gRPC gateway", Content: "REST in front of gRPC.", Tags: []string{"grpc-gateway", "go"}},
+	} {
+		mustCreate(t, s, post)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "trashed"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	// Completions are ranked by usage, then by name
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Prefix: "g"}); fmt.Sprint(got) != "[go grpc generics graphql grpc-gateway]" {
+		t.Errorf("expected [go grpc generics graphql grpc-gateway] for g, got %v", got)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Prefix: "gr", Limit: 2}); fmt.Sprint(got) != "[grpc graphql]" {
+		t.Errorf("expected [grpc graphql] for gr, got %v", got)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Prefix: "z"}); len(got) != 0 {
+		t.Errorf("expected no completions for z, got %v", got)
+	}
+	suggestions, err := s.SuggestTags(ctx, &models.SuggestTagsRequest{Limit: 1})
+	if err != nil || len(suggestions) != 1 || suggestions[0].Tag.Name != "go" || suggestions[0].Score != 3 {
+		t.Errorf("expected go scored by its usage, got %+v, %v", suggestions, err)
+	}
+
+	// A draft gets the tags of similar posts, and of posts sharing its tags
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Title: "Bidirectional streaming in gRPC"}); fmt.Sprint(got) != "[go grpc streaming]" {
+		t.Errorf("expected [go grpc streaming] for a draft about streaming, got %v", got)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Title: "Bidirectional streaming in gRPC", Prefix: "s"}); fmt.Sprint(got) != "[streaming]" {
+		t.Errorf("expected [streaming] for the draft and a prefix, got %v", got)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Tags: []string{"grpc"}}); fmt.Sprint(got) != "[go streaming]" {
+		t.Errorf("expected [go streaming] for a draft tagged grpc, got %v", got)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Content: "Nothing shared."}); len(got) != 0 {
+		t.Errorf("expected no suggestions for an unrelated draft, got %v", got)
+	}
+
+	// Completions follow renames and merges
+	tags := tagsByName(t, s)
+	if _, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: tags["generics"].TagId, Name: "type-parameters"}); err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+	if _, err := s.MergeTags(ctx, &models.MergeTagsRequest{SourceTagId: tags["graphql"].TagId, TargetTagId: tags["rust"].TagId}); err != nil {
+		t.Fatalf("MergeTags failed: %v", err)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Prefix: "g"}); fmt.Sprint(got) != "[go grpc grpc-gateway]" {
+		t.Errorf("expected [go grpc grpc-gateway] after the rename and merge, got %v", got)
+	}
+	if got := suggestNames(t, s, models.SuggestTagsRequest{Prefix: "type"}); fmt.Sprint(got) != "[type-parameters]" {
+		t.Errorf("expected [type-parameters] after the rename, got %v", got)
+	}
+
+	if _, err := s.SuggestTags(ctx, &models.SuggestTagsRequest{Prefix: "g", Limit: -1}); !errors.Is(err, models.ErrInvalidLimit) {
+		t.Errorf("expected ErrInvalidLimit, got: %v", err)
+	}
+}
+
+func relatedIds(t *testing.T, s storage.BlogStorage, postId string, limit int) []string {
+	t.Helper()
+	related, err := s.GetRelatedPosts(context.Background(), &models.GetRelatedPostsRequest{PostId: postId, Limit: limit})
+	if err != nil {
+		t.Fatalf("GetRelatedPosts(%s) failed: %v", postId, err)
+	}
+	ids := make([]string, len(related))
+	for i, r := range related {
+		if r.Score <= 0 || r.Score > 1 {
+			t.Errorf("expected a score between 0 and 1, got %v for %s", r.Score, r.Post.PostId)
+		}
+		ids[i] = r.Post.PostId
+	}
+	return ids
+}
+
+func testRelatedPosts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	for _, post := range []*models.BlogPost{
+		{PostId: "source", Title: "Streaming with gRPC", Content: "Server streaming keeps a connection open for messages.", Tags: []string{"grpc", "go"}},
+		{PostId: "twin", Title: "gRPC streaming in practice", Content: "Server streaming and client streaming messages.", Tags: []string{"go", "grpc"}},
+		{PostId: "tags", Title: "Weekly links", Content: "Assorted reading.", Tags: []string{"grpc", "go", "links"}},
+		{PostId: "text", Title: "Streaming server", Content: "Streaming messages over a connection."},
+		{PostId: "rust", Title: "Rust ownership", Content: "Borrowing rules.", Tags: []string{"rust"}},
+		{PostId: "draft", Title: "Streaming with gRPC, again", Content: "Server streaming.", Tags: []string{"grpc", "go"}, Status: models.StatusDraft},
+		{PostId: "trashed", Title: "Streaming with gRPC, once more", Content: "Server streaming.", Tags: []string{"grpc", "go"}},
+	} {
+		mustCreate(t, s, post)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "trashed"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	// The post with the same tags and similar text comes first; posts that
+	// share only tags or only text follow, and unrelated, unpublished and
+	// trashed posts are left out
+	got := relatedIds(t, s, "source", 0)
+	if len(got) != 3 || got[0] != "twin" {
+		t.Fatalf("expected twin first of three related posts, got %v", got)
+	}
+	if rest := []string{got[1], got[2]}; !(rest[0] == "tags" && rest[1] == "text" || rest[0] == "text" && rest[1] == "tags") {
+		t.Errorf("expected tags and text after twin, got %v", got)
+	}
+	if got := relatedIds(t, s, "source", 1); fmt.Sprint(got) != "[twin]" {
+		t.Errorf("expected [twin] with a limit of 1, got %v", got)
+	}
+
+	// Unpublished posts have related posts too
+	if got := relatedIds(t, s, "draft", 0); len(got) == 0 || hasId(got, "draft") || hasId(got, "trashed") {
+		t.Errorf("expected published posts related to the draft, got %v", got)
+	}
+
+	// The scores follow the writes
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "twin", Title: "Ownership", Content: "Borrowing.", Tags: []string{"rust"}}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if got := relatedIds(t, s, "source", 0); hasId(got, "twin") {
+		t.Errorf("expected twin to no longer be related, got %v", got)
+	}
+
+	if _, err := s.GetRelatedPosts(ctx, &models.GetRelatedPostsRequest{PostId: "trashed"}); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound for a trashed post, got: %v", err)
+	}
+	if _, err := s.GetRelatedPosts(ctx, &models.GetRelatedPostsRequest{PostId: "source", Limit: -1}); !errors.Is(err, models.ErrInvalidLimit) {
+		t.Errorf("expected ErrInvalidLimit, got: %v", err)
+	}
+}
+
+func hasId(ids []string, id string) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// errEnoughEvents stops a watch once it has seen the events a test expects.
+var errEnoughEvents = errors.New("enough events")
+
+// watchEvents watches the posts until n events match req, and returns them.
+func watchEvents(s storage.BlogStorage, req models.WatchPostsRequest, n int) ([]*models.PostEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var events []*models.PostEvent
+	err := s.WatchPosts(ctx, &req, func(event *models.PostEvent) error {
+		events = append(events, event)
+		if len(events) == n {
+			return errEnoughEvents
+		}
+		return nil
+	})
+	if !errors.Is(err, errEnoughEvents) {
+		return events, fmt.Errorf("watch stopped after %d of %d events: %w", len(events), n, err)
+	}
+	return events, nil
+}
+
+// eventString lists the type and post ID of every event.
+func eventString(events []*models.PostEvent) string {
+	s := ""
+	for i, event := range events {
+		if i > 0 {
+			s += " "
+		}
+		s += string(event.Type) + ":" + event.PostId
+	}
+	return s
+}
+
+// latestSequence returns the sequence number of a change made to postId once
+// a watcher from now is known to be running, so that the events after it are
+// exactly those of the writes that follow.
+func latestSequence(t *testing.T, s storage.BlogStorage, postId string) uint64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := make(chan *models.PostEvent)
+	go s.WatchPosts(ctx, &models.WatchPostsRequest{}, func(event *models.PostEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	update := func(title string) {
+		if _, err := s.UpdatePost(context.Background(), &models.UpdateBlogPostRequest{PostId: postId, Title: title}); err != nil {
+			t.Fatalf("UpdatePost failed: %v", err)
+		}
+	}
+
+	// The watcher may start after the first updates, so keep updating
+	// until it sees one, then mark the point to resume from
+	for live := false; !live; {
+		update("probe")
+		select {
+		case <-events:
+			live = true
+		case <-time.After(10 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatalf("the watcher saw no event: %v", ctx.Err())
+		}
+	}
+	update("marker")
+	for {
+		select {
+		case event := <-events:
+			if event.Post.Title == "marker" {
+				return event.Sequence
+			}
+		case <-ctx.Done():
+			t.Fatalf("the watcher missed the marker: %v", ctx.Err())
+		}
+	}
+}
+
+func testWatchPosts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreateAuthor(t, s, &models.Author{AuthorId: "a1", Name: "Aman Pandae", ProfileName: "aman"})
+	mustCreate(t, s, &models.BlogPost{PostId: "p0"})
+	start := latestSequence(t, s, "p0")
+
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "First", Tags: []string{"go"}})
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "First, edited"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	if _, err := s.RestorePost(ctx, "p1"); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", AuthorId: "a1", Tags: []string{"rust"}})
+	if _, err := s.UpdateAuthor(ctx, &models.UpdateAuthorRequest{AuthorId: "a1", Name: "Aman P."}); err != nil {
+		t.Fatalf("UpdateAuthor failed: %v", err)
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p2", Tags: []string{"go"}}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p2"}); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	mustCreate(t, s, &models.BlogPost{PostId: "sched", PublicationDate: time.Now().Add(time.Hour)})
+	if n, err := s.PublishScheduled(ctx, time.Now().Add(2*time.Hour)); err != nil || n != 1 {
+		t.Fatalf("PublishScheduled: expected 1 published post, got %d, %v", n, err)
+	}
+	if _, err := s.RenameTag(ctx, &models.RenameTagRequest{TagId: tagsByName(t, s)["go"].TagId, Name: "golang"}); err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p0"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+	// Posts in the trash are already deleted as far as watchers know
+	if err := s.PurgePost(ctx, "p0"); err != nil {
+		t.Fatalf("PurgePost failed: %v", err)
+	}
+
+	// A watcher resuming from a sequence number reads every change after it,
+	// in order, and then the changes as they happen
+	want := "created:p1 updated:p1 deleted:p1 created:p1 created:p2 updated:p2 updated:p2 deleted:p2 created:sched updated:sched updated:p1 deleted:p0 created:p3"
+	type result struct {
+		events []*models.PostEvent
+		err    error
+	}
+	done := make(chan result)
+	go func() {
+		events, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: start}, 13)
+		done <- result{events, err}
+	}()
+	mustCreate(t, s, &models.BlogPost{PostId: "p3"})
+	all := <-done
+	if all.err != nil {
+		t.Fatalf("WatchPosts failed: %v", all.err)
+	}
+	events := all.events
+	if got := eventString(events); got != want {
+		t.Fatalf("unexpected events:\n got %s\nwant %s", got, want)
+	}
+	for i, event := range events {
+		if event.Sequence != start+uint64(i)+1 || event.Time.IsZero() {
+			t.Errorf("expected event %d at sequence %d with a time, got %d at %v", i, start+uint64(i)+1, event.Sequence, event.Time)
+		}
+	}
+	if updated := events[1]; updated.Post.Title != "First, edited" || updated.Previous == nil || updated.Previous.Title != "First" {
+		t.Errorf("expected the update to carry the post before and after, got %+v", updated)
+	}
+	if created := events[3]; created.Previous != nil || created.Post.Title != "First, edited" {
+		t.Errorf("expected the restore to create the post, got %+v", created)
+	}
+	if deleted := events[7]; deleted.Post == nil || deleted.Post.PostId != "p2" {
+		t.Errorf("expected the delete to carry the deleted post, got %+v", deleted)
+	}
+
+	// Resuming from any event skips those up to it
+	if got, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: events[2].Sequence}, 1); err != nil || eventString(got) != "created:p1" {
+		t.Errorf("expected to resume with the restore, got %s, %v", eventString(got), err)
+	}
+
+	// Filters match the post before or after the change, so watchers learn
+	// about posts that stop matching too
+	if got, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: start, Tag: "go"}, 7); err != nil ||
+		eventString(got) != "created:p1 updated:p1 deleted:p1 created:p1 updated:p2 deleted:p2 updated:p1" {
+		t.Errorf("unexpected events for tag go: %s, %v", eventString(got), err)
+	}
+	if got, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: start, AuthorId: "a1"}, 4); err != nil ||
+		eventString(got) != "created:p2 updated:p2 updated:p2 deleted:p2" {
+		t.Errorf("unexpected events for author a1: %s, %v", eventString(got), err)
+	}
+	if got, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: start, AuthorId: "a1", Tag: "go"}, 2); err != nil ||
+		eventString(got) != "updated:p2 deleted:p2" {
+		t.Errorf("unexpected events for author a1 and tag go: %s, %v", eventString(got), err)
+	}
+
+	// Sequence numbers the storage cannot resume from are rejected
+	last := events[len(events)-1].Sequence
+	for _, since := range []uint64{1, last + 1} {
+		if _, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: since}, 1); !errors.Is(err, models.ErrSequenceExpired) {
+			t.Errorf("WatchPosts(since %d): expected ErrSequenceExpired, got: %v", since, err)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err := s.WatchPosts(canceled, &models.WatchPostsRequest{SinceSequence: start}, func(*models.PostEvent) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WatchPosts: expected context.Canceled, got: %v", err)
+	}
+}
+
+// exportIds exports the posts matching req and returns their IDs.
+func exportIds(t *testing.T, s storage.BlogStorage, req models.ExportPostsRequest) []string {
+	t.Helper()
+	var ids []string
+	err := s.ExportPosts(context.Background(), &req, func(post *models.BlogPost, sequence uint64) error {
+		ids = append(ids, post.PostId)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportPosts(%+v) failed: %v", req, err)
+	}
+	return ids
+}
+
+func testExportPosts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	mustCreate(t, s, &models.BlogPost{PostId: "p2", Status: models.StatusDraft})
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "First", Tags: []string{"go"}})
+	mustCreate(t, s, &models.BlogPost{PostId: "p3"})
+	if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p3"}); err != nil {
+		t.Fatalf("TrashPost failed: %v", err)
+	}
+
+	// Posts of every status are exported in ID order, and those in the trash
+	// when asked for
+	if got := exportIds(t, s, models.ExportPostsRequest{}); fmt.Sprint(got) != "[p1 p2]" {
+		t.Errorf("expected [p1 p2], got %v", got)
+	}
+	if got := exportIds(t, s, models.ExportPostsRequest{ShowDeleted: true}); fmt.Sprint(got) != "[p1 p2 p3]" {
+		t.Errorf("expected [p1 p2 p3] with the trash, got %v", got)
+	}
+	if got := exportIds(t, s, models.ExportPostsRequest{Filter: `status = "draft"`}); fmt.Sprint(got) != "[p2]" {
+		t.Errorf("expected [p2] for drafts, got %v", got)
+	}
+	noop := func(*models.BlogPost, uint64) error { return nil }
+	if err := s.ExportPosts(ctx, &models.ExportPostsRequest{Filter: "title ="}, noop); err == nil {
+		t.Errorf("expected an invalid filter to fail")
+	}
+
+	// Writes made during the export neither wait for it nor show up in it,
+	// and watching from the export's sequence number picks them up
+	var (
+		exported []*models.BlogPost
+		sequence uint64
+	)
+	err := s.ExportPosts(ctx, &models.ExportPostsRequest{}, func(post *models.BlogPost, seq uint64) error {
+		if len(exported) == 0 {
+			mustCreate(t, s, &models.BlogPost{PostId: "p0"})
+			if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p2", Title: "Changed"}); err != nil {
+				t.Fatalf("UpdatePost failed: %v", err)
+			}
+			if err := s.TrashPost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); err != nil {
+				t.Fatalf("TrashPost failed: %v", err)
+			}
+		}
+		exported = append(exported, post)
+		sequence = seq
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportPosts failed: %v", err)
+	}
+	if len(exported) != 2 || exported[0].PostId != "p1" || exported[0].Trashed() || exported[1].Title != "" {
+		t.Errorf("expected p1 and p2 as they were before the writes, got %+v", exported)
+	}
+	if got, err := watchEvents(s, models.WatchPostsRequest{SinceSequence: sequence}, 3); err != nil ||
+		eventString(got) != "created:p0 updated:p2 deleted:p1" {
+		t.Errorf("expected the writes made during the export, got %s, %v", eventString(got), err)
+	}
+
+	// fn stops the export
+	errStop := errors.New("stop")
+	calls := 0
+	err = s.ExportPosts(ctx, &models.ExportPostsRequest{}, func(*models.BlogPost, uint64) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("expected the export to stop after the first post, got %d calls, %v", calls, err)
+	}
+
+	// Large exports come out whole and in order
+	var batch []*models.BlogPost
+	for i := 0; i < 1200; i++ {
+		batch = append(batch, &models.BlogPost{PostId: fmt.Sprintf("bulk-%04d", i)})
+	}
+	if err := s.CreatePosts(ctx, batch); err != nil {
+		t.Fatalf("CreatePosts failed: %v", err)
+	}
+	got := exportIds(t, s, models.ExportPostsRequest{Filter: `post_id : "bulk-"`})
+	if len(got) != len(batch) || got[0] != "bulk-0000" || got[len(got)-1] != "bulk-1199" {
+		t.Errorf("expected %d bulk posts in order, got %d", len(batch), len(got))
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := s.ExportPosts(canceled, &models.ExportPostsRequest{}, noop); !errors.Is(err, context.Canceled) {
+		t.Errorf("ExportPosts: expected context.Canceled, got: %v", err)
+	}
+}
+
+func testConcurrentConditionalUpdates(t *testing.T, s storage.Storage) {
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "start"})
+
+	// Every writer read version 1, so exactly one of them may win
+	const writers = 8
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		wins int
+	)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			req := &models.UpdateBlogPostRequest{PostId: "p1", Title: fmt.Sprintf("title-%d", w), ExpectedVersion: 1}
+			_, err := s.UpdatePost(context.Background(), req)
+			switch {
+			case err == nil:
+				mu.Lock()
+				wins++
+				mu.Unlock()
+			case !errors.Is(err, models.ErrVersionMismatch):
+				t.Errorf("UpdatePost failed: %v", err)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if wins != 1 {
+		t.Errorf("expected exactly one successful update, got %d", wins)
+	}
+	got, err := s.GetPost(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.Version != 2 {
+		t.Errorf("expected version 2, got %d", got.Version)
+	}
+}
+
+func testContextCanceled(t *testing.T, s storage.Storage) {
+	mustCreate(t, s, &models.BlogPost{PostId: "p1", Title: "Title"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := s.CreatePost(ctx, &models.BlogPost{PostId: "p2"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("CreatePost: expected context.Canceled, got: %v", err)
+	}
+	if _, err := s.GetPost(ctx, "p1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetPost: expected context.Canceled, got: %v", err)
+	}
+	if _, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Title: "changed"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("UpdatePost: expected context.Canceled, got: %v", err)
+	}
+	if err := s.DeletePost(ctx, &models.DeleteBlogPostRequest{PostId: "p1"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("DeletePost: expected context.Canceled, got: %v", err)
+	}
+	if _, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListPosts: expected context.Canceled, got: %v", err)
+	}
+
+	// Nothing may have changed
+	if _, err := s.GetPost(context.Background(), "p2"); !errors.Is(err, models.ErrPostNotFound) {
+		t.Errorf("canceled create must not store the post, got: %v", err)
+	}
+	got, err := s.GetPost(context.Background(), "p1")
+	if err != nil || got.Title != "Title" {
+		t.Errorf("canceled update or delete must not change the post: %+v, %v", got, err)
+	}
+}
+
+func testNoAliasing(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	created := &models.BlogPost{PostId: "p1", Title: "Title", Tags: []string{"go", "grpc"}}
+	mustCreate(t, s, created)
+
+	// Mutating the post passed to CreatePost must not reach storage
+	created.Title = "changed"
+	created.Tags[0] = "changed"
+
+	got, err := s.GetPost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	// Neither may mutating a post returned by GetPost
+	got.Title = "changed"
+	got.Tags[0] = "changed"
+
+	tags := []string{"rust"}
+	updated, err := s.UpdatePost(ctx, &models.UpdateBlogPostRequest{PostId: "p1", Content: "Content", Tags: tags})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	// Nor the tags of an update request or the post UpdatePost returned
+	tags[0] = "changed"
+	updated.Title = "changed"
+
+	page, _, err := s.ListPosts(ctx, &models.ListBlogPostsRequest{})
+	if err != nil || len(page) != 1 {
+		t.Fatalf("ListPosts failed: %v", err)
+	}
+	page[0].Tags[0] = "changed"
+
+	got, err = s.GetPost(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if got.Title != "Title" || fmt.Sprint(got.Tags) != "[rust]" {
+		t.Errorf("storage shares memory with its callers: %+v", got)
+	}
+}
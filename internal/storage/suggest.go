@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/search"
+)
+
+const (
+	// DefaultSuggestLimit is used when a suggest request does not set a limit.
+	DefaultSuggestLimit = 10
+
+	// MaxSuggestLimit caps the number of tags suggested at once.
+	MaxSuggestLimit = 100
+
+	// similarPosts is the number of posts similar to a draft whose tags are
+	// suggested for it.
+	similarPosts = 20
+)
+
+// suggestLimit validates and normalizes the requested number of suggestions.
+func suggestLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, models.ErrInvalidLimit
+	case limit == 0:
+		return DefaultSuggestLimit, nil
+	case limit > MaxSuggestLimit:
+		return MaxSuggestLimit, nil
+	}
+	return limit, nil
+}
+
+// draftDocument is the searchable text of the draft post in a suggest
+// request.
+func draftDocument(req *models.SuggestTagsRequest) search.Document {
+	return search.Document{Title: req.Title, Content: req.Content, Tags: req.Tags}
+}
+
+// draftTagScores scores the tags that fit a draft post. Every tag on a post
+// similar to the draft scores the similarity of that post relative to the
+// closest one, so the tags of the closest post score 1 each. Every tag on a
+// post that shares a tag with the draft scores one over the number of posts
+// carrying the shared tag, so a tag that always goes with it scores 1.
+//
+// similarTags holds the tags of the similar posts, and tagged the tags of the
+// posts carrying each tag of the draft, by post ID. Tags already on the draft
+// are left out.
+func draftTagScores(draft []string, similar []search.Hit, similarTags map[string][]string, tagged map[string]map[string][]string) map[string]float64 {
+	scores := make(map[string]float64)
+	if len(similar) > 0 && similar[0].Score > 0 {
+		for _, hit := range similar {
+			for _, tag := range similarTags[hit.Id] {
+				scores[tag] += hit.Score / similar[0].Score
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	for _, shared := range draft {
+		if seen[shared] {
+			continue
+		}
+		seen[shared] = true
+		posts := tagged[shared]
+		for _, tags := range posts {
+			for _, tag := range tags {
+				scores[tag] += 1 / float64(len(posts))
+			}
+		}
+	}
+	for _, tag := range draft {
+		delete(scores, tag)
+	}
+	return scores
+}
+
+// rankSuggestions sorts the suggestions best first, then by usage and name,
+// and keeps the first limit of them.
+func rankSuggestions(suggestions []*models.TagSuggestion, limit int) []*models.TagSuggestion {
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Tag.UsageCount != b.Tag.UsageCount {
+			return a.Tag.UsageCount > b.Tag.UsageCount
+		}
+		return a.Tag.Name < b.Tag.Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// nameIndex is the sorted list of the names in the tag registry, so that the
+// names starting with a prefix are found by binary search.
+type nameIndex []string
+
+func (idx *nameIndex) add(name string) {
+	i := sort.SearchStrings(*idx, name)
+	if i < len(*idx) && (*idx)[i] == name {
+		return
+	}
+	*idx = append(*idx, "")
+	copy((*idx)[i+1:], (*idx)[i:])
+	(*idx)[i] = name
+}
+
+func (idx *nameIndex) remove(name string) {
+	i := sort.SearchStrings(*idx, name)
+	if i < len(*idx) && (*idx)[i] == name {
+		*idx = append((*idx)[:i], (*idx)[i+1:]...)
+	}
+}
+
+// withPrefix returns the names that start with prefix, in order.
+func (idx nameIndex) withPrefix(prefix string) []string {
+	start := sort.SearchStrings(idx, prefix)
+	end := start
+	for end < len(idx) && strings.HasPrefix(idx[end], prefix) {
+		end++
+	}
+	return idx[start:end]
+}
@@ -0,0 +1,350 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/pandae7/go-blogger/internal/models"
+	"github.com/pandae7/go-blogger/internal/search"
+)
+
+// TagStorage defines the storage operations for the tag registry. Posts carry
+// their tags by name, and every backend registers a tag the first time a post
+// uses it. Tags stay registered when their last post goes away.
+type TagStorage interface {
+	// ListTags returns one page of tags sorted by name, with the tag ID as
+	// tiebreaker, and the token for the next page.
+	ListTags(ctx context.Context, req *models.ListTagsRequest) ([]*models.Tag, string, error)
+
+	// GetTag retrieves a tag by ID.
+	GetTag(ctx context.Context, tagId string) (*models.Tag, error)
+
+	// RenameTag renames a tag and rewrites every post that carries it,
+	// including those in the trash, in one atomic step. Each rewritten post
+	// has its version incremented and a revision by models.SystemEditor
+	// recorded. It fails with models.ErrDuplicateTag if another tag already
+	// has the new name.
+	RenameTag(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error)
+
+	// MergeTags replaces the source tag with the target tag on every post and
+	// removes the source tag, in one atomic step, and returns the target tag.
+	// Posts are rewritten as by RenameTag, each with a revision by
+	// models.SystemEditor.
+	MergeTags(ctx context.Context, req *models.MergeTagsRequest) (*models.Tag, error)
+
+	// SuggestTags returns up to req.Limit registered tags starting with
+	// req.Prefix, best first. Without a draft they are ranked by usage and
+	// scored with their usage count. For a draft they are the tags of the
+	// published posts most similar to it and of the posts sharing a tag with
+	// it, scored by how similar those posts are and how often the tags go
+	// with the draft's own tags, which are left out.
+	SuggestTags(ctx context.Context, req *models.SuggestTagsRequest) ([]*models.TagSuggestion, error)
+}
+
+func (s *BlogStorageImpl) ListTags(ctx context.Context, req *models.ListTagsRequest) ([]*models.Tag, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	q, err := parseNameListRequest(req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	tags := make([]*models.Tag, 0, len(s.tags))
+	for _, tag := range s.tags {
+		tags = append(tags, tag)
+	}
+	page, next := paginateByName(q, tags, tagSortKey)
+
+	for i, tag := range page {
+		page[i] = s.withUsage(tag)
+	}
+	return page, next, nil
+}
+
+func (s *BlogStorageImpl) GetTag(ctx context.Context, tagId string) (*models.Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tag, exists := s.tags[tagId]
+	if !exists {
+		return nil, models.ErrTagNotFound
+	}
+	return s.withUsage(tag), nil
+}
+
+func (s *BlogStorageImpl) RenameTag(ctx context.Context, req *models.RenameTagRequest) (*models.Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingTag, exists := s.tags[req.TagId]
+	if !exists {
+		return nil, models.ErrTagNotFound
+	}
+
+	now := time.Now()
+	renamedTag := existingTag.Clone()
+	renamedTag.UpdatedAt = now
+	if req.Description != nil {
+		renamedTag.Description = *req.Description
+	}
+	var changes []change
+	if req.Name != "" && req.Name != existingTag.Name {
+		if _, taken := s.tagIds[req.Name]; taken {
+			return nil, models.ErrDuplicateTag
+		}
+		renamedTag.Name = req.Name
+		renamedTag.Slug, _ = uniqueSlug(req.Name, func(slug string) (bool, error) {
+			return s.slugTaken(slug, renamedTag.TagId), nil
+		})
+		changes = s.retagPosts(existingTag.Name, renamedTag.Name, now)
+	}
+
+	changes = append(changes, change{Op: opPutTag, TagId: renamedTag.TagId, Tag: renamedTag})
+	if err := s.apply(changes...); err != nil {
+		return nil, err
+	}
+	return s.withUsage(renamedTag), nil
+}
+
+func (s *BlogStorageImpl) MergeTags(ctx context.Context, req *models.MergeTagsRequest) (*models.Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.SourceTagId == req.TargetTagId {
+		return nil, models.ErrMergeSameTag
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sourceTag, exists := s.tags[req.SourceTagId]
+	if !exists {
+		return nil, models.ErrTagNotFound
+	}
+	targetTag, exists := s.tags[req.TargetTagId]
+	if !exists {
+		return nil, models.ErrTagNotFound
+	}
+
+	changes := s.retagPosts(sourceTag.Name, targetTag.Name, time.Now())
+	changes = append(changes, change{Op: opDeleteTag, TagId: sourceTag.TagId})
+	if err := s.apply(changes...); err != nil {
+		return nil, err
+	}
+	return s.withUsage(targetTag), nil
+}
+
+func (s *BlogStorageImpl) SuggestTags(ctx context.Context, req *models.SuggestTagsRequest) ([]*models.TagSuggestion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	limit, err := suggestLimit(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var suggestions []*models.TagSuggestion
+	if !req.Draft() {
+		for _, name := range s.tagNames.withPrefix(req.Prefix) {
+			tag := s.withUsage(s.tags[s.tagIds[name]])
+			suggestions = append(suggestions, &models.TagSuggestion{Tag: tag, Score: float64(tag.UsageCount)})
+		}
+		return rankSuggestions(suggestions, limit), nil
+	}
+
+	similar := s.fullText.Similar(draftDocument(req), search.DefaultBoosts, similarPosts)
+	similarTags := make(map[string][]string, len(similar))
+	for _, hit := range similar {
+		similarTags[hit.Id] = s.posts[hit.Id].Tags
+	}
+	tagged := make(map[string]map[string][]string, len(req.Tags))
+	for _, shared := range req.Tags {
+		posts := make(map[string][]string)
+		for id := range s.byTag[shared] {
+			if post := s.posts[id]; !post.Trashed() {
+				posts[id] = post.Tags
+			}
+		}
+		tagged[shared] = posts
+	}
+	for name, score := range draftTagScores(req.Tags, similar, similarTags, tagged) {
+		id, exists := s.tagIds[name]
+		if !exists || !strings.HasPrefix(name, req.Prefix) {
+			continue
+		}
+		suggestions = append(suggestions, &models.TagSuggestion{Tag: s.withUsage(s.tags[id]), Score: score})
+	}
+	return rankSuggestions(suggestions, limit), nil
+}
+
+// registerTags returns the changes that add the given tags to the registry,
+// skipping those already registered. The caller must hold the write lock.
+func (s *BlogStorageImpl) registerTags(tags []string, now time.Time) []change {
+	var changes []change
+	pending := make(map[string]bool)
+	for _, name := range tags {
+		if _, exists := s.tagIds[name]; exists || pending[name] {
+			continue
+		}
+		slug, _ := uniqueSlug(name, func(slug string) (bool, error) {
+			return pending[slug] || s.slugTaken(slug, ""), nil
+		})
+		pending[name], pending[slug] = true, true
+		tag := &models.Tag{
+			TagId:     uuid.New().String(),
+			Name:      name,
+			Slug:      slug,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		changes = append(changes, change{Op: opPutTag, TagId: tag.TagId, Tag: tag})
+	}
+	return changes
+}
+
+// retagPosts returns the changes that replace one tag with another on every
+// post that carries it, recording a revision of each post by the system. The
+// caller must hold the write lock.
+func (s *BlogStorageImpl) retagPosts(from, to string, now time.Time) []change {
+	var changes []change
+	for _, post := range s.indexedPosts(s.byTag, from) {
+		retaggedPost := post.Clone()
+		retaggedPost.Tags = replaceTag(retaggedPost.Tags, from, to)
+		retaggedPost.Version++
+		retaggedPost.UpdatedAt = now
+		changes = append(changes,
+			change{Op: opPutPost, PostId: post.PostId, Post: retaggedPost},
+			change{Op: opAddRevision, PostId: post.PostId, Revision: models.NewPostRevision(retaggedPost, models.SystemEditor)},
+		)
+	}
+	return changes
+}
+
+// slugTaken reports whether a tag other than tagId has the slug. The caller
+// must hold the lock.
+func (s *BlogStorageImpl) slugTaken(slug, tagId string) bool {
+	for _, tag := range s.tags {
+		if tag.Slug == slug && tag.TagId != tagId {
+			return true
+		}
+	}
+	return false
+}
+
+// withUsage returns a copy of the tag with its usage count. The caller must
+// hold the lock.
+func (s *BlogStorageImpl) withUsage(tag *models.Tag) *models.Tag {
+	clone := tag.Clone()
+	for id := range s.byTag[tag.Name] {
+		if !s.posts[id].Trashed() {
+			clone.UsageCount++
+		}
+	}
+	return clone
+}
+
+// tagSortKey orders tag listings by name.
+func tagSortKey(tag *models.Tag) (string, string) {
+	return tag.Name, tag.TagId
+}
+
+// slugify derives the URL-friendly form of a tag name: its lowercase letters
+// and digits, with every run of other characters turned into one hyphen.
+func slugify(name string) string {
+	var b strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(name) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = true
+			continue
+		}
+		if separate && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		separate = false
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "tag"
+	}
+	return b.String()
+}
+
+// uniqueSlug returns the slug of name. If taken reports it as used, a numeric
+// suffix is added, starting at -2 and counting up to the first free one.
+func uniqueSlug(name string, taken func(slug string) (bool, error)) (string, error) {
+	base := slugify(name)
+	slug := base
+	for n := 2; ; n++ {
+		used, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+func hasTag(tags []string, name string) bool {
+	for _, tag := range tags {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceTag replaces from with to in tags, keeping the position of the
+// first occurrence and dropping any that would repeat to.
+func replaceTag(tags []string, from, to string) []string {
+	replaced := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag == from {
+			tag = to
+		}
+		if tag == to && hasTag(replaced, to) {
+			continue
+		}
+		replaced = append(replaced, tag)
+	}
+	return replaced
+}
+
+// postTags returns the distinct tags of the posts, sorted, so that tags are
+// registered in the same order whatever the order of the posts.
+func postTags(posts map[string]*models.BlogPost) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, post := range posts {
+		for _, tag := range post.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The write-ahead log is a sequence of segment files named after the sequence
+// number of their first record. Every record is framed as
+//
+//	[4 byte length][4 byte CRC-32C of payload][payload]
+//
+// where the payload is the JSON encoding of a walRecord. A record that is cut
+// short by the end of the last segment, or that is the last record and fails
+// its checksum, is the remains of a write interrupted by a crash and is
+// discarded on replay. Damage anywhere else fails the replay.
+
+const (
+	walSegmentPrefix = "wal-"
+	walSegmentSuffix = ".log"
+	walHeaderSize    = 8
+
+	// maxWALRecordSize guards replay against allocating huge buffers when a
+	// length prefix is corrupted.
+	maxWALRecordSize = 64 << 20
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errCorruptWAL = errors.New("corrupt write-ahead log")
+
+	// errTornWAL reports a record cut short by the end of a segment.
+	errTornWAL = fmt.Errorf("%w: incomplete record at the end of the segment", errCorruptWAL)
+)
+
+// walRecord holds all changes of one mutation, so that replay applies them
+// atomically.
+type walRecord struct {
+	Seq     uint64   `json:"seq"`
+	Changes []change `json:"changes"`
+}
+
+// wal appends records to the current segment file.
+type wal struct {
+	dir string
+
+	// mu serializes writes, syncs and segment rotation.
+	mu    sync.Mutex
+	file  *os.File
+	size  int64
+	dirty bool
+
+	// err is set when a failed write could not be rolled back, after which
+	// the segment can no longer be trusted and all appends fail.
+	err error
+}
+
+func walSegmentName(firstSeq uint64) string {
+	return fmt.Sprintf("%s%020d%s", walSegmentPrefix, firstSeq, walSegmentSuffix)
+}
+
+// walSegments lists the segment files in dir, ordered by first sequence
+// number.
+func walSegments(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type segment struct {
+		name string
+		seq  uint64
+	}
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, walSegmentPrefix) || !strings.HasSuffix(name, walSegmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, walSegmentPrefix), walSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{name: name, seq: seq})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].seq < segments[j].seq })
+
+	paths := make([]string, len(segments))
+	for i, seg := range segments {
+		paths[i] = filepath.Join(dir, seg.name)
+	}
+	return paths, nil
+}
+
+// readWALSegment calls fn for every intact record of the segment at path and
+// returns the offset just past the last intact record. A torn record at the
+// end of the file is reported as errTornWAL along with that offset; a damaged
+// record followed by more data is reported as errCorruptWAL.
+func readWALSegment(path string, fn func(walRecord) error) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	var offset int64
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(f, header); err != nil {
+			switch err {
+			case io.EOF:
+				return offset, nil
+			case io.ErrUnexpectedEOF:
+				return offset, errTornWAL
+			}
+			return offset, err
+		}
+		size := binary.LittleEndian.Uint32(header[0:4])
+		sum := binary.LittleEndian.Uint32(header[4:8])
+		// A record that would run past the end of the file was cut short,
+		// whatever its length prefix says
+		end := offset + walHeaderSize + int64(size)
+		if end > info.Size() {
+			return offset, errTornWAL
+		}
+		if size > maxWALRecordSize {
+			return offset, fmt.Errorf("%w: record of %d bytes at offset %d", errCorruptWAL, size, offset)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(f, payload); err != nil {
+			return offset, err
+		}
+		var record walRecord
+		if crc32.Checksum(payload, crcTable) != sum {
+			err = errors.New("checksum mismatch")
+		} else {
+			err = json.Unmarshal(payload, &record)
+		}
+		if err != nil {
+			// The last record may have been written only in part
+			if end == info.Size() {
+				return offset, errTornWAL
+			}
+			return offset, fmt.Errorf("%w: record at offset %d: %v", errCorruptWAL, offset, err)
+		}
+		if err := fn(record); err != nil {
+			return offset, err
+		}
+		offset = end
+	}
+}
+
+// openWAL opens the segment at path for appending, creating it if needed.
+func openWAL(dir, path string) (*wal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err == nil {
+		err = syncDir(dir)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &wal{dir: dir, file: f, size: info.Size()}, nil
+}
+
+// append writes a record to the current segment, syncing it to disk when
+// sync is set.
+func (w *wal) append(record walRecord, sync bool) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.Checksum(payload, crcTable))
+	copy(frame[walHeaderSize:], payload)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	if _, err := w.file.Write(frame); err != nil {
+		w.rollback()
+		return err
+	}
+	if sync {
+		if err := w.file.Sync(); err != nil {
+			w.rollback()
+			return err
+		}
+	} else {
+		w.dirty = true
+	}
+	w.size += int64(len(frame))
+	return nil
+}
+
+// rollback drops a partially written record so that a failed append does not
+// leave a torn record in the middle of the segment. The caller must hold mu.
+func (w *wal) rollback() {
+	if err := w.file.Truncate(w.size); err != nil {
+		w.err = fmt.Errorf("%w: cannot roll back failed write: %v", errCorruptWAL, err)
+	}
+}
+
+// sync flushes the current segment to disk if it has unsynced writes.
+func (w *wal) sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.dirty {
+		return nil
+	}
+	w.dirty = false
+	return w.file.Sync()
+}
+
+// rotate syncs and closes the current segment and starts a new one whose
+// first record will have sequence number firstSeq.
+func (w *wal) rotate(firstSeq uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	path := filepath.Join(w.dir, walSegmentName(firstSeq))
+	if path == w.file.Name() {
+		// Nothing has been written since the last rotation
+		return nil
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := syncDir(w.dir); err != nil {
+		f.Close()
+		return err
+	}
+	w.file.Close()
+	w.file = f
+	w.size = 0
+	w.dirty = false
+	return nil
+}
+
+// currentSegment returns the path of the segment being appended to.
+func (w *wal) currentSegment() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Name()
+}
+
+func (w *wal) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// syncDir fsyncs a directory so that file creations, renames and removals in
+// it are durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
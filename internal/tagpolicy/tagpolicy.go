@@ -0,0 +1,153 @@
+// Package tagpolicy normalizes and validates the tags of a post.
+//
+// Normalize runs every tag through the same pipeline, so that "Go", " go"
+// and "ｇｏ" all end up as the same tag:
+//
+//  1. Unicode NFKC normalization
+//  2. lowercasing
+//  3. trimming surrounding whitespace
+//  4. replacing every run of inner whitespace with a hyphen
+//
+// and then drops duplicates, keeping the first occurrence. Tags that are
+// empty or too long after normalization, and posts with too many tags, are
+// rejected with a *PolicyError listing every violation.
+package tagpolicy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Policy configures the normalization pipeline and the limits tags must
+// respect.
+type Policy struct {
+	// NFKC applies Unicode compatibility normalization, which folds
+	// full-width letters, ligatures and the like into their plain forms.
+	NFKC bool
+
+	// Lowercase folds tags to lower case.
+	Lowercase bool
+
+	// CollapseSpaces replaces every run of whitespace inside a tag with a
+	// single hyphen. Surrounding whitespace is always trimmed.
+	CollapseSpaces bool
+
+	// MaxLength caps the length of a normalized tag, in characters. Zero
+	// means no limit.
+	MaxLength int
+
+	// MaxCount caps the number of distinct tags on a post. Zero means no
+	// limit.
+	MaxCount int
+}
+
+const (
+	DefaultMaxLength = 50
+	DefaultMaxCount  = 20
+)
+
+// Default returns the policy with every normalization step enabled and the
+// default limits.
+func Default() Policy {
+	return Policy{
+		NFKC:           true,
+		Lowercase:      true,
+		CollapseSpaces: true,
+		MaxLength:      DefaultMaxLength,
+		MaxCount:       DefaultMaxCount,
+	}
+}
+
+// Violation reasons.
+const (
+	ReasonEmptyTag    = "EMPTY_TAG"
+	ReasonTagTooLong  = "TAG_TOO_LONG"
+	ReasonTooManyTags = "TOO_MANY_TAGS"
+)
+
+// Violation describes one way in which the tags broke the policy.
+type Violation struct {
+	// Field is the request field at fault: "tags[i]" for a single tag, or
+	// "tags" for the list as a whole.
+	Field string
+	// Reason is one of the Reason constants.
+	Reason      string
+	Description string
+}
+
+// PolicyError lists every violation found in a list of tags.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	if len(e.Violations) == 1 {
+		return "invalid tags: " + e.Violations[0].Description
+	}
+	return fmt.Sprintf("invalid tags: %s (and %d more)", e.Violations[0].Description, len(e.Violations)-1)
+}
+
+// Normalize returns the normalized, deduplicated tags. It fails with a
+// *PolicyError if any tag is empty or too long after normalization, or if
+// there are too many distinct tags.
+func (p Policy) Normalize(tags []string) ([]string, error) {
+	var (
+		normalized []string
+		violations []Violation
+		seen       = make(map[string]bool)
+	)
+	for i, tag := range tags {
+		tag = p.normalize(tag)
+		field := fmt.Sprintf("tags[%d]", i)
+		switch {
+		case tag == "":
+			violations = append(violations, Violation{
+				Field:       field,
+				Reason:      ReasonEmptyTag,
+				Description: "tag cannot be empty",
+			})
+			continue
+		case p.MaxLength > 0 && utf8.RuneCountInString(tag) > p.MaxLength:
+			violations = append(violations, Violation{
+				Field:       field,
+				Reason:      ReasonTagTooLong,
+				Description: fmt.Sprintf("tag %q is longer than %d characters", tag, p.MaxLength),
+			})
+			continue
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	if p.MaxCount > 0 && len(normalized) > p.MaxCount {
+		violations = append(violations, Violation{
+			Field:       "tags",
+			Reason:      ReasonTooManyTags,
+			Description: fmt.Sprintf("a post can have at most %d tags, got %d", p.MaxCount, len(normalized)),
+		})
+	}
+	if len(violations) > 0 {
+		return nil, &PolicyError{Violations: violations}
+	}
+	return normalized, nil
+}
+
+// normalize runs a single tag through the pipeline.
+func (p Policy) normalize(tag string) string {
+	if p.NFKC {
+		tag = norm.NFKC.String(tag)
+	}
+	if p.Lowercase {
+		tag = strings.ToLower(tag)
+	}
+	tag = strings.TrimSpace(tag)
+	if p.CollapseSpaces {
+		tag = strings.Join(strings.FieldsFunc(tag, unicode.IsSpace), "-")
+	}
+	return tag
+}
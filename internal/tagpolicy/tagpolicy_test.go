@@ -0,0 +1,76 @@
+package tagpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want string
+	}{
+		{nil, "[]"},
+		{[]string{"Go", "go ", " GO"}, "[go]"},
+		{[]string{"ｇｏ", "ﬁle"}, "[go file]"},
+		{[]string{"Cloud  Native\tApps", "cloud native apps"}, "[cloud-native-apps]"},
+		{[]string{"rust", "go", "Rust"}, "[rust go]"},
+		{[]string{"C++", "c#"}, "[c++ c#]"},
+	}
+	for _, tt := range tests {
+		got, err := Default().Normalize(tt.tags)
+		if err != nil {
+			t.Errorf("Normalize(%q) failed: %v", tt.tags, err)
+			continue
+		}
+		if fmt.Sprint(got) != tt.want {
+			t.Errorf("Normalize(%q) = %v, want %s", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize_Steps(t *testing.T) {
+	p := Policy{}
+	got, err := p.Normalize([]string{" Go  Lang ", "Go  Lang"})
+	if err != nil || fmt.Sprint(got) != "[Go  Lang]" {
+		t.Errorf("expected only trimming and deduplication, got %q, %v", got, err)
+	}
+
+	p.CollapseSpaces = true
+	got, err = p.Normalize([]string{"Go  Lang"})
+	if err != nil || fmt.Sprint(got) != "[Go-Lang]" {
+		t.Errorf("expected spaces collapsed, got %q, %v", got, err)
+	}
+}
+
+func TestNormalize_Violations(t *testing.T) {
+	p := Policy{Lowercase: true, MaxLength: 5, MaxCount: 2}
+	_, err := p.Normalize([]string{"go", "  ", "kubernetes", "rust", "GO", "zig"})
+
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected a PolicyError, got %v", err)
+	}
+	var got []string
+	for _, v := range policyErr.Violations {
+		got = append(got, v.Field+" "+v.Reason)
+	}
+	want := "tags[1] EMPTY_TAG, tags[2] TAG_TOO_LONG, tags TOO_MANY_TAGS"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("expected violations %s, got %s", want, strings.Join(got, ", "))
+	}
+	if !strings.Contains(err.Error(), "(and 2 more)") {
+		t.Errorf("unexpected message: %v", err)
+	}
+
+	// Duplicates count once against the limit
+	if _, err := p.Normalize([]string{"go", "Go", "rust"}); err != nil {
+		t.Errorf("expected duplicates to be dropped before counting, got %v", err)
+	}
+	// Length is measured in characters, not bytes
+	if _, err := p.Normalize([]string{"ñandú"}); err != nil {
+		t.Errorf("expected a 5 character tag to pass, got %v", err)
+	}
+}
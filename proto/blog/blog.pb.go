@@ -9,6 +9,7 @@ package blog
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,6 +23,261 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Stage of a post's publishing lifecycle
+// Only published posts are listed unless the listing filter mentions the status
+type PostStatus int32
+
+const (
+	PostStatus_POST_STATUS_UNSPECIFIED PostStatus = 0 // Derived from the publication date on create
+	PostStatus_POST_STATUS_DRAFT       PostStatus = 1 // Work in progress
+	PostStatus_POST_STATUS_SCHEDULED   PostStatus = 2 // Published automatically once the publication date has passed
+	PostStatus_POST_STATUS_PUBLISHED   PostStatus = 3 // Live
+	PostStatus_POST_STATUS_ARCHIVED    PostStatus = 4 // Published once and since retired
+)
+
+// Enum value maps for PostStatus.
+var (
+	PostStatus_name = map[int32]string{
+		0: "POST_STATUS_UNSPECIFIED",
+		1: "POST_STATUS_DRAFT",
+		2: "POST_STATUS_SCHEDULED",
+		3: "POST_STATUS_PUBLISHED",
+		4: "POST_STATUS_ARCHIVED",
+	}
+	PostStatus_value = map[string]int32{
+		"POST_STATUS_UNSPECIFIED": 0,
+		"POST_STATUS_DRAFT":       1,
+		"POST_STATUS_SCHEDULED":   2,
+		"POST_STATUS_PUBLISHED":   3,
+		"POST_STATUS_ARCHIVED":    4,
+	}
+)
+
+func (x PostStatus) Enum() *PostStatus {
+	p := new(PostStatus)
+	*p = x
+	return p
+}
+
+func (x PostStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[0].Descriptor()
+}
+
+func (PostStatus) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[0]
+}
+
+func (x PostStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostStatus.Descriptor instead.
+func (PostStatus) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{0}
+}
+
+// How ImportBlogPosts treats posts that cannot be created
+type ImportMode int32
+
+const (
+	ImportMode_IMPORT_MODE_BEST_EFFORT    ImportMode = 0 // Create every post that can be created and report the others
+	ImportMode_IMPORT_MODE_ALL_OR_NOTHING ImportMode = 1 // Create the posts only if every one of them can be created
+)
+
+// Enum value maps for ImportMode.
+var (
+	ImportMode_name = map[int32]string{
+		0: "IMPORT_MODE_BEST_EFFORT",
+		1: "IMPORT_MODE_ALL_OR_NOTHING",
+	}
+	ImportMode_value = map[string]int32{
+		"IMPORT_MODE_BEST_EFFORT":    0,
+		"IMPORT_MODE_ALL_OR_NOTHING": 1,
+	}
+)
+
+func (x ImportMode) Enum() *ImportMode {
+	p := new(ImportMode)
+	*p = x
+	return p
+}
+
+func (x ImportMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportMode) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[1]
+}
+
+func (x ImportMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportMode.Descriptor instead.
+func (ImportMode) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{1}
+}
+
+// What happened to a post
+// Posts moved to the trash are deleted, and created again when restored
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_EVENT_TYPE_CREATED     EventType = 1 // The post was created or restored from the trash
+	EventType_EVENT_TYPE_UPDATED     EventType = 2 // The post was changed
+	EventType_EVENT_TYPE_DELETED     EventType = 3 // The post was deleted or moved to the trash
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_CREATED",
+		2: "EVENT_TYPE_UPDATED",
+		3: "EVENT_TYPE_DELETED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_CREATED":     1,
+		"EVENT_TYPE_UPDATED":     2,
+		"EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[2].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[2]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{2}
+}
+
+// How DiffPostRevisions reports changes to text fields
+type DiffFormat int32
+
+const (
+	DiffFormat_DIFF_FORMAT_UNIFIED DiffFormat = 0 // Unified line diff, as produced by diff -u
+	DiffFormat_DIFF_FORMAT_WORDS   DiffFormat = 1 // Word-level spans of kept, inserted and deleted text
+)
+
+// Enum value maps for DiffFormat.
+var (
+	DiffFormat_name = map[int32]string{
+		0: "DIFF_FORMAT_UNIFIED",
+		1: "DIFF_FORMAT_WORDS",
+	}
+	DiffFormat_value = map[string]int32{
+		"DIFF_FORMAT_UNIFIED": 0,
+		"DIFF_FORMAT_WORDS":   1,
+	}
+)
+
+func (x DiffFormat) Enum() *DiffFormat {
+	p := new(DiffFormat)
+	*p = x
+	return p
+}
+
+func (x DiffFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiffFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[3].Descriptor()
+}
+
+func (DiffFormat) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[3]
+}
+
+func (x DiffFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiffFormat.Descriptor instead.
+func (DiffFormat) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{3}
+}
+
+// What happened to the text of a DiffSpan
+type DiffOp int32
+
+const (
+	DiffOp_DIFF_OP_EQUAL  DiffOp = 0 // Text present in both revisions
+	DiffOp_DIFF_OP_INSERT DiffOp = 1 // Text only present in the newer revision
+	DiffOp_DIFF_OP_DELETE DiffOp = 2 // Text only present in the older revision
+)
+
+// Enum value maps for DiffOp.
+var (
+	DiffOp_name = map[int32]string{
+		0: "DIFF_OP_EQUAL",
+		1: "DIFF_OP_INSERT",
+		2: "DIFF_OP_DELETE",
+	}
+	DiffOp_value = map[string]int32{
+		"DIFF_OP_EQUAL":  0,
+		"DIFF_OP_INSERT": 1,
+		"DIFF_OP_DELETE": 2,
+	}
+)
+
+func (x DiffOp) Enum() *DiffOp {
+	p := new(DiffOp)
+	*p = x
+	return p
+}
+
+func (x DiffOp) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiffOp) Descriptor() protoreflect.EnumDescriptor {
+	return file_blog_proto_enumTypes[4].Descriptor()
+}
+
+func (DiffOp) Type() protoreflect.EnumType {
+	return &file_blog_proto_enumTypes[4]
+}
+
+func (x DiffOp) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiffOp.Descriptor instead.
+func (DiffOp) EnumDescriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{4}
+}
+
 type BlogPost struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                            // Unique identifier for the post
@@ -31,6 +287,10 @@ type BlogPost struct {
 	PublicationDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=publication_date,json=publicationDate,proto3" json:"publication_date,omitempty"` // Publication date of the blog post
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                   // Creation date of the blog post
 	Tags            []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`                                              // Tags associated with the blog post
+	Version         int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`                                       // Incremented on every update, starting at 1 when the post is created
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`                   // When the post was moved to the trash, unset for live posts
+	Status          PostStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=blog.v1.PostStatus" json:"status,omitempty"`                // Stage of the post's publishing lifecycle
+	AuthorId        string                 `protobuf:"bytes,11,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`                     // ID of the author, unset for posts created before authors existed
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -114,35 +374,4726 @@ func (x *BlogPost) GetTags() []string {
 	return nil
 }
 
+func (x *BlogPost) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BlogPost) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *BlogPost) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_POST_STATUS_UNSPECIFIED
+}
+
+func (x *BlogPost) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+// A revision records the editable fields of a post after a create or update
+// Revisions are immutable and numbered by the post version they recorded
+type PostRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`          // Post the revision belongs to
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`                   // Post version this revision recorded
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`                          // Title at this revision
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                      // Content at this revision
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                            // Tags at this revision
+	Editor        string                 `protobuf:"bytes,6,opt,name=editor,proto3" json:"editor,omitempty"`                        // Who made the change (the author for the first revision)
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // When the revision was made
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRevision) Reset() {
+	*x = PostRevision{}
+	mi := &file_blog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRevision) ProtoMessage() {}
+
+func (x *PostRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRevision.ProtoReflect.Descriptor instead.
+func (*PostRevision) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostRevision) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostRevision) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *PostRevision) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostRevision) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PostRevision) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PostRevision) GetEditor() string {
+	if x != nil {
+		return x.Editor
+	}
+	return ""
+}
+
+func (x *PostRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 // Request message for creating a new blog post
 // Input: Post details (Title, Content, Author, Publication Date, Tags)
 // Publication Date is optional and defaults to the current time if not provided
+// Status is optional: posts with a future publication date are scheduled, others published
+// The post's author name is taken from the author record
+// Set request_id to make the create safe to retry: a retry with the same request_id and payload
+// returns the post created the first time instead of a new one (ignored by ImportBlogPosts)
 type CreateBlogPostRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Title           string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                                                  // Title of the blog post
-	Content         string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                                              // Content of the blog post
-	Author          string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`                                                // Author of the blog post
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Title   string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`     // Title of the blog post
+	Content string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` // Content of the blog post
+	// Deprecated: Marked as deprecated in blog.proto.
+	Author          string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`                                                // Ignored, set author_id instead
 	PublicationDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=publication_date,json=publicationDate,proto3,oneof" json:"publication_date,omitempty"` // Publication date of the blog post (optional)
 	Tags            []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                                                    // Tags associated with the blog post
+	Status          PostStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=blog.v1.PostStatus" json:"status,omitempty"`                       // DRAFT, SCHEDULED (needs a future publication date) or PUBLISHED (optional)
+	AuthorId        string                 `protobuf:"bytes,7,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`                            // ID of an existing author, see AuthorService
+	RequestId       string                 `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                         // Idempotency key chosen by the client, at most 128 characters (optional)
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *CreateBlogPostRequest) Reset() {
-	*x = CreateBlogPostRequest{}
-	mi := &file_blog_proto_msgTypes[1]
+func (x *CreateBlogPostRequest) Reset() {
+	*x = CreateBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBlogPostRequest) ProtoMessage() {}
+
+func (x *CreateBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*CreateBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateBlogPostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateBlogPostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in blog.proto.
+func (x *CreateBlogPostRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *CreateBlogPostRequest) GetPublicationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublicationDate
+	}
+	return nil
+}
+
+func (x *CreateBlogPostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateBlogPostRequest) GetStatus() PostStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PostStatus_POST_STATUS_UNSPECIFIED
+}
+
+func (x *CreateBlogPostRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateBlogPostRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Response message for creating a new blog post
+// Output: The Post (PostID, Title, Content, Author, Publication Date, Tags)
+type CreateBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The created blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,4,opt,name=replayed,proto3" json:"replayed,omitempty"` // Whether the post was created by an earlier request with the same request_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBlogPostResponse) Reset() {
+	*x = CreateBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBlogPostResponse) ProtoMessage() {}
+
+func (x *CreateBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*CreateBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *CreateBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateBlogPostResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+// Request message for importing posts, one post per message
+// Input: The post, validated like a CreateBlogPostRequest, and the mode, read from the first message
+type ImportBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *CreateBlogPostRequest `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`                          // The post to create
+	Mode          ImportMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=blog.v1.ImportMode" json:"mode,omitempty"` // How to treat posts that cannot be created (first message only)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBlogPostsRequest) Reset() {
+	*x = ImportBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBlogPostsRequest) ProtoMessage() {}
+
+func (x *ImportBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*ImportBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportBlogPostsRequest) GetPost() *CreateBlogPostRequest {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *ImportBlogPostsRequest) GetMode() ImportMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ImportMode_IMPORT_MODE_BEST_EFFORT
+}
+
+// A post that could not be imported
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`    // Position of the post in the stream, starting at 0
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`   // Reason of the error, as in the ErrorInfo CreateBlogPost would return
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // Description of the error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_blog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Response message for importing posts
+// Output: The counts, the IDs of the created posts and the errors
+type ImportBlogPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Received      int32                  `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`             // Number of posts received
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`               // Number of posts created
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`                 // Number of posts that could not be created
+	PostIds       []string               `protobuf:"bytes,4,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"` // ID of the post created for each message received, empty if none was
+	Errors        []*ImportError         `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`                  // The posts that could not be created
+	Success       bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`               // Whether every post was created
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBlogPostsResponse) Reset() {
+	*x = ImportBlogPostsResponse{}
+	mi := &file_blog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBlogPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBlogPostsResponse) ProtoMessage() {}
+
+func (x *ImportBlogPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBlogPostsResponse.ProtoReflect.Descriptor instead.
+func (*ImportBlogPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportBlogPostsResponse) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *ImportBlogPostsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportBlogPostsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportBlogPostsResponse) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+func (x *ImportBlogPostsResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportBlogPostsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportBlogPostsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for retrieving a blog post
+// Input: PostID of the post to retrieve
+type GetBlogPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to retrieve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlogPostRequest) Reset() {
+	*x = GetBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlogPostRequest) ProtoMessage() {}
+
+func (x *GetBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*GetBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Response message for retrieving a blog post
+// Output: The Post (PostID, Title, Content, Author, Publication Date, Tags)
+type GetBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The retrieved blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlogPostResponse) Reset() {
+	*x = GetBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlogPostResponse) ProtoMessage() {}
+
+func (x *GetBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*GetBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *GetBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for retrieving several blog posts at once
+// Input: Up to 100 PostIDs, read at a single point in time
+type BatchGetBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"` // Unique identifiers of the posts to retrieve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetBlogPostsRequest) Reset() {
+	*x = BatchGetBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetBlogPostsRequest) ProtoMessage() {}
+
+func (x *BatchGetBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetBlogPostsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+// The outcome for one PostID of a batch
+type BatchGetBlogPostsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // The requested PostID
+	Post          *BlogPost              `protobuf:"bytes,2,opt,name=post,proto3" json:"post,omitempty"`                   // The retrieved blog post, unset if it was not found
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`               // Reason of the error, as in the ErrorInfo GetBlogPost would return
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`             // Description of the error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetBlogPostsResult) Reset() {
+	*x = BatchGetBlogPostsResult{}
+	mi := &file_blog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetBlogPostsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetBlogPostsResult) ProtoMessage() {}
+
+func (x *BatchGetBlogPostsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetBlogPostsResult.ProtoReflect.Descriptor instead.
+func (*BatchGetBlogPostsResult) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchGetBlogPostsResult) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *BatchGetBlogPostsResult) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *BatchGetBlogPostsResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchGetBlogPostsResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Response message for retrieving several blog posts
+// Output: One result per requested PostID, in request order
+type BatchGetBlogPostsResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Results       []*BatchGetBlogPostsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Found         int32                      `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`     // Number of posts retrieved
+	Missing       int32                      `protobuf:"varint,3,opt,name=missing,proto3" json:"missing,omitempty"` // Number of PostIDs without a post
+	Success       bool                       `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"` // Whether every post was retrieved
+	Message       string                     `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetBlogPostsResponse) Reset() {
+	*x = BatchGetBlogPostsResponse{}
+	mi := &file_blog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetBlogPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetBlogPostsResponse) ProtoMessage() {}
+
+func (x *BatchGetBlogPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetBlogPostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetBlogPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchGetBlogPostsResponse) GetResults() []*BatchGetBlogPostsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchGetBlogPostsResponse) GetFound() int32 {
+	if x != nil {
+		return x.Found
+	}
+	return 0
+}
+
+func (x *BatchGetBlogPostsResponse) GetMissing() int32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+func (x *BatchGetBlogPostsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchGetBlogPostsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for updating a blog post
+// Input: PostID of the post to update and new details (Title, Content, Author, Tags)
+// Set expected_version to the version that was read to avoid overwriting a concurrent edit
+// With an update_mask exactly the listed fields are set, so an empty tags list removes all tags;
+// without one, empty fields are left unchanged
+type UpdateBlogPostRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	PostId  string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to update
+	Title   string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                 // New title of the blog post
+	Content string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`             // New content of the blog post
+	Tags    []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                   // New tags associated with the blog post
+	// publication_date is not allowed to be updated
+	// updated_at is automatically set to the current time when the post is updated
+	ExpectedVersion int64                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fail with ABORTED unless the post is still at this version, 0 to skip the check
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`                 // Fields to update: title, content and/or tags
+	Editor          string                 `protobuf:"bytes,7,opt,name=editor,proto3" json:"editor,omitempty"`                                           // Who is making the change, recorded in the revision history
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateBlogPostRequest) Reset() {
+	*x = UpdateBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlogPostRequest) ProtoMessage() {}
+
+func (x *UpdateBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *UpdateBlogPostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateBlogPostRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateBlogPostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateBlogPostRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateBlogPostRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateBlogPostRequest) GetEditor() string {
+	if x != nil {
+		return x.Editor
+	}
+	return ""
+}
+
+// Response message for updating a blog post
+// Output: Post details (PostID, Title, Content, Author, Publication Date, Tags)
+type UpdateBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The updated blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBlogPostResponse) Reset() {
+	*x = UpdateBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlogPostResponse) ProtoMessage() {}
+
+func (x *UpdateBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *UpdateBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for deleting a blog post
+// Input: PostID of the post to delete
+// Deleted posts are moved to the trash, from where they can be restored until they are purged
+type DeleteBlogPostRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                             // Unique identifier for the post to delete
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fail with ABORTED unless the post is still at this version, 0 to skip the check
+	Permanent       bool                   `protobuf:"varint,3,opt,name=permanent,proto3" json:"permanent,omitempty"`                                    // Skip the trash and delete the post for good
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteBlogPostRequest) Reset() {
+	*x = DeleteBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBlogPostRequest) ProtoMessage() {}
+
+func (x *DeleteBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *DeleteBlogPostRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *DeleteBlogPostRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+// Response message for deleting a blog post
+// Output: Success/Failure message
+type DeleteBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBlogPostResponse) Reset() {
+	*x = DeleteBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBlogPostResponse) ProtoMessage() {}
+
+func (x *DeleteBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for deleting several blog posts at once
+// Input: Up to 100 PostIDs, deleted together like DeleteBlogPostRequest without a version check
+type BatchDeleteBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"` // Unique identifiers of the posts to delete
+	Permanent     bool                   `protobuf:"varint,2,opt,name=permanent,proto3" json:"permanent,omitempty"`           // Skip the trash and delete the posts for good
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteBlogPostsRequest) Reset() {
+	*x = BatchDeleteBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteBlogPostsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchDeleteBlogPostsRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+func (x *BatchDeleteBlogPostsRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+// The outcome for one PostID of a batch
+type BatchDeleteBlogPostsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // The requested PostID
+	Deleted       bool                   `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`            // Whether the post was deleted
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`               // Reason of the error, as in the ErrorInfo DeleteBlogPost would return
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`             // Description of the error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteBlogPostsResult) Reset() {
+	*x = BatchDeleteBlogPostsResult{}
+	mi := &file_blog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteBlogPostsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteBlogPostsResult) ProtoMessage() {}
+
+func (x *BatchDeleteBlogPostsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteBlogPostsResult.ProtoReflect.Descriptor instead.
+func (*BatchDeleteBlogPostsResult) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchDeleteBlogPostsResult) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *BatchDeleteBlogPostsResult) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *BatchDeleteBlogPostsResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchDeleteBlogPostsResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Response message for deleting several blog posts
+// Output: One result per requested PostID, in request order
+type BatchDeleteBlogPostsResponse struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Results       []*BatchDeleteBlogPostsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Deleted       int32                         `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"` // Number of posts deleted
+	Missing       int32                         `protobuf:"varint,3,opt,name=missing,proto3" json:"missing,omitempty"` // Number of PostIDs without a post to delete
+	Success       bool                          `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"` // Whether every post was deleted
+	Message       string                        `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteBlogPostsResponse) Reset() {
+	*x = BatchDeleteBlogPostsResponse{}
+	mi := &file_blog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteBlogPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteBlogPostsResponse) ProtoMessage() {}
+
+func (x *BatchDeleteBlogPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteBlogPostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeleteBlogPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchDeleteBlogPostsResponse) GetResults() []*BatchDeleteBlogPostsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchDeleteBlogPostsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *BatchDeleteBlogPostsResponse) GetMissing() int32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+func (x *BatchDeleteBlogPostsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchDeleteBlogPostsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for restoring a blog post from the trash
+// Input: PostID of the post to restore
+type RestoreBlogPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to restore
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreBlogPostRequest) Reset() {
+	*x = RestoreBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBlogPostRequest) ProtoMessage() {}
+
+func (x *RestoreBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*RestoreBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RestoreBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Response message for restoring a blog post
+// Output: The restored post
+type RestoreBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The restored blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreBlogPostResponse) Reset() {
+	*x = RestoreBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBlogPostResponse) ProtoMessage() {}
+
+func (x *RestoreBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*RestoreBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *RestoreBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for permanently deleting a blog post from the trash
+// Input: PostID of the post to purge
+type PurgeBlogPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to purge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeBlogPostRequest) Reset() {
+	*x = PurgeBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeBlogPostRequest) ProtoMessage() {}
+
+func (x *PurgeBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*PurgeBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Response message for purging a blog post
+// Output: Success/Failure message
+type PurgeBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeBlogPostResponse) Reset() {
+	*x = PurgeBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeBlogPostResponse) ProtoMessage() {}
+
+func (x *PurgeBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*PurgeBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PurgeBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PurgeBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for publishing a blog post
+// Input: PostID of the post to publish and an optional publication date
+// Drafts and scheduled posts are published now unless publish_at is set, in which case a future
+// date schedules the post; archived posts are republished with their original date
+type PublishBlogPostRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                             // Unique identifier for the post to publish
+	PublishAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=publish_at,json=publishAt,proto3,oneof" json:"publish_at,omitempty"`              // When to publish the post (optional)
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fail with ABORTED unless the post is still at this version, 0 to skip the check
+	Editor          string                 `protobuf:"bytes,4,opt,name=editor,proto3" json:"editor,omitempty"`                                           // Who is making the change, recorded in the revision history
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PublishBlogPostRequest) Reset() {
+	*x = PublishBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishBlogPostRequest) ProtoMessage() {}
+
+func (x *PublishBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*PublishBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PublishBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PublishBlogPostRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *PublishBlogPostRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *PublishBlogPostRequest) GetEditor() string {
+	if x != nil {
+		return x.Editor
+	}
+	return ""
+}
+
+// Response message for publishing a blog post
+// Output: The published or scheduled post
+type PublishBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The published blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishBlogPostResponse) Reset() {
+	*x = PublishBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishBlogPostResponse) ProtoMessage() {}
+
+func (x *PublishBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*PublishBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PublishBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *PublishBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PublishBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for turning a scheduled, published or archived post back into a draft
+// Input: PostID of the post to unpublish
+type UnpublishBlogPostRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                             // Unique identifier for the post to unpublish
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fail with ABORTED unless the post is still at this version, 0 to skip the check
+	Editor          string                 `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`                                           // Who is making the change, recorded in the revision history
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UnpublishBlogPostRequest) Reset() {
+	*x = UnpublishBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishBlogPostRequest) ProtoMessage() {}
+
+func (x *UnpublishBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnpublishBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *UnpublishBlogPostRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UnpublishBlogPostRequest) GetEditor() string {
+	if x != nil {
+		return x.Editor
+	}
+	return ""
+}
+
+// Response message for unpublishing a blog post
+// Output: The draft post
+type UnpublishBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The unpublished blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishBlogPostResponse) Reset() {
+	*x = UnpublishBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishBlogPostResponse) ProtoMessage() {}
+
+func (x *UnpublishBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*UnpublishBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnpublishBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *UnpublishBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnpublishBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for archiving a published post
+// Input: PostID of the post to archive
+type ArchiveBlogPostRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                             // Unique identifier for the post to archive
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fail with ABORTED unless the post is still at this version, 0 to skip the check
+	Editor          string                 `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`                                           // Who is making the change, recorded in the revision history
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ArchiveBlogPostRequest) Reset() {
+	*x = ArchiveBlogPostRequest{}
+	mi := &file_blog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveBlogPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveBlogPostRequest) ProtoMessage() {}
+
+func (x *ArchiveBlogPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveBlogPostRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveBlogPostRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ArchiveBlogPostRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *ArchiveBlogPostRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *ArchiveBlogPostRequest) GetEditor() string {
+	if x != nil {
+		return x.Editor
+	}
+	return ""
+}
+
+// Response message for archiving a blog post
+// Output: The archived post
+type ArchiveBlogPostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The archived blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveBlogPostResponse) Reset() {
+	*x = ArchiveBlogPostResponse{}
+	mi := &file_blog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveBlogPostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveBlogPostResponse) ProtoMessage() {}
+
+func (x *ArchiveBlogPostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveBlogPostResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveBlogPostResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ArchiveBlogPostResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *ArchiveBlogPostResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ArchiveBlogPostResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for listing blog posts
+// Input: Page size, an opaque page token from a previous response, and optional filter and order
+// Posts are ordered by publication date (newest first) unless order_by says otherwise,
+// with PostID as a tiebreaker
+// Filter example: author = "x" AND tags:"go" AND publication_date > "2025-01-01"
+// Order example: title asc
+type ListBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // Maximum number of posts to return (defaults to 50, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`        // Token from a previous ListBlogPostsResponse, empty for the first page
+	Filter        string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`                               // Filter expression, empty to list all posts
+	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`              // Field to sort by, optionally followed by asc or desc
+	ShowDeleted   bool                   `protobuf:"varint,5,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"` // Include posts in the trash
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlogPostsRequest) Reset() {
+	*x = ListBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlogPostsRequest) ProtoMessage() {}
+
+func (x *ListBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListBlogPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBlogPostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBlogPostsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListBlogPostsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListBlogPostsRequest) GetShowDeleted() bool {
+	if x != nil {
+		return x.ShowDeleted
+	}
+	return false
+}
+
+// Response message for listing blog posts
+// Output: A page of posts and the token for the next page
+type ListBlogPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*BlogPost            `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`                                        // The posts in this page
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty when there are no more posts
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlogPostsResponse) Reset() {
+	*x = ListBlogPostsResponse{}
+	mi := &file_blog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlogPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlogPostsResponse) ProtoMessage() {}
+
+func (x *ListBlogPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlogPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListBlogPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListBlogPostsResponse) GetPosts() []*BlogPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListBlogPostsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBlogPostsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListBlogPostsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for listing the published posts of an author
+// Input: AuthorID, page size and an opaque page token from a previous response
+// Posts are ordered by publication date (newest first), with PostID as a tiebreaker
+type ListBlogPostsByAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`    // Unique identifier for the author
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of posts to return (defaults to 50, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from a previous ListBlogPostsByAuthorResponse, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlogPostsByAuthorRequest) Reset() {
+	*x = ListBlogPostsByAuthorRequest{}
+	mi := &file_blog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlogPostsByAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlogPostsByAuthorRequest) ProtoMessage() {}
+
+func (x *ListBlogPostsByAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlogPostsByAuthorRequest.ProtoReflect.Descriptor instead.
+func (*ListBlogPostsByAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListBlogPostsByAuthorRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListBlogPostsByAuthorRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBlogPostsByAuthorRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response message for listing the published posts of an author
+// Output: A page of posts and the token for the next page
+type ListBlogPostsByAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*BlogPost            `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`                                        // The posts in this page
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty when there are no more posts
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlogPostsByAuthorResponse) Reset() {
+	*x = ListBlogPostsByAuthorResponse{}
+	mi := &file_blog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlogPostsByAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlogPostsByAuthorResponse) ProtoMessage() {}
+
+func (x *ListBlogPostsByAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlogPostsByAuthorResponse.ProtoReflect.Descriptor instead.
+func (*ListBlogPostsByAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListBlogPostsByAuthorResponse) GetPosts() []*BlogPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListBlogPostsByAuthorResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBlogPostsByAuthorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListBlogPostsByAuthorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for listing the published posts carrying a tag
+// Input: Tag name, page size and an opaque page token from a previous response
+// Posts are ordered as by ListBlogPostsByAuthor
+type ListBlogPostsByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`                              // Name of the tag
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of posts to return (defaults to 50, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from a previous ListBlogPostsByTagResponse, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlogPostsByTagRequest) Reset() {
+	*x = ListBlogPostsByTagRequest{}
+	mi := &file_blog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlogPostsByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlogPostsByTagRequest) ProtoMessage() {}
+
+func (x *ListBlogPostsByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlogPostsByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListBlogPostsByTagRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListBlogPostsByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListBlogPostsByTagRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBlogPostsByTagRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response message for listing the published posts carrying a tag
+// Output: A page of posts and the token for the next page
+type ListBlogPostsByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*BlogPost            `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`                                        // The posts in this page
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty when there are no more posts
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlogPostsByTagResponse) Reset() {
+	*x = ListBlogPostsByTagResponse{}
+	mi := &file_blog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlogPostsByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlogPostsByTagResponse) ProtoMessage() {}
+
+func (x *ListBlogPostsByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlogPostsByTagResponse.ProtoReflect.Descriptor instead.
+func (*ListBlogPostsByTagResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListBlogPostsByTagResponse) GetPosts() []*BlogPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ListBlogPostsByTagResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBlogPostsByTagResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListBlogPostsByTagResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for searching blog posts
+// Input: Query, page size and an opaque page token from a previous response
+// Query example: grpc "server streaming" gorout*
+// Words, "quoted phrases" and prefixes ending in * must all match the title,
+// content or tags of a published post; results are ranked by relevance
+type SearchBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                          // The search query
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of results to return (defaults to 50, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from a previous SearchBlogPostsResponse, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchBlogPostsRequest) Reset() {
+	*x = SearchBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchBlogPostsRequest) ProtoMessage() {}
+
+func (x *SearchBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*SearchBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SearchBlogPostsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchBlogPostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchBlogPostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// A run of text in a search result
+type HighlightSpan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Highlighted   bool                   `protobuf:"varint,2,opt,name=highlighted,proto3" json:"highlighted,omitempty"` // Whether the text matches the query
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HighlightSpan) Reset() {
+	*x = HighlightSpan{}
+	mi := &file_blog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HighlightSpan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighlightSpan) ProtoMessage() {}
+
+func (x *HighlightSpan) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighlightSpan.ProtoReflect.Descriptor instead.
+func (*HighlightSpan) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *HighlightSpan) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *HighlightSpan) GetHighlighted() bool {
+	if x != nil {
+		return x.Highlighted
+	}
+	return false
+}
+
+// A post matching a search query
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`       // The matching post
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`   // Relevance of the post to the query, higher is more relevant
+	Title         []*HighlightSpan       `protobuf:"bytes,3,rep,name=title,proto3" json:"title,omitempty"`     // The whole title, split around the matches
+	Snippet       []*HighlightSpan       `protobuf:"bytes,4,rep,name=snippet,proto3" json:"snippet,omitempty"` // An excerpt of the content around the first match, split around the matches
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_blog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SearchResult) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetTitle() []*HighlightSpan {
+	if x != nil {
+		return x.Title
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSnippet() []*HighlightSpan {
+	if x != nil {
+		return x.Snippet
+	}
+	return nil
+}
+
+// Response message for searching blog posts
+// Output: A page of results, most relevant first, and the token for the next page
+type SearchBlogPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                    // The results in this page
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty when there are no more results
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchBlogPostsResponse) Reset() {
+	*x = SearchBlogPostsResponse{}
+	mi := &file_blog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchBlogPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchBlogPostsResponse) ProtoMessage() {}
+
+func (x *SearchBlogPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchBlogPostsResponse.ProtoReflect.Descriptor instead.
+func (*SearchBlogPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SearchBlogPostsResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchBlogPostsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchBlogPostsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SearchBlogPostsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for finding the posts related to a post
+// Input: PostID of the post and the number of related posts to return
+// Published posts are scored by the overlap of their tags with those of the
+// post (a Jaccard index) combined with the similarity of their title and content
+type GetRelatedPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                // Maximum number of related posts to return (defaults to 5, capped at 50)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedPostsRequest) Reset() {
+	*x = GetRelatedPostsRequest{}
+	mi := &file_blog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedPostsRequest) ProtoMessage() {}
+
+func (x *GetRelatedPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetRelatedPostsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetRelatedPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A post related to another
+type RelatedPost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`     // The related post
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // How closely the post is related, from 0 to 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedPost) Reset() {
+	*x = RelatedPost{}
+	mi := &file_blog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedPost) ProtoMessage() {}
+
+func (x *RelatedPost) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedPost.ProtoReflect.Descriptor instead.
+func (*RelatedPost) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RelatedPost) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *RelatedPost) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// Response message for finding related posts
+// Output: The related posts, most closely related first
+type GetRelatedPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posts         []*RelatedPost         `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"` // The related posts
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedPostsResponse) Reset() {
+	*x = GetRelatedPostsResponse{}
+	mi := &file_blog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedPostsResponse) ProtoMessage() {}
+
+func (x *GetRelatedPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedPostsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetRelatedPostsResponse) GetPosts() []*RelatedPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *GetRelatedPostsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetRelatedPostsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for exporting posts
+// Input: Optional filter, with the same syntax as ListBlogPostsRequest
+// Posts of every status are exported unless the filter narrows them down
+type ExportBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`                               // Filter expression, empty to export all posts
+	ShowDeleted   bool                   `protobuf:"varint,2,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"` // Include posts in the trash
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBlogPostsRequest) Reset() {
+	*x = ExportBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBlogPostsRequest) ProtoMessage() {}
+
+func (x *ExportBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*ExportBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExportBlogPostsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ExportBlogPostsRequest) GetShowDeleted() bool {
+	if x != nil {
+		return x.ShowDeleted
+	}
+	return false
+}
+
+// One exported post
+// All the posts of an export are read from the same point in time
+type ExportBlogPostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`          // The exported post
+	Sequence      uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"` // Sequence number of the last change included in the export, the same on every message; watch from it to follow the later changes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBlogPostsResponse) Reset() {
+	*x = ExportBlogPostsResponse{}
+	mi := &file_blog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBlogPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBlogPostsResponse) ProtoMessage() {}
+
+func (x *ExportBlogPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBlogPostsResponse.ProtoReflect.Descriptor instead.
+func (*ExportBlogPostsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ExportBlogPostsResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *ExportBlogPostsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// Request message for watching changes to posts
+// Input: Optional author and tag filters, and the sequence number to resume from
+type WatchBlogPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`                 // Only watch the posts of this author (optional)
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`                                           // Only watch the posts carrying this tag (optional)
+	SinceSequence uint64                 `protobuf:"varint,3,opt,name=since_sequence,json=sinceSequence,proto3" json:"since_sequence,omitempty"` // Resume after the event with this sequence number, or watch from now when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBlogPostsRequest) Reset() {
+	*x = WatchBlogPostsRequest{}
+	mi := &file_blog_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBlogPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBlogPostsRequest) ProtoMessage() {}
+
+func (x *WatchBlogPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBlogPostsRequest.ProtoReflect.Descriptor instead.
+func (*WatchBlogPostsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WatchBlogPostsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *WatchBlogPostsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *WatchBlogPostsRequest) GetSinceSequence() uint64 {
+	if x != nil {
+		return x.SinceSequence
+	}
+	return 0
+}
+
+// A change to a post
+// Filters match the post before or after the change, so watchers also learn
+// about posts that stop matching
+type PostEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`                // Increases by one with every event; pass it as since_sequence to resume after this event
+	Type          EventType              `protobuf:"varint,2,opt,name=type,proto3,enum=blog.v1.EventType" json:"type,omitempty"` // What happened to the post
+	PostId        string                 `protobuf:"bytes,3,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`       // ID of the post
+	Post          *BlogPost              `protobuf:"bytes,4,opt,name=post,proto3" json:"post,omitempty"`                         // The post after the change, or as it was when deleted
+	Previous      *BlogPost              `protobuf:"bytes,5,opt,name=previous,proto3" json:"previous,omitempty"`                 // The post before the change, unset for created posts
+	Time          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`                         // When the change was made
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostEvent) Reset() {
+	*x = PostEvent{}
+	mi := &file_blog_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostEvent) ProtoMessage() {}
+
+func (x *PostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostEvent.ProtoReflect.Descriptor instead.
+func (*PostEvent) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PostEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PostEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *PostEvent) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostEvent) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPrevious() *BlogPost {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *PostEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// Request message for listing the revisions of a post
+// Input: PostID of the post
+type ListPostRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostRevisionsRequest) Reset() {
+	*x = ListPostRevisionsRequest{}
+	mi := &file_blog_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostRevisionsRequest) ProtoMessage() {}
+
+func (x *ListPostRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListPostRevisionsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+// Response message for listing the revisions of a post
+// Output: The revisions, newest first
+type ListPostRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*PostRevision        `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // The revision history of the post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostRevisionsResponse) Reset() {
+	*x = ListPostRevisionsResponse{}
+	mi := &file_blog_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostRevisionsResponse) ProtoMessage() {}
+
+func (x *ListPostRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPostRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListPostRevisionsResponse) GetRevisions() []*PostRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *ListPostRevisionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListPostRevisionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for retrieving a single revision
+// Input: PostID and revision number
+type GetPostRevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`          // Revision number to retrieve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRevisionRequest) Reset() {
+	*x = GetPostRevisionRequest{}
+	mi := &file_blog_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRevisionRequest) ProtoMessage() {}
+
+func (x *GetPostRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRevisionRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetPostRevisionRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *GetPostRevisionRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Response message for retrieving a single revision
+// Output: The revision
+type GetPostRevisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      *PostRevision          `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"` // The requested revision
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRevisionResponse) Reset() {
+	*x = GetPostRevisionResponse{}
+	mi := &file_blog_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRevisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRevisionResponse) ProtoMessage() {}
+
+func (x *GetPostRevisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRevisionResponse.ProtoReflect.Descriptor instead.
+func (*GetPostRevisionResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetPostRevisionResponse) GetRevision() *PostRevision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+func (x *GetPostRevisionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetPostRevisionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for restoring a post to an earlier revision
+// Input: PostID and the revision to restore
+// Restoring copies the title, content and tags of the revision into a new revision
+type RestorePostRevisionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PostId          string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                             // Unique identifier for the post
+	Revision        int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`                                      // Revision number to restore
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Fail with ABORTED unless the post is still at this version, 0 to skip the check
+	Editor          string                 `protobuf:"bytes,4,opt,name=editor,proto3" json:"editor,omitempty"`                                           // Who is restoring the revision
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestorePostRevisionRequest) Reset() {
+	*x = RestorePostRevisionRequest{}
+	mi := &file_blog_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePostRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePostRevisionRequest) ProtoMessage() {}
+
+func (x *RestorePostRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePostRevisionRequest.ProtoReflect.Descriptor instead.
+func (*RestorePostRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RestorePostRevisionRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *RestorePostRevisionRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RestorePostRevisionRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *RestorePostRevisionRequest) GetEditor() string {
+	if x != nil {
+		return x.Editor
+	}
+	return ""
+}
+
+// Response message for restoring a revision
+// Output: The post after the restore
+type RestorePostRevisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The restored blog post
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePostRevisionResponse) Reset() {
+	*x = RestorePostRevisionResponse{}
+	mi := &file_blog_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePostRevisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePostRevisionResponse) ProtoMessage() {}
+
+func (x *RestorePostRevisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePostRevisionResponse.ProtoReflect.Descriptor instead.
+func (*RestorePostRevisionResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RestorePostRevisionResponse) GetPost() *BlogPost {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *RestorePostRevisionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestorePostRevisionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// A run of text that was kept, inserted or deleted
+type DiffSpan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            DiffOp                 `protobuf:"varint,1,opt,name=op,proto3,enum=blog.v1.DiffOp" json:"op,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSpan) Reset() {
+	*x = DiffSpan{}
+	mi := &file_blog_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSpan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSpan) ProtoMessage() {}
+
+func (x *DiffSpan) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSpan.ProtoReflect.Descriptor instead.
+func (*DiffSpan) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DiffSpan) GetOp() DiffOp {
+	if x != nil {
+		return x.Op
+	}
+	return DiffOp_DIFF_OP_EQUAL
+}
+
+func (x *DiffSpan) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// The changes to a single text field
+type TextDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"` // Whether the field differs between the revisions
+	Unified       string                 `protobuf:"bytes,2,opt,name=unified,proto3" json:"unified,omitempty"`  // Unified diff, set for DIFF_FORMAT_UNIFIED
+	Spans         []*DiffSpan            `protobuf:"bytes,3,rep,name=spans,proto3" json:"spans,omitempty"`      // Word-level spans covering the whole field, set for DIFF_FORMAT_WORDS
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextDiff) Reset() {
+	*x = TextDiff{}
+	mi := &file_blog_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextDiff) ProtoMessage() {}
+
+func (x *TextDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextDiff.ProtoReflect.Descriptor instead.
+func (*TextDiff) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TextDiff) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *TextDiff) GetUnified() string {
+	if x != nil {
+		return x.Unified
+	}
+	return ""
+}
+
+func (x *TextDiff) GetSpans() []*DiffSpan {
+	if x != nil {
+		return x.Spans
+	}
+	return nil
+}
+
+// The changes to the tags of a post
+type TagsDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"` // Whether the tags differ between the revisions, including their order
+	Added         []string               `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`      // Tags only present in the newer revision
+	Removed       []string               `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`  // Tags only present in the older revision
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagsDiff) Reset() {
+	*x = TagsDiff{}
+	mi := &file_blog_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagsDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagsDiff) ProtoMessage() {}
+
+func (x *TagsDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagsDiff.ProtoReflect.Descriptor instead.
+func (*TagsDiff) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TagsDiff) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *TagsDiff) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *TagsDiff) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+// Request message for comparing two revisions of a post
+// Input: PostID, the two revisions to compare and the output format
+// A revision of 0 means the current state of the post
+type DiffPostRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`                          // Unique identifier for the post
+	FromRevision  int64                  `protobuf:"varint,2,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`       // Older revision, 0 for the current state
+	ToRevision    int64                  `protobuf:"varint,3,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"`             // Newer revision, 0 for the current state
+	Format        DiffFormat             `protobuf:"varint,4,opt,name=format,proto3,enum=blog.v1.DiffFormat" json:"format,omitempty"`               // Format of the title and content diffs
+	ContextLines  *int32                 `protobuf:"varint,5,opt,name=context_lines,json=contextLines,proto3,oneof" json:"context_lines,omitempty"` // Unchanged lines around each change in unified diffs (defaults to 3)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffPostRevisionsRequest) Reset() {
+	*x = DiffPostRevisionsRequest{}
+	mi := &file_blog_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffPostRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffPostRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffPostRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffPostRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffPostRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DiffPostRevisionsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *DiffPostRevisionsRequest) GetFromRevision() int64 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsRequest) GetToRevision() int64 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsRequest) GetFormat() DiffFormat {
+	if x != nil {
+		return x.Format
+	}
+	return DiffFormat_DIFF_FORMAT_UNIFIED
+}
+
+func (x *DiffPostRevisionsRequest) GetContextLines() int32 {
+	if x != nil && x.ContextLines != nil {
+		return *x.ContextLines
+	}
+	return 0
+}
+
+// Response message for comparing two revisions
+// Output: The changes to the title, content and tags
+type DiffPostRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromRevision  int64                  `protobuf:"varint,1,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"` // Revision number compared from, with "current" resolved
+	ToRevision    int64                  `protobuf:"varint,2,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"`       // Revision number compared to, with "current" resolved
+	Title         *TextDiff              `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`                                    // Changes to the title
+	Content       *TextDiff              `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                                // Changes to the content
+	Tags          *TagsDiff              `protobuf:"bytes,5,opt,name=tags,proto3" json:"tags,omitempty"`                                      // Changes to the tags
+	Success       bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffPostRevisionsResponse) Reset() {
+	*x = DiffPostRevisionsResponse{}
+	mi := &file_blog_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffPostRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffPostRevisionsResponse) ProtoMessage() {}
+
+func (x *DiffPostRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffPostRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffPostRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DiffPostRevisionsResponse) GetFromRevision() int64 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsResponse) GetToRevision() int64 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+func (x *DiffPostRevisionsResponse) GetTitle() *TextDiff {
+	if x != nil {
+		return x.Title
+	}
+	return nil
+}
+
+func (x *DiffPostRevisionsResponse) GetContent() *TextDiff {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *DiffPostRevisionsResponse) GetTags() *TagsDiff {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DiffPostRevisionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DiffPostRevisionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// An author of blog posts
+// Profile names and emails are unique, ignoring case
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`          // Unique identifier for the author
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                  // Display name, copied to the author's posts
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`                                // Contact email (optional)
+	ProfileName   string                 `protobuf:"bytes,4,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"` // Unique handle of the author
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`       // When the author was created
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`       // When the author was last updated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_blog_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Author) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Author) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Author) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+func (x *Author) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Author) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Request message for creating an author
+// Input: Name, profile name and an optional email
+type CreateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                  // Display name of the author
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`                                // Contact email (optional)
+	ProfileName   string                 `protobuf:"bytes,3,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"` // Unique handle of the author
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthorRequest) Reset() {
+	*x = CreateAuthorRequest{}
+	mi := &file_blog_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthorRequest) ProtoMessage() {}
+
+func (x *CreateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateAuthorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAuthorRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateAuthorRequest) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+// Response message for creating an author
+// Output: The created author
+type CreateAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        *Author                `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"` // The created author
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthorResponse) Reset() {
+	*x = CreateAuthorResponse{}
+	mi := &file_blog_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthorResponse) ProtoMessage() {}
+
+func (x *CreateAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthorResponse.ProtoReflect.Descriptor instead.
+func (*CreateAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateAuthorResponse) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *CreateAuthorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateAuthorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for retrieving an author
+// Input: AuthorID of the author to retrieve
+type GetAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // Unique identifier for the author to retrieve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorRequest) Reset() {
+	*x = GetAuthorRequest{}
+	mi := &file_blog_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorRequest) ProtoMessage() {}
+
+func (x *GetAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetAuthorRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+// Response message for retrieving an author
+// Output: The author
+type GetAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        *Author                `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"` // The retrieved author
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorResponse) Reset() {
+	*x = GetAuthorResponse{}
+	mi := &file_blog_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorResponse) ProtoMessage() {}
+
+func (x *GetAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorResponse.ProtoReflect.Descriptor instead.
+func (*GetAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetAuthorResponse) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *GetAuthorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetAuthorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for updating an author
+// Input: AuthorID of the author to update and the new details; empty fields are left unchanged
+// A new name is copied to all of the author's posts
+type UpdateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`          // Unique identifier for the author to update
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                  // New display name
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`                                // New contact email
+	ProfileName   string                 `protobuf:"bytes,4,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"` // New handle
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuthorRequest) Reset() {
+	*x = UpdateAuthorRequest{}
+	mi := &file_blog_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuthorRequest) ProtoMessage() {}
+
+func (x *UpdateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *UpdateAuthorRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *UpdateAuthorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateAuthorRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateAuthorRequest) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+// Response message for updating an author
+// Output: The updated author
+type UpdateAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        *Author                `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"` // The updated author
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuthorResponse) Reset() {
+	*x = UpdateAuthorResponse{}
+	mi := &file_blog_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuthorResponse) ProtoMessage() {}
+
+func (x *UpdateAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuthorResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *UpdateAuthorResponse) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *UpdateAuthorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateAuthorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for deleting an author
+// Input: AuthorID of the author to delete
+// Authors that still have posts, including posts in the trash, cannot be deleted
+type DeleteAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // Unique identifier for the author to delete
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAuthorRequest) Reset() {
+	*x = DeleteAuthorRequest{}
+	mi := &file_blog_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAuthorRequest) ProtoMessage() {}
+
+func (x *DeleteAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAuthorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DeleteAuthorRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+// Response message for deleting an author
+// Output: Success/Failure message
+type DeleteAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAuthorResponse) Reset() {
+	*x = DeleteAuthorResponse{}
+	mi := &file_blog_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAuthorResponse) ProtoMessage() {}
+
+func (x *DeleteAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAuthorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DeleteAuthorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteAuthorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for listing authors
+// Input: Page size and an opaque page token from a previous response
+// Authors are ordered by name, with AuthorID as a tiebreaker
+type ListAuthorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of authors to return (defaults to 50, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from a previous ListAuthorsResponse, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsRequest) Reset() {
+	*x = ListAuthorsRequest{}
+	mi := &file_blog_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsRequest) ProtoMessage() {}
+
+func (x *ListAuthorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuthorsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListAuthorsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuthorsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response message for listing authors
+// Output: One page of authors and the token for the next page
+type ListAuthorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authors       []*Author              `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty"`                                    // The authors in this page
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty when there are no more authors
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorsResponse) Reset() {
+	*x = ListAuthorsResponse{}
+	mi := &file_blog_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorsResponse) ProtoMessage() {}
+
+func (x *ListAuthorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuthorsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListAuthorsResponse) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *ListAuthorsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAuthorsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAuthorsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Entry of the tag registry. Posts carry tags by name, and a tag is
+// registered the first time a post uses it
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TagId         string                 `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`                 // Unique identifier for the tag
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                // Name of the tag as carried by posts
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`                                // Unique URL-friendly form of the name
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                  // Description of the tag
+	UsageCount    int32                  `protobuf:"varint,5,opt,name=usage_count,json=usageCount,proto3" json:"usage_count,omitempty"` // Number of posts outside the trash carrying the tag
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`     // When the tag was registered
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`     // When the tag was last changed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_blog_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Tag) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Tag) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Tag) GetUsageCount() int32 {
+	if x != nil {
+		return x.UsageCount
+	}
+	return 0
+}
+
+func (x *Tag) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Tag) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Request message for listing tags
+// Input: Page size and an opaque page token from a previous response
+// Tags are ordered by name, with TagID as a tiebreaker
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of tags to return (defaults to 50, capped at 1000)
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from a previous ListTagsResponse, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_blog_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListTagsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTagsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response message for listing tags
+// Output: One page of tags and the token for the next page
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`                                          // The tags in this page
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page, empty when there are no more tags
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_blog_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTagsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTagsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListTagsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for retrieving a tag
+// Input: TagID of the tag to retrieve
+type GetTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TagId         string                 `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"` // Unique identifier for the tag to retrieve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagRequest) Reset() {
+	*x = GetTagRequest{}
+	mi := &file_blog_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagRequest) ProtoMessage() {}
+
+func (x *GetTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagRequest.ProtoReflect.Descriptor instead.
+func (*GetTagRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetTagRequest) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+// Response message for retrieving a tag
+// Output: The tag
+type GetTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // The retrieved tag
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTagResponse) Reset() {
+	*x = GetTagResponse{}
+	mi := &file_blog_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTagResponse) ProtoMessage() {}
+
+func (x *GetTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTagResponse.ProtoReflect.Descriptor instead.
+func (*GetTagResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetTagResponse) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *GetTagResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetTagResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request message for renaming a tag
+// Input: TagID of the tag to rename, its new name and optionally a new description
+// Every post carrying the tag is rewritten to the new name
+type RenameTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TagId         string                 `protobuf:"bytes,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`      // Unique identifier for the tag to rename
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                     // New name; empty keeps the current name
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"` // New description, replaced only when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_blog_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBlogPostRequest) String() string {
+func (x *RenameTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBlogPostRequest) ProtoMessage() {}
+func (*RenameTagRequest) ProtoMessage() {}
 
-func (x *CreateBlogPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[1]
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,72 +5104,58 @@ func (x *CreateBlogPostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBlogPostRequest.ProtoReflect.Descriptor instead.
-func (*CreateBlogPostRequest) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *CreateBlogPostRequest) GetTitle() string {
+func (x *RenameTagRequest) GetTagId() string {
 	if x != nil {
-		return x.Title
+		return x.TagId
 	}
 	return ""
 }
 
-func (x *CreateBlogPostRequest) GetContent() string {
+func (x *RenameTagRequest) GetName() string {
 	if x != nil {
-		return x.Content
+		return x.Name
 	}
 	return ""
 }
 
-func (x *CreateBlogPostRequest) GetAuthor() string {
-	if x != nil {
-		return x.Author
+func (x *RenameTagRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
-func (x *CreateBlogPostRequest) GetPublicationDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.PublicationDate
-	}
-	return nil
-}
-
-func (x *CreateBlogPostRequest) GetTags() []string {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
-}
-
-// Response message for creating a new blog post
-// Output: The Post (PostID, Title, Content, Author, Publication Date, Tags)
-type CreateBlogPostResponse struct {
+// Response message for renaming a tag
+// Output: The renamed tag
+type RenameTagResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The created blog post
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // The renamed tag
 	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateBlogPostResponse) Reset() {
-	*x = CreateBlogPostResponse{}
-	mi := &file_blog_proto_msgTypes[2]
+func (x *RenameTagResponse) Reset() {
+	*x = RenameTagResponse{}
+	mi := &file_blog_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBlogPostResponse) String() string {
+func (x *RenameTagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBlogPostResponse) ProtoMessage() {}
+func (*RenameTagResponse) ProtoMessage() {}
 
-func (x *CreateBlogPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[2]
+func (x *RenameTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,56 +5166,58 @@ func (x *CreateBlogPostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBlogPostResponse.ProtoReflect.Descriptor instead.
-func (*CreateBlogPostResponse) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RenameTagResponse.ProtoReflect.Descriptor instead.
+func (*RenameTagResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *CreateBlogPostResponse) GetPost() *BlogPost {
+func (x *RenameTagResponse) GetTag() *Tag {
 	if x != nil {
-		return x.Post
+		return x.Tag
 	}
 	return nil
 }
 
-func (x *CreateBlogPostResponse) GetSuccess() bool {
+func (x *RenameTagResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *CreateBlogPostResponse) GetMessage() string {
+func (x *RenameTagResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// Request message for retrieving a blog post
-// Input: PostID of the post to retrieve
-type GetBlogPostRequest struct {
+// Request message for merging two tags
+// Input: TagIDs of the tag to merge away and of the tag to keep
+// Every post carrying the source tag is rewritten to carry the target tag instead
+type MergeTagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to retrieve
+	SourceTagId   string                 `protobuf:"bytes,1,opt,name=source_tag_id,json=sourceTagId,proto3" json:"source_tag_id,omitempty"` // Unique identifier for the tag to remove
+	TargetTagId   string                 `protobuf:"bytes,2,opt,name=target_tag_id,json=targetTagId,proto3" json:"target_tag_id,omitempty"` // Unique identifier for the tag to keep
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBlogPostRequest) Reset() {
-	*x = GetBlogPostRequest{}
-	mi := &file_blog_proto_msgTypes[3]
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_blog_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBlogPostRequest) String() string {
+func (x *MergeTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBlogPostRequest) ProtoMessage() {}
+func (*MergeTagsRequest) ProtoMessage() {}
 
-func (x *GetBlogPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[3]
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,44 +5228,51 @@ func (x *GetBlogPostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBlogPostRequest.ProtoReflect.Descriptor instead.
-func (*GetBlogPostRequest) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *GetBlogPostRequest) GetPostId() string {
+func (x *MergeTagsRequest) GetSourceTagId() string {
 	if x != nil {
-		return x.PostId
+		return x.SourceTagId
 	}
 	return ""
 }
 
-// Response message for retrieving a blog post
-// Output: The Post (PostID, Title, Content, Author, Publication Date, Tags)
-type GetBlogPostResponse struct {
+func (x *MergeTagsRequest) GetTargetTagId() string {
+	if x != nil {
+		return x.TargetTagId
+	}
+	return ""
+}
+
+// Response message for merging two tags
+// Output: The target tag
+type MergeTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The retrieved blog post
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"` // The tag the source was merged into
 	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBlogPostResponse) Reset() {
-	*x = GetBlogPostResponse{}
-	mi := &file_blog_proto_msgTypes[4]
+func (x *MergeTagsResponse) Reset() {
+	*x = MergeTagsResponse{}
+	mi := &file_blog_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBlogPostResponse) String() string {
+func (x *MergeTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBlogPostResponse) ProtoMessage() {}
+func (*MergeTagsResponse) ProtoMessage() {}
 
-func (x *GetBlogPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[4]
+func (x *MergeTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,59 +5283,64 @@ func (x *GetBlogPostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBlogPostResponse.ProtoReflect.Descriptor instead.
-func (*GetBlogPostResponse) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use MergeTagsResponse.ProtoReflect.Descriptor instead.
+func (*MergeTagsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetBlogPostResponse) GetPost() *BlogPost {
+func (x *MergeTagsResponse) GetTag() *Tag {
 	if x != nil {
-		return x.Post
+		return x.Tag
 	}
 	return nil
 }
 
-func (x *GetBlogPostResponse) GetSuccess() bool {
+func (x *MergeTagsResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *GetBlogPostResponse) GetMessage() string {
+func (x *MergeTagsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// Request message for updating a blog post
-// Input: PostID of the post to update and new details (Title, Content, Author, Tags)
-type UpdateBlogPostRequest struct {
+// Request message for suggesting tags
+// Input: A prefix of the tag being typed and, optionally, the draft post being edited
+// Without a draft, the tags starting with the prefix are suggested, most used first.
+// With a title, content or tags, tags found on similar published posts and on
+// posts carrying the draft's tags are suggested, best match first, limited to
+// those starting with the prefix. The draft's own tags are never suggested.
+type SuggestTagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to update
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                 // New title of the blog post
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`             // New content of the blog post
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                   // New tags associated with the blog post
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`   // Start of the tag name, empty for any tag
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`     // Title of the draft post
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"` // Content of the draft post
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`       // Tags already on the draft post
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`    // Maximum number of suggestions to return (defaults to 10, capped at 100)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateBlogPostRequest) Reset() {
-	*x = UpdateBlogPostRequest{}
-	mi := &file_blog_proto_msgTypes[5]
+func (x *SuggestTagsRequest) Reset() {
+	*x = SuggestTagsRequest{}
+	mi := &file_blog_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBlogPostRequest) String() string {
+func (x *SuggestTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBlogPostRequest) ProtoMessage() {}
+func (*SuggestTagsRequest) ProtoMessage() {}
 
-func (x *UpdateBlogPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[5]
+func (x *SuggestTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,65 +5351,70 @@ func (x *UpdateBlogPostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBlogPostRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBlogPostRequest) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SuggestTagsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestTagsRequest) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *UpdateBlogPostRequest) GetPostId() string {
+func (x *SuggestTagsRequest) GetPrefix() string {
 	if x != nil {
-		return x.PostId
+		return x.Prefix
 	}
 	return ""
 }
 
-func (x *UpdateBlogPostRequest) GetTitle() string {
+func (x *SuggestTagsRequest) GetTitle() string {
 	if x != nil {
 		return x.Title
 	}
 	return ""
 }
 
-func (x *UpdateBlogPostRequest) GetContent() string {
+func (x *SuggestTagsRequest) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
 	return ""
 }
 
-func (x *UpdateBlogPostRequest) GetTags() []string {
+func (x *SuggestTagsRequest) GetTags() []string {
 	if x != nil {
 		return x.Tags
 	}
 	return nil
 }
 
-// Response message for updating a blog post
-// Output: Post details (PostID, Title, Content, Author, Publication Date, Tags)
-type UpdateBlogPostResponse struct {
+func (x *SuggestTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A suggested tag
+type TagSuggestion struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Post          *BlogPost              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"` // The updated blog post
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`       // The suggested tag
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // The usage count without a draft, how well the tag fits the draft otherwise
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateBlogPostResponse) Reset() {
-	*x = UpdateBlogPostResponse{}
-	mi := &file_blog_proto_msgTypes[6]
+func (x *TagSuggestion) Reset() {
+	*x = TagSuggestion{}
+	mi := &file_blog_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBlogPostResponse) String() string {
+func (x *TagSuggestion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBlogPostResponse) ProtoMessage() {}
+func (*TagSuggestion) ProtoMessage() {}
 
-func (x *UpdateBlogPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[6]
+func (x *TagSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,56 +5425,51 @@ func (x *UpdateBlogPostResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBlogPostResponse.ProtoReflect.Descriptor instead.
-func (*UpdateBlogPostResponse) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use TagSuggestion.ProtoReflect.Descriptor instead.
+func (*TagSuggestion) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *UpdateBlogPostResponse) GetPost() *BlogPost {
+func (x *TagSuggestion) GetTag() *Tag {
 	if x != nil {
-		return x.Post
+		return x.Tag
 	}
 	return nil
 }
 
-func (x *UpdateBlogPostResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *UpdateBlogPostResponse) GetMessage() string {
+func (x *TagSuggestion) GetScore() float64 {
 	if x != nil {
-		return x.Message
+		return x.Score
 	}
-	return ""
+	return 0
 }
 
-// Request message for deleting a blog post
-// Input: PostID of the post to delete
-type DeleteBlogPostRequest struct {
+// Response message for suggesting tags
+// Output: The suggestions, best first
+type SuggestTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"` // Unique identifier for the post to delete
+	Suggestions   []*TagSuggestion       `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"` // The suggested tags
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBlogPostRequest) Reset() {
-	*x = DeleteBlogPostRequest{}
-	mi := &file_blog_proto_msgTypes[7]
+func (x *SuggestTagsResponse) Reset() {
+	*x = SuggestTagsResponse{}
+	mi := &file_blog_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBlogPostRequest) String() string {
+func (x *SuggestTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBlogPostRequest) ProtoMessage() {}
+func (*SuggestTagsResponse) ProtoMessage() {}
 
-func (x *DeleteBlogPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[7]
+func (x *SuggestTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blog_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,66 +5480,26 @@ func (x *DeleteBlogPostRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBlogPostRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBlogPostRequest) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *DeleteBlogPostRequest) GetPostId() string {
-	if x != nil {
-		return x.PostId
-	}
-	return ""
-}
-
-// Response message for deleting a blog post
-// Output: Success/Failure message
-type DeleteBlogPostResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteBlogPostResponse) Reset() {
-	*x = DeleteBlogPostResponse{}
-	mi := &file_blog_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteBlogPostResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use SuggestTagsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestTagsResponse) Descriptor() ([]byte, []int) {
+	return file_blog_proto_rawDescGZIP(), []int{79}
 }
 
-func (*DeleteBlogPostResponse) ProtoMessage() {}
-
-func (x *DeleteBlogPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_blog_proto_msgTypes[8]
+func (x *SuggestTagsResponse) GetSuggestions() []*TagSuggestion {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Suggestions
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteBlogPostResponse.ProtoReflect.Descriptor instead.
-func (*DeleteBlogPostResponse) Descriptor() ([]byte, []int) {
-	return file_blog_proto_rawDescGZIP(), []int{8}
+	return nil
 }
 
-func (x *DeleteBlogPostResponse) GetSuccess() bool {
+func (x *SuggestTagsResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *DeleteBlogPostResponse) GetMessage() string {
+func (x *SuggestTagsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
@@ -600,7 +5511,7 @@ var File_blog_proto protoreflect.FileDescriptor
 const file_blog_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"blog.proto\x12\ablog.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x02\n" +
+	"blog.proto\x12\ablog.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x03\n" +
 	"\bBlogPost\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -609,43 +5520,433 @@ const file_blog_proto_rawDesc = "" +
 	"\x10publication_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fpublicationDate\x129\n" +
 	"\n" +
 	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
-	"\x04tags\x18\a \x03(\tR\x04tags\"\xd4\x01\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12+\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x13.blog.v1.PostStatusR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\v \x01(\tR\bauthorId\"\xda\x01\n" +
+	"\fPostRevision\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06editor\x18\x06 \x01(\tR\x06editor\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc1\x02\n" +
 	"\x15CreateBlogPostRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
-	"\x06author\x18\x03 \x01(\tR\x06author\x12J\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1a\n" +
+	"\x06author\x18\x03 \x01(\tB\x02\x18\x01R\x06author\x12J\n" +
 	"\x10publication_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0fpublicationDate\x88\x01\x01\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tagsB\x13\n" +
-	"\x11_publication_date\"s\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12+\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x13.blog.v1.PostStatusR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\a \x01(\tR\bauthorId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tR\trequestIdB\x13\n" +
+	"\x11_publication_date\"\x8f\x01\n" +
 	"\x16CreateBlogPostResponse\x12%\n" +
 	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"-\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x04 \x01(\bR\breplayed\"u\n" +
+	"\x16ImportBlogPostsRequest\x122\n" +
+	"\x04post\x18\x01 \x01(\v2\x1e.blog.v1.CreateBlogPostRequestR\x04post\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.blog.v1.ImportModeR\x04mode\"U\n" +
+	"\vImportError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xe4\x01\n" +
+	"\x17ImportBlogPostsResponse\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x05R\breceived\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x19\n" +
+	"\bpost_ids\x18\x04 \x03(\tR\apostIds\x12,\n" +
+	"\x06errors\x18\x05 \x03(\v2\x14.blog.v1.ImportErrorR\x06errors\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"-\n" +
 	"\x12GetBlogPostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\"p\n" +
 	"\x13GetBlogPostResponse\x12%\n" +
 	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"t\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"5\n" +
+	"\x18BatchGetBlogPostsRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\"\x8b\x01\n" +
+	"\x17BatchGetBlogPostsResult\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12%\n" +
+	"\x04post\x18\x02 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xbb\x01\n" +
+	"\x19BatchGetBlogPostsResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .blog.v1.BatchGetBlogPostsResultR\aresults\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\x05R\x05found\x12\x18\n" +
+	"\amissing\x18\x03 \x01(\x05R\amissing\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xf4\x01\n" +
 	"\x15UpdateBlogPostRequest\x12\x17\n" +
 	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"s\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\x03R\x0fexpectedVersion\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x16\n" +
+	"\x06editor\x18\a \x01(\tR\x06editor\"s\n" +
 	"\x16UpdateBlogPostResponse\x12%\n" +
 	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"0\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"y\n" +
 	"\x15DeleteBlogPostRequest\x12\x17\n" +
-	"\apost_id\x18\x01 \x01(\tR\x06postId\"L\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\x12\x1c\n" +
+	"\tpermanent\x18\x03 \x01(\bR\tpermanent\"L\n" +
 	"\x16DeleteBlogPostResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xd0\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"V\n" +
+	"\x1bBatchDeleteBlogPostsRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\x12\x1c\n" +
+	"\tpermanent\x18\x02 \x01(\bR\tpermanent\"\x81\x01\n" +
+	"\x1aBatchDeleteBlogPostsResult\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xc5\x01\n" +
+	"\x1cBatchDeleteBlogPostsResponse\x12=\n" +
+	"\aresults\x18\x01 \x03(\v2#.blog.v1.BatchDeleteBlogPostsResultR\aresults\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\x05R\adeleted\x12\x18\n" +
+	"\amissing\x18\x03 \x01(\x05R\amissing\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"1\n" +
+	"\x16RestoreBlogPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"t\n" +
+	"\x17RestoreBlogPostResponse\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"/\n" +
+	"\x14PurgeBlogPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"K\n" +
+	"\x15PurgeBlogPostResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xc3\x01\n" +
+	"\x16PublishBlogPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12>\n" +
+	"\n" +
+	"publish_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tpublishAt\x88\x01\x01\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\x12\x16\n" +
+	"\x06editor\x18\x04 \x01(\tR\x06editorB\r\n" +
+	"\v_publish_at\"t\n" +
+	"\x17PublishBlogPostResponse\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"v\n" +
+	"\x18UnpublishBlogPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\x12\x16\n" +
+	"\x06editor\x18\x03 \x01(\tR\x06editor\"v\n" +
+	"\x19UnpublishBlogPostResponse\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"t\n" +
+	"\x16ArchiveBlogPostRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\x12\x16\n" +
+	"\x06editor\x18\x03 \x01(\tR\x06editor\"t\n" +
+	"\x17ArchiveBlogPostResponse\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xa8\x01\n" +
+	"\x14ListBlogPostsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12!\n" +
+	"\fshow_deleted\x18\x05 \x01(\bR\vshowDeleted\"\x9c\x01\n" +
+	"\x15ListBlogPostsResponse\x12'\n" +
+	"\x05posts\x18\x01 \x03(\v2\x11.blog.v1.BlogPostR\x05posts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"w\n" +
+	"\x1cListBlogPostsByAuthorRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xa4\x01\n" +
+	"\x1dListBlogPostsByAuthorResponse\x12'\n" +
+	"\x05posts\x18\x01 \x03(\v2\x11.blog.v1.BlogPostR\x05posts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"i\n" +
+	"\x19ListBlogPostsByTagRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xa1\x01\n" +
+	"\x1aListBlogPostsByTagResponse\x12'\n" +
+	"\x05posts\x18\x01 \x03(\v2\x11.blog.v1.BlogPostR\x05posts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"j\n" +
+	"\x16SearchBlogPostsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"E\n" +
+	"\rHighlightSpan\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12 \n" +
+	"\vhighlighted\x18\x02 \x01(\bR\vhighlighted\"\xab\x01\n" +
+	"\fSearchResult\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12,\n" +
+	"\x05title\x18\x03 \x03(\v2\x16.blog.v1.HighlightSpanR\x05title\x120\n" +
+	"\asnippet\x18\x04 \x03(\v2\x16.blog.v1.HighlightSpanR\asnippet\"\xa6\x01\n" +
+	"\x17SearchBlogPostsResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.blog.v1.SearchResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"G\n" +
+	"\x16GetRelatedPostsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"J\n" +
+	"\vRelatedPost\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"y\n" +
+	"\x17GetRelatedPostsResponse\x12*\n" +
+	"\x05posts\x18\x01 \x03(\v2\x14.blog.v1.RelatedPostR\x05posts\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"S\n" +
+	"\x16ExportBlogPostsRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12!\n" +
+	"\fshow_deleted\x18\x02 \x01(\bR\vshowDeleted\"\\\n" +
+	"\x17ExportBlogPostsResponse\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\"m\n" +
+	"\x15WatchBlogPostsRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12%\n" +
+	"\x0esince_sequence\x18\x03 \x01(\x04R\rsinceSequence\"\xee\x01\n" +
+	"\tPostEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12&\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x12.blog.v1.EventTypeR\x04type\x12\x17\n" +
+	"\apost_id\x18\x03 \x01(\tR\x06postId\x12%\n" +
+	"\x04post\x18\x04 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12-\n" +
+	"\bprevious\x18\x05 \x01(\v2\x11.blog.v1.BlogPostR\bprevious\x12.\n" +
+	"\x04time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"3\n" +
+	"\x18ListPostRevisionsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"\x84\x01\n" +
+	"\x19ListPostRevisionsResponse\x123\n" +
+	"\trevisions\x18\x01 \x03(\v2\x15.blog.v1.PostRevisionR\trevisions\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"M\n" +
+	"\x16GetPostRevisionRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\"\x80\x01\n" +
+	"\x17GetPostRevisionResponse\x121\n" +
+	"\brevision\x18\x01 \x01(\v2\x15.blog.v1.PostRevisionR\brevision\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x94\x01\n" +
+	"\x1aRestorePostRevisionRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\x12\x16\n" +
+	"\x06editor\x18\x04 \x01(\tR\x06editor\"x\n" +
+	"\x1bRestorePostRevisionResponse\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.blog.v1.BlogPostR\x04post\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"?\n" +
+	"\bDiffSpan\x12\x1f\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x0f.blog.v1.DiffOpR\x02op\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"g\n" +
+	"\bTextDiff\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\x12\x18\n" +
+	"\aunified\x18\x02 \x01(\tR\aunified\x12'\n" +
+	"\x05spans\x18\x03 \x03(\v2\x11.blog.v1.DiffSpanR\x05spans\"T\n" +
+	"\bTagsDiff\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\x12\x14\n" +
+	"\x05added\x18\x02 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x03 \x03(\tR\aremoved\"\xe2\x01\n" +
+	"\x18DiffPostRevisionsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12#\n" +
+	"\rfrom_revision\x18\x02 \x01(\x03R\ffromRevision\x12\x1f\n" +
+	"\vto_revision\x18\x03 \x01(\x03R\n" +
+	"toRevision\x12+\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x13.blog.v1.DiffFormatR\x06format\x12(\n" +
+	"\rcontext_lines\x18\x05 \x01(\x05H\x00R\fcontextLines\x88\x01\x01B\x10\n" +
+	"\x0e_context_lines\"\x92\x02\n" +
+	"\x19DiffPostRevisionsResponse\x12#\n" +
+	"\rfrom_revision\x18\x01 \x01(\x03R\ffromRevision\x12\x1f\n" +
+	"\vto_revision\x18\x02 \x01(\x03R\n" +
+	"toRevision\x12'\n" +
+	"\x05title\x18\x03 \x01(\v2\x11.blog.v1.TextDiffR\x05title\x12+\n" +
+	"\acontent\x18\x04 \x01(\v2\x11.blog.v1.TextDiffR\acontent\x12%\n" +
+	"\x04tags\x18\x05 \x01(\v2\x11.blog.v1.TagsDiffR\x04tags\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"\xe8\x01\n" +
+	"\x06Author\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12!\n" +
+	"\fprofile_name\x18\x04 \x01(\tR\vprofileName\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"b\n" +
+	"\x13CreateAuthorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12!\n" +
+	"\fprofile_name\x18\x03 \x01(\tR\vprofileName\"s\n" +
+	"\x14CreateAuthorResponse\x12'\n" +
+	"\x06author\x18\x01 \x01(\v2\x0f.blog.v1.AuthorR\x06author\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"/\n" +
+	"\x10GetAuthorRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\"p\n" +
+	"\x11GetAuthorResponse\x12'\n" +
+	"\x06author\x18\x01 \x01(\v2\x0f.blog.v1.AuthorR\x06author\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x7f\n" +
+	"\x13UpdateAuthorRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12!\n" +
+	"\fprofile_name\x18\x04 \x01(\tR\vprofileName\"s\n" +
+	"\x14UpdateAuthorResponse\x12'\n" +
+	"\x06author\x18\x01 \x01(\v2\x0f.blog.v1.AuthorR\x06author\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"2\n" +
+	"\x13DeleteAuthorRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\"J\n" +
+	"\x14DeleteAuthorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"P\n" +
+	"\x12ListAuthorsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x9c\x01\n" +
+	"\x13ListAuthorsResponse\x12)\n" +
+	"\aauthors\x18\x01 \x03(\v2\x0f.blog.v1.AuthorR\aauthors\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xfd\x01\n" +
+	"\x03Tag\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vusage_count\x18\x05 \x01(\x05R\n" +
+	"usageCount\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"M\n" +
+	"\x0fListTagsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x90\x01\n" +
+	"\x10ListTagsResponse\x12 \n" +
+	"\x04tags\x18\x01 \x03(\v2\f.blog.v1.TagR\x04tags\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"&\n" +
+	"\rGetTagRequest\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\"d\n" +
+	"\x0eGetTagResponse\x12\x1e\n" +
+	"\x03tag\x18\x01 \x01(\v2\f.blog.v1.TagR\x03tag\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"t\n" +
+	"\x10RenameTagRequest\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\tR\x05tagId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
+	"\f_description\"g\n" +
+	"\x11RenameTagResponse\x12\x1e\n" +
+	"\x03tag\x18\x01 \x01(\v2\f.blog.v1.TagR\x03tag\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"Z\n" +
+	"\x10MergeTagsRequest\x12\"\n" +
+	"\rsource_tag_id\x18\x01 \x01(\tR\vsourceTagId\x12\"\n" +
+	"\rtarget_tag_id\x18\x02 \x01(\tR\vtargetTagId\"g\n" +
+	"\x11MergeTagsResponse\x12\x1e\n" +
+	"\x03tag\x18\x01 \x01(\v2\f.blog.v1.TagR\x03tag\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x86\x01\n" +
+	"\x12SuggestTagsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"E\n" +
+	"\rTagSuggestion\x12\x1e\n" +
+	"\x03tag\x18\x01 \x01(\v2\f.blog.v1.TagR\x03tag\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x83\x01\n" +
+	"\x13SuggestTagsResponse\x128\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x16.blog.v1.TagSuggestionR\vsuggestions\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage*\x90\x01\n" +
+	"\n" +
+	"PostStatus\x12\x1b\n" +
+	"\x17POST_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11POST_STATUS_DRAFT\x10\x01\x12\x19\n" +
+	"\x15POST_STATUS_SCHEDULED\x10\x02\x12\x19\n" +
+	"\x15POST_STATUS_PUBLISHED\x10\x03\x12\x18\n" +
+	"\x14POST_STATUS_ARCHIVED\x10\x04*I\n" +
+	"\n" +
+	"ImportMode\x12\x1b\n" +
+	"\x17IMPORT_MODE_BEST_EFFORT\x10\x00\x12\x1e\n" +
+	"\x1aIMPORT_MODE_ALL_OR_NOTHING\x10\x01*o\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12EVENT_TYPE_CREATED\x10\x01\x12\x16\n" +
+	"\x12EVENT_TYPE_UPDATED\x10\x02\x12\x16\n" +
+	"\x12EVENT_TYPE_DELETED\x10\x03*<\n" +
+	"\n" +
+	"DiffFormat\x12\x17\n" +
+	"\x13DIFF_FORMAT_UNIFIED\x10\x00\x12\x15\n" +
+	"\x11DIFF_FORMAT_WORDS\x10\x01*C\n" +
+	"\x06DiffOp\x12\x11\n" +
+	"\rDIFF_OP_EQUAL\x10\x00\x12\x12\n" +
+	"\x0eDIFF_OP_INSERT\x10\x01\x12\x12\n" +
+	"\x0eDIFF_OP_DELETE\x10\x022\xea\x0f\n" +
 	"\vBlogService\x12Q\n" +
-	"\x0eCreateBlogPost\x12\x1e.blog.v1.CreateBlogPostRequest\x1a\x1f.blog.v1.CreateBlogPostResponse\x12H\n" +
-	"\vGetBlogPost\x12\x1b.blog.v1.GetBlogPostRequest\x1a\x1c.blog.v1.GetBlogPostResponse\x12Q\n" +
+	"\x0eCreateBlogPost\x12\x1e.blog.v1.CreateBlogPostRequest\x1a\x1f.blog.v1.CreateBlogPostResponse\x12V\n" +
+	"\x0fImportBlogPosts\x12\x1f.blog.v1.ImportBlogPostsRequest\x1a .blog.v1.ImportBlogPostsResponse(\x01\x12H\n" +
+	"\vGetBlogPost\x12\x1b.blog.v1.GetBlogPostRequest\x1a\x1c.blog.v1.GetBlogPostResponse\x12Z\n" +
+	"\x11BatchGetBlogPosts\x12!.blog.v1.BatchGetBlogPostsRequest\x1a\".blog.v1.BatchGetBlogPostsResponse\x12Q\n" +
 	"\x0eUpdateBlogPost\x12\x1e.blog.v1.UpdateBlogPostRequest\x1a\x1f.blog.v1.UpdateBlogPostResponse\x12Q\n" +
-	"\x0eDeleteBlogPost\x12\x1e.blog.v1.DeleteBlogPostRequest\x1a\x1f.blog.v1.DeleteBlogPostResponseB*Z(github.com/pandae7/go-blogger/proto/blogb\x06proto3"
+	"\x0eDeleteBlogPost\x12\x1e.blog.v1.DeleteBlogPostRequest\x1a\x1f.blog.v1.DeleteBlogPostResponse\x12c\n" +
+	"\x14BatchDeleteBlogPosts\x12$.blog.v1.BatchDeleteBlogPostsRequest\x1a%.blog.v1.BatchDeleteBlogPostsResponse\x12T\n" +
+	"\x0fRestoreBlogPost\x12\x1f.blog.v1.RestoreBlogPostRequest\x1a .blog.v1.RestoreBlogPostResponse\x12N\n" +
+	"\rPurgeBlogPost\x12\x1d.blog.v1.PurgeBlogPostRequest\x1a\x1e.blog.v1.PurgeBlogPostResponse\x12T\n" +
+	"\x0fPublishBlogPost\x12\x1f.blog.v1.PublishBlogPostRequest\x1a .blog.v1.PublishBlogPostResponse\x12Z\n" +
+	"\x11UnpublishBlogPost\x12!.blog.v1.UnpublishBlogPostRequest\x1a\".blog.v1.UnpublishBlogPostResponse\x12T\n" +
+	"\x0fArchiveBlogPost\x12\x1f.blog.v1.ArchiveBlogPostRequest\x1a .blog.v1.ArchiveBlogPostResponse\x12N\n" +
+	"\rListBlogPosts\x12\x1d.blog.v1.ListBlogPostsRequest\x1a\x1e.blog.v1.ListBlogPostsResponse\x12f\n" +
+	"\x15ListBlogPostsByAuthor\x12%.blog.v1.ListBlogPostsByAuthorRequest\x1a&.blog.v1.ListBlogPostsByAuthorResponse\x12]\n" +
+	"\x12ListBlogPostsByTag\x12\".blog.v1.ListBlogPostsByTagRequest\x1a#.blog.v1.ListBlogPostsByTagResponse\x12T\n" +
+	"\x0fSearchBlogPosts\x12\x1f.blog.v1.SearchBlogPostsRequest\x1a .blog.v1.SearchBlogPostsResponse\x12T\n" +
+	"\x0fGetRelatedPosts\x12\x1f.blog.v1.GetRelatedPostsRequest\x1a .blog.v1.GetRelatedPostsResponse\x12V\n" +
+	"\x0fExportBlogPosts\x12\x1f.blog.v1.ExportBlogPostsRequest\x1a .blog.v1.ExportBlogPostsResponse0\x01\x12F\n" +
+	"\x0eWatchBlogPosts\x12\x1e.blog.v1.WatchBlogPostsRequest\x1a\x12.blog.v1.PostEvent0\x01\x12Z\n" +
+	"\x11ListPostRevisions\x12!.blog.v1.ListPostRevisionsRequest\x1a\".blog.v1.ListPostRevisionsResponse\x12T\n" +
+	"\x0fGetPostRevision\x12\x1f.blog.v1.GetPostRevisionRequest\x1a .blog.v1.GetPostRevisionResponse\x12`\n" +
+	"\x13RestorePostRevision\x12#.blog.v1.RestorePostRevisionRequest\x1a$.blog.v1.RestorePostRevisionResponse\x12Z\n" +
+	"\x11DiffPostRevisions\x12!.blog.v1.DiffPostRevisionsRequest\x1a\".blog.v1.DiffPostRevisionsResponse2\x84\x03\n" +
+	"\rAuthorService\x12K\n" +
+	"\fCreateAuthor\x12\x1c.blog.v1.CreateAuthorRequest\x1a\x1d.blog.v1.CreateAuthorResponse\x12B\n" +
+	"\tGetAuthor\x12\x19.blog.v1.GetAuthorRequest\x1a\x1a.blog.v1.GetAuthorResponse\x12K\n" +
+	"\fUpdateAuthor\x12\x1c.blog.v1.UpdateAuthorRequest\x1a\x1d.blog.v1.UpdateAuthorResponse\x12K\n" +
+	"\fDeleteAuthor\x12\x1c.blog.v1.DeleteAuthorRequest\x1a\x1d.blog.v1.DeleteAuthorResponse\x12H\n" +
+	"\vListAuthors\x12\x1b.blog.v1.ListAuthorsRequest\x1a\x1c.blog.v1.ListAuthorsResponse2\xda\x02\n" +
+	"\n" +
+	"TagService\x12?\n" +
+	"\bListTags\x12\x18.blog.v1.ListTagsRequest\x1a\x19.blog.v1.ListTagsResponse\x129\n" +
+	"\x06GetTag\x12\x16.blog.v1.GetTagRequest\x1a\x17.blog.v1.GetTagResponse\x12B\n" +
+	"\tRenameTag\x12\x19.blog.v1.RenameTagRequest\x1a\x1a.blog.v1.RenameTagResponse\x12B\n" +
+	"\tMergeTags\x12\x19.blog.v1.MergeTagsRequest\x1a\x1a.blog.v1.MergeTagsResponse\x12H\n" +
+	"\vSuggestTags\x12\x1b.blog.v1.SuggestTagsRequest\x1a\x1c.blog.v1.SuggestTagsResponseB*Z(github.com/pandae7/go-blogger/proto/blogb\x06proto3"
 
 var (
 	file_blog_proto_rawDescOnce sync.Once
@@ -659,39 +5960,228 @@ func file_blog_proto_rawDescGZIP() []byte {
 	return file_blog_proto_rawDescData
 }
 
-var file_blog_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_blog_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_blog_proto_msgTypes = make([]protoimpl.MessageInfo, 80)
 var file_blog_proto_goTypes = []any{
-	(*BlogPost)(nil),               // 0: blog.v1.BlogPost
-	(*CreateBlogPostRequest)(nil),  // 1: blog.v1.CreateBlogPostRequest
-	(*CreateBlogPostResponse)(nil), // 2: blog.v1.CreateBlogPostResponse
-	(*GetBlogPostRequest)(nil),     // 3: blog.v1.GetBlogPostRequest
-	(*GetBlogPostResponse)(nil),    // 4: blog.v1.GetBlogPostResponse
-	(*UpdateBlogPostRequest)(nil),  // 5: blog.v1.UpdateBlogPostRequest
-	(*UpdateBlogPostResponse)(nil), // 6: blog.v1.UpdateBlogPostResponse
-	(*DeleteBlogPostRequest)(nil),  // 7: blog.v1.DeleteBlogPostRequest
-	(*DeleteBlogPostResponse)(nil), // 8: blog.v1.DeleteBlogPostResponse
-	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(PostStatus)(0),                       // 0: blog.v1.PostStatus
+	(ImportMode)(0),                       // 1: blog.v1.ImportMode
+	(EventType)(0),                        // 2: blog.v1.EventType
+	(DiffFormat)(0),                       // 3: blog.v1.DiffFormat
+	(DiffOp)(0),                           // 4: blog.v1.DiffOp
+	(*BlogPost)(nil),                      // 5: blog.v1.BlogPost
+	(*PostRevision)(nil),                  // 6: blog.v1.PostRevision
+	(*CreateBlogPostRequest)(nil),         // 7: blog.v1.CreateBlogPostRequest
+	(*CreateBlogPostResponse)(nil),        // 8: blog.v1.CreateBlogPostResponse
+	(*ImportBlogPostsRequest)(nil),        // 9: blog.v1.ImportBlogPostsRequest
+	(*ImportError)(nil),                   // 10: blog.v1.ImportError
+	(*ImportBlogPostsResponse)(nil),       // 11: blog.v1.ImportBlogPostsResponse
+	(*GetBlogPostRequest)(nil),            // 12: blog.v1.GetBlogPostRequest
+	(*GetBlogPostResponse)(nil),           // 13: blog.v1.GetBlogPostResponse
+	(*BatchGetBlogPostsRequest)(nil),      // 14: blog.v1.BatchGetBlogPostsRequest
+	(*BatchGetBlogPostsResult)(nil),       // 15: blog.v1.BatchGetBlogPostsResult
+	(*BatchGetBlogPostsResponse)(nil),     // 16: blog.v1.BatchGetBlogPostsResponse
+	(*UpdateBlogPostRequest)(nil),         // 17: blog.v1.UpdateBlogPostRequest
+	(*UpdateBlogPostResponse)(nil),        // 18: blog.v1.UpdateBlogPostResponse
+	(*DeleteBlogPostRequest)(nil),         // 19: blog.v1.DeleteBlogPostRequest
+	(*DeleteBlogPostResponse)(nil),        // 20: blog.v1.DeleteBlogPostResponse
+	(*BatchDeleteBlogPostsRequest)(nil),   // 21: blog.v1.BatchDeleteBlogPostsRequest
+	(*BatchDeleteBlogPostsResult)(nil),    // 22: blog.v1.BatchDeleteBlogPostsResult
+	(*BatchDeleteBlogPostsResponse)(nil),  // 23: blog.v1.BatchDeleteBlogPostsResponse
+	(*RestoreBlogPostRequest)(nil),        // 24: blog.v1.RestoreBlogPostRequest
+	(*RestoreBlogPostResponse)(nil),       // 25: blog.v1.RestoreBlogPostResponse
+	(*PurgeBlogPostRequest)(nil),          // 26: blog.v1.PurgeBlogPostRequest
+	(*PurgeBlogPostResponse)(nil),         // 27: blog.v1.PurgeBlogPostResponse
+	(*PublishBlogPostRequest)(nil),        // 28: blog.v1.PublishBlogPostRequest
+	(*PublishBlogPostResponse)(nil),       // 29: blog.v1.PublishBlogPostResponse
+	(*UnpublishBlogPostRequest)(nil),      // 30: blog.v1.UnpublishBlogPostRequest
+	(*UnpublishBlogPostResponse)(nil),     // 31: blog.v1.UnpublishBlogPostResponse
+	(*ArchiveBlogPostRequest)(nil),        // 32: blog.v1.ArchiveBlogPostRequest
+	(*ArchiveBlogPostResponse)(nil),       // 33: blog.v1.ArchiveBlogPostResponse
+	(*ListBlogPostsRequest)(nil),          // 34: blog.v1.ListBlogPostsRequest
+	(*ListBlogPostsResponse)(nil),         // 35: blog.v1.ListBlogPostsResponse
+	(*ListBlogPostsByAuthorRequest)(nil),  // 36: blog.v1.ListBlogPostsByAuthorRequest
+	(*ListBlogPostsByAuthorResponse)(nil), // 37: blog.v1.ListBlogPostsByAuthorResponse
+	(*ListBlogPostsByTagRequest)(nil),     // 38: blog.v1.ListBlogPostsByTagRequest
+	(*ListBlogPostsByTagResponse)(nil),    // 39: blog.v1.ListBlogPostsByTagResponse
+	(*SearchBlogPostsRequest)(nil),        // 40: blog.v1.SearchBlogPostsRequest
+	(*HighlightSpan)(nil),                 // 41: blog.v1.HighlightSpan
+	(*SearchResult)(nil),                  // 42: blog.v1.SearchResult
+	(*SearchBlogPostsResponse)(nil),       // 43: blog.v1.SearchBlogPostsResponse
+	(*GetRelatedPostsRequest)(nil),        // 44: blog.v1.GetRelatedPostsRequest
+	(*RelatedPost)(nil),                   // 45: blog.v1.RelatedPost
+	(*GetRelatedPostsResponse)(nil),       // 46: blog.v1.GetRelatedPostsResponse
+	(*ExportBlogPostsRequest)(nil),        // 47: blog.v1.ExportBlogPostsRequest
+	(*ExportBlogPostsResponse)(nil),       // 48: blog.v1.ExportBlogPostsResponse
+	(*WatchBlogPostsRequest)(nil),         // 49: blog.v1.WatchBlogPostsRequest
+	(*PostEvent)(nil),                     // 50: blog.v1.PostEvent
+	(*ListPostRevisionsRequest)(nil),      // 51: blog.v1.ListPostRevisionsRequest
+	(*ListPostRevisionsResponse)(nil),     // 52: blog.v1.ListPostRevisionsResponse
+	(*GetPostRevisionRequest)(nil),        // 53: blog.v1.GetPostRevisionRequest
+	(*GetPostRevisionResponse)(nil),       // 54: blog.v1.GetPostRevisionResponse
+	(*RestorePostRevisionRequest)(nil),    // 55: blog.v1.RestorePostRevisionRequest
+	(*RestorePostRevisionResponse)(nil),   // 56: blog.v1.RestorePostRevisionResponse
+	(*DiffSpan)(nil),                      // 57: blog.v1.DiffSpan
+	(*TextDiff)(nil),                      // 58: blog.v1.TextDiff
+	(*TagsDiff)(nil),                      // 59: blog.v1.TagsDiff
+	(*DiffPostRevisionsRequest)(nil),      // 60: blog.v1.DiffPostRevisionsRequest
+	(*DiffPostRevisionsResponse)(nil),     // 61: blog.v1.DiffPostRevisionsResponse
+	(*Author)(nil),                        // 62: blog.v1.Author
+	(*CreateAuthorRequest)(nil),           // 63: blog.v1.CreateAuthorRequest
+	(*CreateAuthorResponse)(nil),          // 64: blog.v1.CreateAuthorResponse
+	(*GetAuthorRequest)(nil),              // 65: blog.v1.GetAuthorRequest
+	(*GetAuthorResponse)(nil),             // 66: blog.v1.GetAuthorResponse
+	(*UpdateAuthorRequest)(nil),           // 67: blog.v1.UpdateAuthorRequest
+	(*UpdateAuthorResponse)(nil),          // 68: blog.v1.UpdateAuthorResponse
+	(*DeleteAuthorRequest)(nil),           // 69: blog.v1.DeleteAuthorRequest
+	(*DeleteAuthorResponse)(nil),          // 70: blog.v1.DeleteAuthorResponse
+	(*ListAuthorsRequest)(nil),            // 71: blog.v1.ListAuthorsRequest
+	(*ListAuthorsResponse)(nil),           // 72: blog.v1.ListAuthorsResponse
+	(*Tag)(nil),                           // 73: blog.v1.Tag
+	(*ListTagsRequest)(nil),               // 74: blog.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),              // 75: blog.v1.ListTagsResponse
+	(*GetTagRequest)(nil),                 // 76: blog.v1.GetTagRequest
+	(*GetTagResponse)(nil),                // 77: blog.v1.GetTagResponse
+	(*RenameTagRequest)(nil),              // 78: blog.v1.RenameTagRequest
+	(*RenameTagResponse)(nil),             // 79: blog.v1.RenameTagResponse
+	(*MergeTagsRequest)(nil),              // 80: blog.v1.MergeTagsRequest
+	(*MergeTagsResponse)(nil),             // 81: blog.v1.MergeTagsResponse
+	(*SuggestTagsRequest)(nil),            // 82: blog.v1.SuggestTagsRequest
+	(*TagSuggestion)(nil),                 // 83: blog.v1.TagSuggestion
+	(*SuggestTagsResponse)(nil),           // 84: blog.v1.SuggestTagsResponse
+	(*timestamppb.Timestamp)(nil),         // 85: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),         // 86: google.protobuf.FieldMask
 }
 var file_blog_proto_depIdxs = []int32{
-	9,  // 0: blog.v1.BlogPost.publication_date:type_name -> google.protobuf.Timestamp
-	9,  // 1: blog.v1.BlogPost.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: blog.v1.CreateBlogPostRequest.publication_date:type_name -> google.protobuf.Timestamp
-	0,  // 3: blog.v1.CreateBlogPostResponse.post:type_name -> blog.v1.BlogPost
-	0,  // 4: blog.v1.GetBlogPostResponse.post:type_name -> blog.v1.BlogPost
-	0,  // 5: blog.v1.UpdateBlogPostResponse.post:type_name -> blog.v1.BlogPost
-	1,  // 6: blog.v1.BlogService.CreateBlogPost:input_type -> blog.v1.CreateBlogPostRequest
-	3,  // 7: blog.v1.BlogService.GetBlogPost:input_type -> blog.v1.GetBlogPostRequest
-	5,  // 8: blog.v1.BlogService.UpdateBlogPost:input_type -> blog.v1.UpdateBlogPostRequest
-	7,  // 9: blog.v1.BlogService.DeleteBlogPost:input_type -> blog.v1.DeleteBlogPostRequest
-	2,  // 10: blog.v1.BlogService.CreateBlogPost:output_type -> blog.v1.CreateBlogPostResponse
-	4,  // 11: blog.v1.BlogService.GetBlogPost:output_type -> blog.v1.GetBlogPostResponse
-	6,  // 12: blog.v1.BlogService.UpdateBlogPost:output_type -> blog.v1.UpdateBlogPostResponse
-	8,  // 13: blog.v1.BlogService.DeleteBlogPost:output_type -> blog.v1.DeleteBlogPostResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	85, // 0: blog.v1.BlogPost.publication_date:type_name -> google.protobuf.Timestamp
+	85, // 1: blog.v1.BlogPost.updated_at:type_name -> google.protobuf.Timestamp
+	85, // 2: blog.v1.BlogPost.deleted_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: blog.v1.BlogPost.status:type_name -> blog.v1.PostStatus
+	85, // 4: blog.v1.PostRevision.created_at:type_name -> google.protobuf.Timestamp
+	85, // 5: blog.v1.CreateBlogPostRequest.publication_date:type_name -> google.protobuf.Timestamp
+	0,  // 6: blog.v1.CreateBlogPostRequest.status:type_name -> blog.v1.PostStatus
+	5,  // 7: blog.v1.CreateBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	7,  // 8: blog.v1.ImportBlogPostsRequest.post:type_name -> blog.v1.CreateBlogPostRequest
+	1,  // 9: blog.v1.ImportBlogPostsRequest.mode:type_name -> blog.v1.ImportMode
+	10, // 10: blog.v1.ImportBlogPostsResponse.errors:type_name -> blog.v1.ImportError
+	5,  // 11: blog.v1.GetBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	5,  // 12: blog.v1.BatchGetBlogPostsResult.post:type_name -> blog.v1.BlogPost
+	15, // 13: blog.v1.BatchGetBlogPostsResponse.results:type_name -> blog.v1.BatchGetBlogPostsResult
+	86, // 14: blog.v1.UpdateBlogPostRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 15: blog.v1.UpdateBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	22, // 16: blog.v1.BatchDeleteBlogPostsResponse.results:type_name -> blog.v1.BatchDeleteBlogPostsResult
+	5,  // 17: blog.v1.RestoreBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	85, // 18: blog.v1.PublishBlogPostRequest.publish_at:type_name -> google.protobuf.Timestamp
+	5,  // 19: blog.v1.PublishBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	5,  // 20: blog.v1.UnpublishBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	5,  // 21: blog.v1.ArchiveBlogPostResponse.post:type_name -> blog.v1.BlogPost
+	5,  // 22: blog.v1.ListBlogPostsResponse.posts:type_name -> blog.v1.BlogPost
+	5,  // 23: blog.v1.ListBlogPostsByAuthorResponse.posts:type_name -> blog.v1.BlogPost
+	5,  // 24: blog.v1.ListBlogPostsByTagResponse.posts:type_name -> blog.v1.BlogPost
+	5,  // 25: blog.v1.SearchResult.post:type_name -> blog.v1.BlogPost
+	41, // 26: blog.v1.SearchResult.title:type_name -> blog.v1.HighlightSpan
+	41, // 27: blog.v1.SearchResult.snippet:type_name -> blog.v1.HighlightSpan
+	42, // 28: blog.v1.SearchBlogPostsResponse.results:type_name -> blog.v1.SearchResult
+	5,  // 29: blog.v1.RelatedPost.post:type_name -> blog.v1.BlogPost
+	45, // 30: blog.v1.GetRelatedPostsResponse.posts:type_name -> blog.v1.RelatedPost
+	5,  // 31: blog.v1.ExportBlogPostsResponse.post:type_name -> blog.v1.BlogPost
+	2,  // 32: blog.v1.PostEvent.type:type_name -> blog.v1.EventType
+	5,  // 33: blog.v1.PostEvent.post:type_name -> blog.v1.BlogPost
+	5,  // 34: blog.v1.PostEvent.previous:type_name -> blog.v1.BlogPost
+	85, // 35: blog.v1.PostEvent.time:type_name -> google.protobuf.Timestamp
+	6,  // 36: blog.v1.ListPostRevisionsResponse.revisions:type_name -> blog.v1.PostRevision
+	6,  // 37: blog.v1.GetPostRevisionResponse.revision:type_name -> blog.v1.PostRevision
+	5,  // 38: blog.v1.RestorePostRevisionResponse.post:type_name -> blog.v1.BlogPost
+	4,  // 39: blog.v1.DiffSpan.op:type_name -> blog.v1.DiffOp
+	57, // 40: blog.v1.TextDiff.spans:type_name -> blog.v1.DiffSpan
+	3,  // 41: blog.v1.DiffPostRevisionsRequest.format:type_name -> blog.v1.DiffFormat
+	58, // 42: blog.v1.DiffPostRevisionsResponse.title:type_name -> blog.v1.TextDiff
+	58, // 43: blog.v1.DiffPostRevisionsResponse.content:type_name -> blog.v1.TextDiff
+	59, // 44: blog.v1.DiffPostRevisionsResponse.tags:type_name -> blog.v1.TagsDiff
+	85, // 45: blog.v1.Author.created_at:type_name -> google.protobuf.Timestamp
+	85, // 46: blog.v1.Author.updated_at:type_name -> google.protobuf.Timestamp
+	62, // 47: blog.v1.CreateAuthorResponse.author:type_name -> blog.v1.Author
+	62, // 48: blog.v1.GetAuthorResponse.author:type_name -> blog.v1.Author
+	62, // 49: blog.v1.UpdateAuthorResponse.author:type_name -> blog.v1.Author
+	62, // 50: blog.v1.ListAuthorsResponse.authors:type_name -> blog.v1.Author
+	85, // 51: blog.v1.Tag.created_at:type_name -> google.protobuf.Timestamp
+	85, // 52: blog.v1.Tag.updated_at:type_name -> google.protobuf.Timestamp
+	73, // 53: blog.v1.ListTagsResponse.tags:type_name -> blog.v1.Tag
+	73, // 54: blog.v1.GetTagResponse.tag:type_name -> blog.v1.Tag
+	73, // 55: blog.v1.RenameTagResponse.tag:type_name -> blog.v1.Tag
+	73, // 56: blog.v1.MergeTagsResponse.tag:type_name -> blog.v1.Tag
+	73, // 57: blog.v1.TagSuggestion.tag:type_name -> blog.v1.Tag
+	83, // 58: blog.v1.SuggestTagsResponse.suggestions:type_name -> blog.v1.TagSuggestion
+	7,  // 59: blog.v1.BlogService.CreateBlogPost:input_type -> blog.v1.CreateBlogPostRequest
+	9,  // 60: blog.v1.BlogService.ImportBlogPosts:input_type -> blog.v1.ImportBlogPostsRequest
+	12, // 61: blog.v1.BlogService.GetBlogPost:input_type -> blog.v1.GetBlogPostRequest
+	14, // 62: blog.v1.BlogService.BatchGetBlogPosts:input_type -> blog.v1.BatchGetBlogPostsRequest
+	17, // 63: blog.v1.BlogService.UpdateBlogPost:input_type -> blog.v1.UpdateBlogPostRequest
+	19, // 64: blog.v1.BlogService.DeleteBlogPost:input_type -> blog.v1.DeleteBlogPostRequest
+	21, // 65: blog.v1.BlogService.BatchDeleteBlogPosts:input_type -> blog.v1.BatchDeleteBlogPostsRequest
+	24, // 66: blog.v1.BlogService.RestoreBlogPost:input_type -> blog.v1.RestoreBlogPostRequest
+	26, // 67: blog.v1.BlogService.PurgeBlogPost:input_type -> blog.v1.PurgeBlogPostRequest
+	28, // 68: blog.v1.BlogService.PublishBlogPost:input_type -> blog.v1.PublishBlogPostRequest
+	30, // 69: blog.v1.BlogService.UnpublishBlogPost:input_type -> blog.v1.UnpublishBlogPostRequest
+	32, // 70: blog.v1.BlogService.ArchiveBlogPost:input_type -> blog.v1.ArchiveBlogPostRequest
+	34, // 71: blog.v1.BlogService.ListBlogPosts:input_type -> blog.v1.ListBlogPostsRequest
+	36, // 72: blog.v1.BlogService.ListBlogPostsByAuthor:input_type -> blog.v1.ListBlogPostsByAuthorRequest
+	38, // 73: blog.v1.BlogService.ListBlogPostsByTag:input_type -> blog.v1.ListBlogPostsByTagRequest
+	40, // 74: blog.v1.BlogService.SearchBlogPosts:input_type -> blog.v1.SearchBlogPostsRequest
+	44, // 75: blog.v1.BlogService.GetRelatedPosts:input_type -> blog.v1.GetRelatedPostsRequest
+	47, // 76: blog.v1.BlogService.ExportBlogPosts:input_type -> blog.v1.ExportBlogPostsRequest
+	49, // 77: blog.v1.BlogService.WatchBlogPosts:input_type -> blog.v1.WatchBlogPostsRequest
+	51, // 78: blog.v1.BlogService.ListPostRevisions:input_type -> blog.v1.ListPostRevisionsRequest
+	53, // 79: blog.v1.BlogService.GetPostRevision:input_type -> blog.v1.GetPostRevisionRequest
+	55, // 80: blog.v1.BlogService.RestorePostRevision:input_type -> blog.v1.RestorePostRevisionRequest
+	60, // 81: blog.v1.BlogService.DiffPostRevisions:input_type -> blog.v1.DiffPostRevisionsRequest
+	63, // 82: blog.v1.AuthorService.CreateAuthor:input_type -> blog.v1.CreateAuthorRequest
+	65, // 83: blog.v1.AuthorService.GetAuthor:input_type -> blog.v1.GetAuthorRequest
+	67, // 84: blog.v1.AuthorService.UpdateAuthor:input_type -> blog.v1.UpdateAuthorRequest
+	69, // 85: blog.v1.AuthorService.DeleteAuthor:input_type -> blog.v1.DeleteAuthorRequest
+	71, // 86: blog.v1.AuthorService.ListAuthors:input_type -> blog.v1.ListAuthorsRequest
+	74, // 87: blog.v1.TagService.ListTags:input_type -> blog.v1.ListTagsRequest
+	76, // 88: blog.v1.TagService.GetTag:input_type -> blog.v1.GetTagRequest
+	78, // 89: blog.v1.TagService.RenameTag:input_type -> blog.v1.RenameTagRequest
+	80, // 90: blog.v1.TagService.MergeTags:input_type -> blog.v1.MergeTagsRequest
+	82, // 91: blog.v1.TagService.SuggestTags:input_type -> blog.v1.SuggestTagsRequest
+	8,  // 92: blog.v1.BlogService.CreateBlogPost:output_type -> blog.v1.CreateBlogPostResponse
+	11, // 93: blog.v1.BlogService.ImportBlogPosts:output_type -> blog.v1.ImportBlogPostsResponse
+	13, // 94: blog.v1.BlogService.GetBlogPost:output_type -> blog.v1.GetBlogPostResponse
+	16, // 95: blog.v1.BlogService.BatchGetBlogPosts:output_type -> blog.v1.BatchGetBlogPostsResponse
+	18, // 96: blog.v1.BlogService.UpdateBlogPost:output_type -> blog.v1.UpdateBlogPostResponse
+	20, // 97: blog.v1.BlogService.DeleteBlogPost:output_type -> blog.v1.DeleteBlogPostResponse
+	23, // 98: blog.v1.BlogService.BatchDeleteBlogPosts:output_type -> blog.v1.BatchDeleteBlogPostsResponse
+	25, // 99: blog.v1.BlogService.RestoreBlogPost:output_type -> blog.v1.RestoreBlogPostResponse
+	27, // 100: blog.v1.BlogService.PurgeBlogPost:output_type -> blog.v1.PurgeBlogPostResponse
+	29, // 101: blog.v1.BlogService.PublishBlogPost:output_type -> blog.v1.PublishBlogPostResponse
+	31, // 102: blog.v1.BlogService.UnpublishBlogPost:output_type -> blog.v1.UnpublishBlogPostResponse
+	33, // 103: blog.v1.BlogService.ArchiveBlogPost:output_type -> blog.v1.ArchiveBlogPostResponse
+	35, // 104: blog.v1.BlogService.ListBlogPosts:output_type -> blog.v1.ListBlogPostsResponse
+	37, // 105: blog.v1.BlogService.ListBlogPostsByAuthor:output_type -> blog.v1.ListBlogPostsByAuthorResponse
+	39, // 106: blog.v1.BlogService.ListBlogPostsByTag:output_type -> blog.v1.ListBlogPostsByTagResponse
+	43, // 107: blog.v1.BlogService.SearchBlogPosts:output_type -> blog.v1.SearchBlogPostsResponse
+	46, // 108: blog.v1.BlogService.GetRelatedPosts:output_type -> blog.v1.GetRelatedPostsResponse
+	48, // 109: blog.v1.BlogService.ExportBlogPosts:output_type -> blog.v1.ExportBlogPostsResponse
+	50, // 110: blog.v1.BlogService.WatchBlogPosts:output_type -> blog.v1.PostEvent
+	52, // 111: blog.v1.BlogService.ListPostRevisions:output_type -> blog.v1.ListPostRevisionsResponse
+	54, // 112: blog.v1.BlogService.GetPostRevision:output_type -> blog.v1.GetPostRevisionResponse
+	56, // 113: blog.v1.BlogService.RestorePostRevision:output_type -> blog.v1.RestorePostRevisionResponse
+	61, // 114: blog.v1.BlogService.DiffPostRevisions:output_type -> blog.v1.DiffPostRevisionsResponse
+	64, // 115: blog.v1.AuthorService.CreateAuthor:output_type -> blog.v1.CreateAuthorResponse
+	66, // 116: blog.v1.AuthorService.GetAuthor:output_type -> blog.v1.GetAuthorResponse
+	68, // 117: blog.v1.AuthorService.UpdateAuthor:output_type -> blog.v1.UpdateAuthorResponse
+	70, // 118: blog.v1.AuthorService.DeleteAuthor:output_type -> blog.v1.DeleteAuthorResponse
+	72, // 119: blog.v1.AuthorService.ListAuthors:output_type -> blog.v1.ListAuthorsResponse
+	75, // 120: blog.v1.TagService.ListTags:output_type -> blog.v1.ListTagsResponse
+	77, // 121: blog.v1.TagService.GetTag:output_type -> blog.v1.GetTagResponse
+	79, // 122: blog.v1.TagService.RenameTag:output_type -> blog.v1.RenameTagResponse
+	81, // 123: blog.v1.TagService.MergeTags:output_type -> blog.v1.MergeTagsResponse
+	84, // 124: blog.v1.TagService.SuggestTags:output_type -> blog.v1.SuggestTagsResponse
+	92, // [92:125] is the sub-list for method output_type
+	59, // [59:92] is the sub-list for method input_type
+	59, // [59:59] is the sub-list for extension type_name
+	59, // [59:59] is the sub-list for extension extendee
+	0,  // [0:59] is the sub-list for field type_name
 }
 
 func init() { file_blog_proto_init() }
@@ -699,19 +6189,23 @@ func file_blog_proto_init() {
 	if File_blog_proto != nil {
 		return
 	}
-	file_blog_proto_msgTypes[1].OneofWrappers = []any{}
+	file_blog_proto_msgTypes[2].OneofWrappers = []any{}
+	file_blog_proto_msgTypes[23].OneofWrappers = []any{}
+	file_blog_proto_msgTypes[55].OneofWrappers = []any{}
+	file_blog_proto_msgTypes[73].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blog_proto_rawDesc), len(file_blog_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      5,
+			NumMessages:   80,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_blog_proto_goTypes,
 		DependencyIndexes: file_blog_proto_depIdxs,
+		EnumInfos:         file_blog_proto_enumTypes,
 		MessageInfos:      file_blog_proto_msgTypes,
 	}.Build()
 	File_blog_proto = out.File
@@ -19,10 +19,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BlogService_CreateBlogPost_FullMethodName = "/blog.v1.BlogService/CreateBlogPost"
-	BlogService_GetBlogPost_FullMethodName    = "/blog.v1.BlogService/GetBlogPost"
-	BlogService_UpdateBlogPost_FullMethodName = "/blog.v1.BlogService/UpdateBlogPost"
-	BlogService_DeleteBlogPost_FullMethodName = "/blog.v1.BlogService/DeleteBlogPost"
+	BlogService_CreateBlogPost_FullMethodName        = "/blog.v1.BlogService/CreateBlogPost"
+	BlogService_ImportBlogPosts_FullMethodName       = "/blog.v1.BlogService/ImportBlogPosts"
+	BlogService_GetBlogPost_FullMethodName           = "/blog.v1.BlogService/GetBlogPost"
+	BlogService_BatchGetBlogPosts_FullMethodName     = "/blog.v1.BlogService/BatchGetBlogPosts"
+	BlogService_UpdateBlogPost_FullMethodName        = "/blog.v1.BlogService/UpdateBlogPost"
+	BlogService_DeleteBlogPost_FullMethodName        = "/blog.v1.BlogService/DeleteBlogPost"
+	BlogService_BatchDeleteBlogPosts_FullMethodName  = "/blog.v1.BlogService/BatchDeleteBlogPosts"
+	BlogService_RestoreBlogPost_FullMethodName       = "/blog.v1.BlogService/RestoreBlogPost"
+	BlogService_PurgeBlogPost_FullMethodName         = "/blog.v1.BlogService/PurgeBlogPost"
+	BlogService_PublishBlogPost_FullMethodName       = "/blog.v1.BlogService/PublishBlogPost"
+	BlogService_UnpublishBlogPost_FullMethodName     = "/blog.v1.BlogService/UnpublishBlogPost"
+	BlogService_ArchiveBlogPost_FullMethodName       = "/blog.v1.BlogService/ArchiveBlogPost"
+	BlogService_ListBlogPosts_FullMethodName         = "/blog.v1.BlogService/ListBlogPosts"
+	BlogService_ListBlogPostsByAuthor_FullMethodName = "/blog.v1.BlogService/ListBlogPostsByAuthor"
+	BlogService_ListBlogPostsByTag_FullMethodName    = "/blog.v1.BlogService/ListBlogPostsByTag"
+	BlogService_SearchBlogPosts_FullMethodName       = "/blog.v1.BlogService/SearchBlogPosts"
+	BlogService_GetRelatedPosts_FullMethodName       = "/blog.v1.BlogService/GetRelatedPosts"
+	BlogService_ExportBlogPosts_FullMethodName       = "/blog.v1.BlogService/ExportBlogPosts"
+	BlogService_WatchBlogPosts_FullMethodName        = "/blog.v1.BlogService/WatchBlogPosts"
+	BlogService_ListPostRevisions_FullMethodName     = "/blog.v1.BlogService/ListPostRevisions"
+	BlogService_GetPostRevision_FullMethodName       = "/blog.v1.BlogService/GetPostRevision"
+	BlogService_RestorePostRevision_FullMethodName   = "/blog.v1.BlogService/RestorePostRevision"
+	BlogService_DiffPostRevisions_FullMethodName     = "/blog.v1.BlogService/DiffPostRevisions"
 )
 
 // BlogServiceClient is the client API for BlogService service.
@@ -31,12 +50,51 @@ const (
 type BlogServiceClient interface {
 	// Create a new blog post
 	CreateBlogPost(ctx context.Context, in *CreateBlogPostRequest, opts ...grpc.CallOption) (*CreateBlogPostResponse, error)
+	// Create many posts from a stream, either all of them or as many as possible
+	ImportBlogPosts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportBlogPostsRequest, ImportBlogPostsResponse], error)
 	// Retrieve a blog post by PostID
 	GetBlogPost(ctx context.Context, in *GetBlogPostRequest, opts ...grpc.CallOption) (*GetBlogPostResponse, error)
+	// Retrieve several blog posts by PostID in one call
+	BatchGetBlogPosts(ctx context.Context, in *BatchGetBlogPostsRequest, opts ...grpc.CallOption) (*BatchGetBlogPostsResponse, error)
 	// Update an existing blog post
 	UpdateBlogPost(ctx context.Context, in *UpdateBlogPostRequest, opts ...grpc.CallOption) (*UpdateBlogPostResponse, error)
 	// Delete a blog post by PostID
 	DeleteBlogPost(ctx context.Context, in *DeleteBlogPostRequest, opts ...grpc.CallOption) (*DeleteBlogPostResponse, error)
+	// Delete several blog posts by PostID in one call
+	BatchDeleteBlogPosts(ctx context.Context, in *BatchDeleteBlogPostsRequest, opts ...grpc.CallOption) (*BatchDeleteBlogPostsResponse, error)
+	// Restore a deleted blog post from the trash
+	RestoreBlogPost(ctx context.Context, in *RestoreBlogPostRequest, opts ...grpc.CallOption) (*RestoreBlogPostResponse, error)
+	// Permanently delete a blog post from the trash
+	PurgeBlogPost(ctx context.Context, in *PurgeBlogPostRequest, opts ...grpc.CallOption) (*PurgeBlogPostResponse, error)
+	// Publish a draft, scheduled or archived post now or at a later date
+	PublishBlogPost(ctx context.Context, in *PublishBlogPostRequest, opts ...grpc.CallOption) (*PublishBlogPostResponse, error)
+	// Turn a post back into a draft
+	UnpublishBlogPost(ctx context.Context, in *UnpublishBlogPostRequest, opts ...grpc.CallOption) (*UnpublishBlogPostResponse, error)
+	// Archive a published post
+	ArchiveBlogPost(ctx context.Context, in *ArchiveBlogPostRequest, opts ...grpc.CallOption) (*ArchiveBlogPostResponse, error)
+	// List blog posts one page at a time
+	ListBlogPosts(ctx context.Context, in *ListBlogPostsRequest, opts ...grpc.CallOption) (*ListBlogPostsResponse, error)
+	// List the published posts of an author, newest first
+	ListBlogPostsByAuthor(ctx context.Context, in *ListBlogPostsByAuthorRequest, opts ...grpc.CallOption) (*ListBlogPostsByAuthorResponse, error)
+	// List the published posts carrying a tag, newest first
+	ListBlogPostsByTag(ctx context.Context, in *ListBlogPostsByTagRequest, opts ...grpc.CallOption) (*ListBlogPostsByTagResponse, error)
+	// Search the published posts, most relevant first
+	SearchBlogPosts(ctx context.Context, in *SearchBlogPostsRequest, opts ...grpc.CallOption) (*SearchBlogPostsResponse, error)
+	// List the published posts related to a post, most closely related first
+	GetRelatedPosts(ctx context.Context, in *GetRelatedPostsRequest, opts ...grpc.CallOption) (*GetRelatedPostsResponse, error)
+	// Stream every post, in post ID order, as they all were when the export started
+	ExportBlogPosts(ctx context.Context, in *ExportBlogPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBlogPostsResponse], error)
+	// Stream the changes to posts as they happen, resuming after a sequence
+	// number if one is given
+	WatchBlogPosts(ctx context.Context, in *WatchBlogPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error)
+	// List the revision history of a post, newest first
+	ListPostRevisions(ctx context.Context, in *ListPostRevisionsRequest, opts ...grpc.CallOption) (*ListPostRevisionsResponse, error)
+	// Retrieve a single revision of a post
+	GetPostRevision(ctx context.Context, in *GetPostRevisionRequest, opts ...grpc.CallOption) (*GetPostRevisionResponse, error)
+	// Restore a post to an earlier revision
+	RestorePostRevision(ctx context.Context, in *RestorePostRevisionRequest, opts ...grpc.CallOption) (*RestorePostRevisionResponse, error)
+	// Compare two revisions of a post
+	DiffPostRevisions(ctx context.Context, in *DiffPostRevisionsRequest, opts ...grpc.CallOption) (*DiffPostRevisionsResponse, error)
 }
 
 type blogServiceClient struct {
@@ -57,6 +115,19 @@ func (c *blogServiceClient) CreateBlogPost(ctx context.Context, in *CreateBlogPo
 	return out, nil
 }
 
+func (c *blogServiceClient) ImportBlogPosts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportBlogPostsRequest, ImportBlogPostsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlogService_ServiceDesc.Streams[0], BlogService_ImportBlogPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportBlogPostsRequest, ImportBlogPostsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_ImportBlogPostsClient = grpc.ClientStreamingClient[ImportBlogPostsRequest, ImportBlogPostsResponse]
+
 func (c *blogServiceClient) GetBlogPost(ctx context.Context, in *GetBlogPostRequest, opts ...grpc.CallOption) (*GetBlogPostResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetBlogPostResponse)
@@ -67,6 +138,16 @@ func (c *blogServiceClient) GetBlogPost(ctx context.Context, in *GetBlogPostRequ
 	return out, nil
 }
 
+func (c *blogServiceClient) BatchGetBlogPosts(ctx context.Context, in *BatchGetBlogPostsRequest, opts ...grpc.CallOption) (*BatchGetBlogPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetBlogPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_BatchGetBlogPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blogServiceClient) UpdateBlogPost(ctx context.Context, in *UpdateBlogPostRequest, opts ...grpc.CallOption) (*UpdateBlogPostResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateBlogPostResponse)
@@ -87,18 +168,245 @@ func (c *blogServiceClient) DeleteBlogPost(ctx context.Context, in *DeleteBlogPo
 	return out, nil
 }
 
+func (c *blogServiceClient) BatchDeleteBlogPosts(ctx context.Context, in *BatchDeleteBlogPostsRequest, opts ...grpc.CallOption) (*BatchDeleteBlogPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchDeleteBlogPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_BatchDeleteBlogPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RestoreBlogPost(ctx context.Context, in *RestoreBlogPostRequest, opts ...grpc.CallOption) (*RestoreBlogPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreBlogPostResponse)
+	err := c.cc.Invoke(ctx, BlogService_RestoreBlogPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) PurgeBlogPost(ctx context.Context, in *PurgeBlogPostRequest, opts ...grpc.CallOption) (*PurgeBlogPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeBlogPostResponse)
+	err := c.cc.Invoke(ctx, BlogService_PurgeBlogPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) PublishBlogPost(ctx context.Context, in *PublishBlogPostRequest, opts ...grpc.CallOption) (*PublishBlogPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishBlogPostResponse)
+	err := c.cc.Invoke(ctx, BlogService_PublishBlogPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UnpublishBlogPost(ctx context.Context, in *UnpublishBlogPostRequest, opts ...grpc.CallOption) (*UnpublishBlogPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnpublishBlogPostResponse)
+	err := c.cc.Invoke(ctx, BlogService_UnpublishBlogPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ArchiveBlogPost(ctx context.Context, in *ArchiveBlogPostRequest, opts ...grpc.CallOption) (*ArchiveBlogPostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveBlogPostResponse)
+	err := c.cc.Invoke(ctx, BlogService_ArchiveBlogPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogPosts(ctx context.Context, in *ListBlogPostsRequest, opts ...grpc.CallOption) (*ListBlogPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlogPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_ListBlogPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogPostsByAuthor(ctx context.Context, in *ListBlogPostsByAuthorRequest, opts ...grpc.CallOption) (*ListBlogPostsByAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlogPostsByAuthorResponse)
+	err := c.cc.Invoke(ctx, BlogService_ListBlogPostsByAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogPostsByTag(ctx context.Context, in *ListBlogPostsByTagRequest, opts ...grpc.CallOption) (*ListBlogPostsByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlogPostsByTagResponse)
+	err := c.cc.Invoke(ctx, BlogService_ListBlogPostsByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) SearchBlogPosts(ctx context.Context, in *SearchBlogPostsRequest, opts ...grpc.CallOption) (*SearchBlogPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchBlogPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_SearchBlogPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetRelatedPosts(ctx context.Context, in *GetRelatedPostsRequest, opts ...grpc.CallOption) (*GetRelatedPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedPostsResponse)
+	err := c.cc.Invoke(ctx, BlogService_GetRelatedPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ExportBlogPosts(ctx context.Context, in *ExportBlogPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBlogPostsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlogService_ServiceDesc.Streams[1], BlogService_ExportBlogPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportBlogPostsRequest, ExportBlogPostsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_ExportBlogPostsClient = grpc.ServerStreamingClient[ExportBlogPostsResponse]
+
+func (c *blogServiceClient) WatchBlogPosts(ctx context.Context, in *WatchBlogPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlogService_ServiceDesc.Streams[2], BlogService_WatchBlogPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBlogPostsRequest, PostEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_WatchBlogPostsClient = grpc.ServerStreamingClient[PostEvent]
+
+func (c *blogServiceClient) ListPostRevisions(ctx context.Context, in *ListPostRevisionsRequest, opts ...grpc.CallOption) (*ListPostRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPostRevisionsResponse)
+	err := c.cc.Invoke(ctx, BlogService_ListPostRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetPostRevision(ctx context.Context, in *GetPostRevisionRequest, opts ...grpc.CallOption) (*GetPostRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPostRevisionResponse)
+	err := c.cc.Invoke(ctx, BlogService_GetPostRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RestorePostRevision(ctx context.Context, in *RestorePostRevisionRequest, opts ...grpc.CallOption) (*RestorePostRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestorePostRevisionResponse)
+	err := c.cc.Invoke(ctx, BlogService_RestorePostRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DiffPostRevisions(ctx context.Context, in *DiffPostRevisionsRequest, opts ...grpc.CallOption) (*DiffPostRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffPostRevisionsResponse)
+	err := c.cc.Invoke(ctx, BlogService_DiffPostRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlogServiceServer is the server API for BlogService service.
 // All implementations must embed UnimplementedBlogServiceServer
 // for forward compatibility.
 type BlogServiceServer interface {
 	// Create a new blog post
 	CreateBlogPost(context.Context, *CreateBlogPostRequest) (*CreateBlogPostResponse, error)
+	// Create many posts from a stream, either all of them or as many as possible
+	ImportBlogPosts(grpc.ClientStreamingServer[ImportBlogPostsRequest, ImportBlogPostsResponse]) error
 	// Retrieve a blog post by PostID
 	GetBlogPost(context.Context, *GetBlogPostRequest) (*GetBlogPostResponse, error)
+	// Retrieve several blog posts by PostID in one call
+	BatchGetBlogPosts(context.Context, *BatchGetBlogPostsRequest) (*BatchGetBlogPostsResponse, error)
 	// Update an existing blog post
 	UpdateBlogPost(context.Context, *UpdateBlogPostRequest) (*UpdateBlogPostResponse, error)
 	// Delete a blog post by PostID
 	DeleteBlogPost(context.Context, *DeleteBlogPostRequest) (*DeleteBlogPostResponse, error)
+	// Delete several blog posts by PostID in one call
+	BatchDeleteBlogPosts(context.Context, *BatchDeleteBlogPostsRequest) (*BatchDeleteBlogPostsResponse, error)
+	// Restore a deleted blog post from the trash
+	RestoreBlogPost(context.Context, *RestoreBlogPostRequest) (*RestoreBlogPostResponse, error)
+	// Permanently delete a blog post from the trash
+	PurgeBlogPost(context.Context, *PurgeBlogPostRequest) (*PurgeBlogPostResponse, error)
+	// Publish a draft, scheduled or archived post now or at a later date
+	PublishBlogPost(context.Context, *PublishBlogPostRequest) (*PublishBlogPostResponse, error)
+	// Turn a post back into a draft
+	UnpublishBlogPost(context.Context, *UnpublishBlogPostRequest) (*UnpublishBlogPostResponse, error)
+	// Archive a published post
+	ArchiveBlogPost(context.Context, *ArchiveBlogPostRequest) (*ArchiveBlogPostResponse, error)
+	// List blog posts one page at a time
+	ListBlogPosts(context.Context, *ListBlogPostsRequest) (*ListBlogPostsResponse, error)
+	// List the published posts of an author, newest first
+	ListBlogPostsByAuthor(context.Context, *ListBlogPostsByAuthorRequest) (*ListBlogPostsByAuthorResponse, error)
+	// List the published posts carrying a tag, newest first
+	ListBlogPostsByTag(context.Context, *ListBlogPostsByTagRequest) (*ListBlogPostsByTagResponse, error)
+	// Search the published posts, most relevant first
+	SearchBlogPosts(context.Context, *SearchBlogPostsRequest) (*SearchBlogPostsResponse, error)
+	// List the published posts related to a post, most closely related first
+	GetRelatedPosts(context.Context, *GetRelatedPostsRequest) (*GetRelatedPostsResponse, error)
+	// Stream every post, in post ID order, as they all were when the export started
+	ExportBlogPosts(*ExportBlogPostsRequest, grpc.ServerStreamingServer[ExportBlogPostsResponse]) error
+	// Stream the changes to posts as they happen, resuming after a sequence
+	// number if one is given
+	WatchBlogPosts(*WatchBlogPostsRequest, grpc.ServerStreamingServer[PostEvent]) error
+	// List the revision history of a post, newest first
+	ListPostRevisions(context.Context, *ListPostRevisionsRequest) (*ListPostRevisionsResponse, error)
+	// Retrieve a single revision of a post
+	GetPostRevision(context.Context, *GetPostRevisionRequest) (*GetPostRevisionResponse, error)
+	// Restore a post to an earlier revision
+	RestorePostRevision(context.Context, *RestorePostRevisionRequest) (*RestorePostRevisionResponse, error)
+	// Compare two revisions of a post
+	DiffPostRevisions(context.Context, *DiffPostRevisionsRequest) (*DiffPostRevisionsResponse, error)
 	mustEmbedUnimplementedBlogServiceServer()
 }
 
@@ -112,15 +420,72 @@ type UnimplementedBlogServiceServer struct{}
 func (UnimplementedBlogServiceServer) CreateBlogPost(context.Context, *CreateBlogPostRequest) (*CreateBlogPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBlogPost not implemented")
 }
+func (UnimplementedBlogServiceServer) ImportBlogPosts(grpc.ClientStreamingServer[ImportBlogPostsRequest, ImportBlogPostsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportBlogPosts not implemented")
+}
 func (UnimplementedBlogServiceServer) GetBlogPost(context.Context, *GetBlogPostRequest) (*GetBlogPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlogPost not implemented")
 }
+func (UnimplementedBlogServiceServer) BatchGetBlogPosts(context.Context, *BatchGetBlogPostsRequest) (*BatchGetBlogPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetBlogPosts not implemented")
+}
 func (UnimplementedBlogServiceServer) UpdateBlogPost(context.Context, *UpdateBlogPostRequest) (*UpdateBlogPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlogPost not implemented")
 }
 func (UnimplementedBlogServiceServer) DeleteBlogPost(context.Context, *DeleteBlogPostRequest) (*DeleteBlogPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlogPost not implemented")
 }
+func (UnimplementedBlogServiceServer) BatchDeleteBlogPosts(context.Context, *BatchDeleteBlogPostsRequest) (*BatchDeleteBlogPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteBlogPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) RestoreBlogPost(context.Context, *RestoreBlogPostRequest) (*RestoreBlogPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBlogPost not implemented")
+}
+func (UnimplementedBlogServiceServer) PurgeBlogPost(context.Context, *PurgeBlogPostRequest) (*PurgeBlogPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeBlogPost not implemented")
+}
+func (UnimplementedBlogServiceServer) PublishBlogPost(context.Context, *PublishBlogPostRequest) (*PublishBlogPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBlogPost not implemented")
+}
+func (UnimplementedBlogServiceServer) UnpublishBlogPost(context.Context, *UnpublishBlogPostRequest) (*UnpublishBlogPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpublishBlogPost not implemented")
+}
+func (UnimplementedBlogServiceServer) ArchiveBlogPost(context.Context, *ArchiveBlogPostRequest) (*ArchiveBlogPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveBlogPost not implemented")
+}
+func (UnimplementedBlogServiceServer) ListBlogPosts(context.Context, *ListBlogPostsRequest) (*ListBlogPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlogPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) ListBlogPostsByAuthor(context.Context, *ListBlogPostsByAuthorRequest) (*ListBlogPostsByAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlogPostsByAuthor not implemented")
+}
+func (UnimplementedBlogServiceServer) ListBlogPostsByTag(context.Context, *ListBlogPostsByTagRequest) (*ListBlogPostsByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlogPostsByTag not implemented")
+}
+func (UnimplementedBlogServiceServer) SearchBlogPosts(context.Context, *SearchBlogPostsRequest) (*SearchBlogPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchBlogPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) GetRelatedPosts(context.Context, *GetRelatedPostsRequest) (*GetRelatedPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) ExportBlogPosts(*ExportBlogPostsRequest, grpc.ServerStreamingServer[ExportBlogPostsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportBlogPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) WatchBlogPosts(*WatchBlogPostsRequest, grpc.ServerStreamingServer[PostEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBlogPosts not implemented")
+}
+func (UnimplementedBlogServiceServer) ListPostRevisions(context.Context, *ListPostRevisionsRequest) (*ListPostRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPostRevisions not implemented")
+}
+func (UnimplementedBlogServiceServer) GetPostRevision(context.Context, *GetPostRevisionRequest) (*GetPostRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostRevision not implemented")
+}
+func (UnimplementedBlogServiceServer) RestorePostRevision(context.Context, *RestorePostRevisionRequest) (*RestorePostRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePostRevision not implemented")
+}
+func (UnimplementedBlogServiceServer) DiffPostRevisions(context.Context, *DiffPostRevisionsRequest) (*DiffPostRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffPostRevisions not implemented")
+}
 func (UnimplementedBlogServiceServer) mustEmbedUnimplementedBlogServiceServer() {}
 func (UnimplementedBlogServiceServer) testEmbeddedByValue()                     {}
 
@@ -160,6 +525,13 @@ func _BlogService_CreateBlogPost_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_ImportBlogPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlogServiceServer).ImportBlogPosts(&grpc.GenericServerStream[ImportBlogPostsRequest, ImportBlogPostsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_ImportBlogPostsServer = grpc.ClientStreamingServer[ImportBlogPostsRequest, ImportBlogPostsResponse]
+
 func _BlogService_GetBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetBlogPostRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +550,24 @@ func _BlogService_GetBlogPost_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_BatchGetBlogPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetBlogPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).BatchGetBlogPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_BatchGetBlogPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).BatchGetBlogPosts(ctx, req.(*BatchGetBlogPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlogService_UpdateBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateBlogPostRequest)
 	if err := dec(in); err != nil {
@@ -214,28 +604,928 @@ func _BlogService_DeleteBlogPost_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
-// BlogService_ServiceDesc is the grpc.ServiceDesc for BlogService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var BlogService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "blog.v1.BlogService",
-	HandlerType: (*BlogServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateBlogPost",
-			Handler:    _BlogService_CreateBlogPost_Handler,
-		},
-		{
-			MethodName: "GetBlogPost",
-			Handler:    _BlogService_GetBlogPost_Handler,
-		},
-		{
-			MethodName: "UpdateBlogPost",
-			Handler:    _BlogService_UpdateBlogPost_Handler,
-		},
-		{
-			MethodName: "DeleteBlogPost",
-			Handler:    _BlogService_DeleteBlogPost_Handler,
+func _BlogService_BatchDeleteBlogPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteBlogPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).BatchDeleteBlogPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_BatchDeleteBlogPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).BatchDeleteBlogPosts(ctx, req.(*BatchDeleteBlogPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RestoreBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBlogPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RestoreBlogPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_RestoreBlogPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RestoreBlogPost(ctx, req.(*RestoreBlogPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_PurgeBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeBlogPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PurgeBlogPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_PurgeBlogPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PurgeBlogPost(ctx, req.(*PurgeBlogPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_PublishBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBlogPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PublishBlogPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_PublishBlogPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PublishBlogPost(ctx, req.(*PublishBlogPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UnpublishBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishBlogPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UnpublishBlogPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_UnpublishBlogPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UnpublishBlogPost(ctx, req.(*UnpublishBlogPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ArchiveBlogPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveBlogPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ArchiveBlogPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ArchiveBlogPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ArchiveBlogPost(ctx, req.(*ArchiveBlogPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlogPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ListBlogPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogPosts(ctx, req.(*ListBlogPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlogPostsByAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogPostsByAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogPostsByAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ListBlogPostsByAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogPostsByAuthor(ctx, req.(*ListBlogPostsByAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlogPostsByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogPostsByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogPostsByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ListBlogPostsByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogPostsByTag(ctx, req.(*ListBlogPostsByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_SearchBlogPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchBlogPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).SearchBlogPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_SearchBlogPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).SearchBlogPosts(ctx, req.(*SearchBlogPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetRelatedPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetRelatedPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_GetRelatedPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetRelatedPosts(ctx, req.(*GetRelatedPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ExportBlogPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBlogPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ExportBlogPosts(m, &grpc.GenericServerStream[ExportBlogPostsRequest, ExportBlogPostsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_ExportBlogPostsServer = grpc.ServerStreamingServer[ExportBlogPostsResponse]
+
+func _BlogService_WatchBlogPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBlogPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).WatchBlogPosts(m, &grpc.GenericServerStream[WatchBlogPostsRequest, PostEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlogService_WatchBlogPostsServer = grpc.ServerStreamingServer[PostEvent]
+
+func _BlogService_ListPostRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListPostRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_ListPostRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListPostRevisions(ctx, req.(*ListPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetPostRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetPostRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_GetPostRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetPostRevision(ctx, req.(*GetPostRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RestorePostRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePostRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RestorePostRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_RestorePostRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RestorePostRevision(ctx, req.(*RestorePostRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DiffPostRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffPostRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DiffPostRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogService_DiffPostRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DiffPostRevisions(ctx, req.(*DiffPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BlogService_ServiceDesc is the grpc.ServiceDesc for BlogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.v1.BlogService",
+	HandlerType: (*BlogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBlogPost",
+			Handler:    _BlogService_CreateBlogPost_Handler,
+		},
+		{
+			MethodName: "GetBlogPost",
+			Handler:    _BlogService_GetBlogPost_Handler,
+		},
+		{
+			MethodName: "BatchGetBlogPosts",
+			Handler:    _BlogService_BatchGetBlogPosts_Handler,
+		},
+		{
+			MethodName: "UpdateBlogPost",
+			Handler:    _BlogService_UpdateBlogPost_Handler,
+		},
+		{
+			MethodName: "DeleteBlogPost",
+			Handler:    _BlogService_DeleteBlogPost_Handler,
+		},
+		{
+			MethodName: "BatchDeleteBlogPosts",
+			Handler:    _BlogService_BatchDeleteBlogPosts_Handler,
+		},
+		{
+			MethodName: "RestoreBlogPost",
+			Handler:    _BlogService_RestoreBlogPost_Handler,
+		},
+		{
+			MethodName: "PurgeBlogPost",
+			Handler:    _BlogService_PurgeBlogPost_Handler,
+		},
+		{
+			MethodName: "PublishBlogPost",
+			Handler:    _BlogService_PublishBlogPost_Handler,
+		},
+		{
+			MethodName: "UnpublishBlogPost",
+			Handler:    _BlogService_UnpublishBlogPost_Handler,
+		},
+		{
+			MethodName: "ArchiveBlogPost",
+			Handler:    _BlogService_ArchiveBlogPost_Handler,
+		},
+		{
+			MethodName: "ListBlogPosts",
+			Handler:    _BlogService_ListBlogPosts_Handler,
+		},
+		{
+			MethodName: "ListBlogPostsByAuthor",
+			Handler:    _BlogService_ListBlogPostsByAuthor_Handler,
+		},
+		{
+			MethodName: "ListBlogPostsByTag",
+			Handler:    _BlogService_ListBlogPostsByTag_Handler,
+		},
+		{
+			MethodName: "SearchBlogPosts",
+			Handler:    _BlogService_SearchBlogPosts_Handler,
+		},
+		{
+			MethodName: "GetRelatedPosts",
+			Handler:    _BlogService_GetRelatedPosts_Handler,
+		},
+		{
+			MethodName: "ListPostRevisions",
+			Handler:    _BlogService_ListPostRevisions_Handler,
+		},
+		{
+			MethodName: "GetPostRevision",
+			Handler:    _BlogService_GetPostRevision_Handler,
+		},
+		{
+			MethodName: "RestorePostRevision",
+			Handler:    _BlogService_RestorePostRevision_Handler,
+		},
+		{
+			MethodName: "DiffPostRevisions",
+			Handler:    _BlogService_DiffPostRevisions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportBlogPosts",
+			Handler:       _BlogService_ImportBlogPosts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportBlogPosts",
+			Handler:       _BlogService_ExportBlogPosts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBlogPosts",
+			Handler:       _BlogService_WatchBlogPosts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "blog.proto",
+}
+
+const (
+	AuthorService_CreateAuthor_FullMethodName = "/blog.v1.AuthorService/CreateAuthor"
+	AuthorService_GetAuthor_FullMethodName    = "/blog.v1.AuthorService/GetAuthor"
+	AuthorService_UpdateAuthor_FullMethodName = "/blog.v1.AuthorService/UpdateAuthor"
+	AuthorService_DeleteAuthor_FullMethodName = "/blog.v1.AuthorService/DeleteAuthor"
+	AuthorService_ListAuthors_FullMethodName  = "/blog.v1.AuthorService/ListAuthors"
+)
+
+// AuthorServiceClient is the client API for AuthorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthorServiceClient interface {
+	// Create a new author
+	CreateAuthor(ctx context.Context, in *CreateAuthorRequest, opts ...grpc.CallOption) (*CreateAuthorResponse, error)
+	// Retrieve an author by AuthorID
+	GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*GetAuthorResponse, error)
+	// Update an existing author
+	UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*UpdateAuthorResponse, error)
+	// Delete an author without posts
+	DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*DeleteAuthorResponse, error)
+	// List authors one page at a time
+	ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error)
+}
+
+type authorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthorServiceClient(cc grpc.ClientConnInterface) AuthorServiceClient {
+	return &authorServiceClient{cc}
+}
+
+func (c *authorServiceClient) CreateAuthor(ctx context.Context, in *CreateAuthorRequest, opts ...grpc.CallOption) (*CreateAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAuthorResponse)
+	err := c.cc.Invoke(ctx, AuthorService_CreateAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorServiceClient) GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*GetAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuthorResponse)
+	err := c.cc.Invoke(ctx, AuthorService_GetAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorServiceClient) UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*UpdateAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAuthorResponse)
+	err := c.cc.Invoke(ctx, AuthorService_UpdateAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorServiceClient) DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*DeleteAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAuthorResponse)
+	err := c.cc.Invoke(ctx, AuthorService_DeleteAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorServiceClient) ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuthorsResponse)
+	err := c.cc.Invoke(ctx, AuthorService_ListAuthors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthorServiceServer is the server API for AuthorService service.
+// All implementations must embed UnimplementedAuthorServiceServer
+// for forward compatibility.
+type AuthorServiceServer interface {
+	// Create a new author
+	CreateAuthor(context.Context, *CreateAuthorRequest) (*CreateAuthorResponse, error)
+	// Retrieve an author by AuthorID
+	GetAuthor(context.Context, *GetAuthorRequest) (*GetAuthorResponse, error)
+	// Update an existing author
+	UpdateAuthor(context.Context, *UpdateAuthorRequest) (*UpdateAuthorResponse, error)
+	// Delete an author without posts
+	DeleteAuthor(context.Context, *DeleteAuthorRequest) (*DeleteAuthorResponse, error)
+	// List authors one page at a time
+	ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error)
+	mustEmbedUnimplementedAuthorServiceServer()
+}
+
+// UnimplementedAuthorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthorServiceServer struct{}
+
+func (UnimplementedAuthorServiceServer) CreateAuthor(context.Context, *CreateAuthorRequest) (*CreateAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuthor not implemented")
+}
+func (UnimplementedAuthorServiceServer) GetAuthor(context.Context, *GetAuthorRequest) (*GetAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthor not implemented")
+}
+func (UnimplementedAuthorServiceServer) UpdateAuthor(context.Context, *UpdateAuthorRequest) (*UpdateAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuthor not implemented")
+}
+func (UnimplementedAuthorServiceServer) DeleteAuthor(context.Context, *DeleteAuthorRequest) (*DeleteAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthor not implemented")
+}
+func (UnimplementedAuthorServiceServer) ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuthors not implemented")
+}
+func (UnimplementedAuthorServiceServer) mustEmbedUnimplementedAuthorServiceServer() {}
+func (UnimplementedAuthorServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeAuthorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthorServiceServer will
+// result in compilation errors.
+type UnsafeAuthorServiceServer interface {
+	mustEmbedUnimplementedAuthorServiceServer()
+}
+
+func RegisterAuthorServiceServer(s grpc.ServiceRegistrar, srv AuthorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthorService_ServiceDesc, srv)
+}
+
+func _AuthorService_CreateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).CreateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_CreateAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).CreateAuthor(ctx, req.(*CreateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorService_GetAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).GetAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_GetAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).GetAuthor(ctx, req.(*GetAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorService_UpdateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).UpdateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_UpdateAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).UpdateAuthor(ctx, req.(*UpdateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorService_DeleteAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).DeleteAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_DeleteAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).DeleteAuthor(ctx, req.(*DeleteAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorService_ListAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).ListAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_ListAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).ListAuthors(ctx, req.(*ListAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthorService_ServiceDesc is the grpc.ServiceDesc for AuthorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.v1.AuthorService",
+	HandlerType: (*AuthorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAuthor",
+			Handler:    _AuthorService_CreateAuthor_Handler,
+		},
+		{
+			MethodName: "GetAuthor",
+			Handler:    _AuthorService_GetAuthor_Handler,
+		},
+		{
+			MethodName: "UpdateAuthor",
+			Handler:    _AuthorService_UpdateAuthor_Handler,
+		},
+		{
+			MethodName: "DeleteAuthor",
+			Handler:    _AuthorService_DeleteAuthor_Handler,
+		},
+		{
+			MethodName: "ListAuthors",
+			Handler:    _AuthorService_ListAuthors_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "blog.proto",
+}
+
+const (
+	TagService_ListTags_FullMethodName    = "/blog.v1.TagService/ListTags"
+	TagService_GetTag_FullMethodName      = "/blog.v1.TagService/GetTag"
+	TagService_RenameTag_FullMethodName   = "/blog.v1.TagService/RenameTag"
+	TagService_MergeTags_FullMethodName   = "/blog.v1.TagService/MergeTags"
+	TagService_SuggestTags_FullMethodName = "/blog.v1.TagService/SuggestTags"
+)
+
+// TagServiceClient is the client API for TagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TagServiceClient interface {
+	// List tags one page at a time
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// Retrieve a tag by TagID
+	GetTag(ctx context.Context, in *GetTagRequest, opts ...grpc.CallOption) (*GetTagResponse, error)
+	// Rename a tag on every post that carries it
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	// Merge one tag into another on every post
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	// Suggest existing tags for a prefix or a draft post
+	SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error)
+}
+
+type tagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTagServiceClient(cc grpc.ClientConnInterface) TagServiceClient {
+	return &tagServiceClient{cc}
+}
+
+func (c *tagServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetTag(ctx context.Context, in *GetTagRequest, opts ...grpc.CallOption) (*GetTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTagResponse)
+	err := c.cc.Invoke(ctx, TagService_GetTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, TagService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_SuggestTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TagServiceServer is the server API for TagService service.
+// All implementations must embed UnimplementedTagServiceServer
+// for forward compatibility.
+type TagServiceServer interface {
+	// List tags one page at a time
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// Retrieve a tag by TagID
+	GetTag(context.Context, *GetTagRequest) (*GetTagResponse, error)
+	// Rename a tag on every post that carries it
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	// Merge one tag into another on every post
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	// Suggest existing tags for a prefix or a draft post
+	SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error)
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+// UnimplementedTagServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTagServiceServer struct{}
+
+func (UnimplementedTagServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTagServiceServer) GetTag(context.Context, *GetTagRequest) (*GetTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTag not implemented")
+}
+func (UnimplementedTagServiceServer) RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedTagServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedTagServiceServer) SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestTags not implemented")
+}
+func (UnimplementedTagServiceServer) mustEmbedUnimplementedTagServiceServer() {}
+func (UnimplementedTagServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeTagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TagServiceServer will
+// result in compilation errors.
+type UnsafeTagServiceServer interface {
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+func RegisterTagServiceServer(s grpc.ServiceRegistrar, srv TagServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTagServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TagService_ServiceDesc, srv)
+}
+
+func _TagService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetTag(ctx, req.(*GetTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_SuggestTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).SuggestTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_SuggestTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).SuggestTags(ctx, req.(*SuggestTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.v1.TagService",
+	HandlerType: (*TagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTags",
+			Handler:    _TagService_ListTags_Handler,
+		},
+		{
+			MethodName: "GetTag",
+			Handler:    _TagService_GetTag_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _TagService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _TagService_MergeTags_Handler,
+		},
+		{
+			MethodName: "SuggestTags",
+			Handler:    _TagService_SuggestTags_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},